@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/ottemo/foundation/env"
 )
 
@@ -12,6 +14,8 @@ const (
 	ConstSessionCookieName     = "OTTEMOSESSION" // cookie name which should contain sessionID
 	ConstSessionKeyTimeZone    = "timeZone"      // session key for setting time zone
 
+	ConstContextKeyStoreID = "store_id" // call context key for id of store request was made to
+
 	ConstGETAuthParamName            = "auth"
 	ConstConfigPathStoreRootLogin    = "general.store.root_login"
 	ConstConfigPathStoreRootPassword = "general.store.root_password"
@@ -42,9 +46,22 @@ type StructRestRedirect struct {
 	DoRedirect bool
 }
 
+// StructSessionInfo is a structure to hold information about session of particular owner (visitor)
+type StructSessionInfo struct {
+	ID        string
+	UpdatedAt time.Time
+}
+
 // FuncAPIHandler is an API handler callback function
 type FuncAPIHandler func(context InterfaceApplicationContext) (interface{}, error)
 
+// FuncStoreResolver is a callback function returning id of store request was made to (blank for default store)
+type FuncStoreResolver func(context InterfaceApplicationContext) string
+
+// FuncRouteGuard is a callback function checking API resource (route pattern handler registered on) is currently
+// available, routes could be disabled at runtime
+type FuncRouteGuard func(resource string) bool
+
 // FuncAPIResultHandler is an API result handler callback function.
 // It suppesod to be called by async API handler like api.APIAsyncHandler.
 type FuncAPIResultHandler func(context InterfaceApplicationContext, result interface{}, err error)
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	apicontext "github.com/ottemo/foundation/api/context"
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
 )
@@ -17,21 +18,9 @@ import (
 // False. Any other value returns an error.
 func StartSession(context InterfaceApplicationContext) (InterfaceSession, error) {
 
-	request := context.GetRequest()
-	// use secure cookies by default
-	var flagSecure = true
-	var tmpSecure = ""
-	if iniConfig := env.GetIniConfig(); iniConfig != nil {
-		if iniValue := iniConfig.GetValue("secure_cookie", tmpSecure); iniValue != "" {
-			tmpSecure = iniValue
-			flagSecure, _ = strconv.ParseBool(tmpSecure)
-		}
-	}
-
 	// old method - HTTP specific
-	if _, ok := request.(*http.Request); ok {
-		responseWriter := context.GetResponseWriter()
-		if responseWriter, ok := responseWriter.(http.ResponseWriter); ok {
+	if _, ok := context.GetRequest().(*http.Request); ok {
+		if _, ok := context.GetResponseWriter().(http.ResponseWriter); ok {
 			// check session-cookie or header
 			if sessionID := context.GetRequestSetting(ConstSessionCookieName); sessionID != nil {
 				sessionID := utils.InterfaceToString(sessionID)
@@ -47,19 +36,7 @@ func StartSession(context InterfaceApplicationContext) (InterfaceSession, error)
 				return nil, env.ErrorDispatch(err)
 			}
 
-			// Session Cookie Declaration
-			// - expires in 1 year
-			// - Domain defaults to the full subdomain path
-			cookieExpires := time.Now().Add(365 * 24 * time.Hour)
-			var cookie = &http.Cookie{
-				Name:     ConstSessionCookieName,
-				Value:    result.GetID(),
-				Path:     "/",
-				Secure:   flagSecure,
-				HttpOnly: true,
-				Expires:  cookieExpires,
-			}
-			http.SetCookie(responseWriter, cookie)
+			setSessionCookie(context, result.GetID())
 
 			return result, nil
 		}
@@ -86,6 +63,118 @@ func StartSession(context InterfaceApplicationContext) (InterfaceSession, error)
 	return sessionInstance, err
 }
 
+// GetExistingSession returns session request refers to without making a new one
+//   - transient session, which is not stored and has blank id, is returned if request has no valid session
+func GetExistingSession(context InterfaceApplicationContext) InterfaceSession {
+	if sessionID := context.GetRequestSetting(ConstSessionCookieName); sessionID != nil && currentSessionService != nil {
+		if sessionInstance, err := currentSessionService.Get(utils.InterfaceToString(sessionID), false); err == nil {
+			return sessionInstance
+		}
+	}
+	return &transientSession{data: make(map[string]interface{})}
+}
+
+// setSessionCookie passes session id to client within session cookie
+//   - secure cookie is used by default, it could be changed with "secure_cookie" ini value
+//   - previously set session cookie of response is replaced, blank session id removes cookie on client
+func setSessionCookie(context InterfaceApplicationContext, sessionID string) {
+	responseWriter, ok := context.GetResponseWriter().(http.ResponseWriter)
+	if !ok {
+		return
+	}
+
+	var cookies []string
+	for _, value := range responseWriter.Header()["Set-Cookie"] {
+		if !strings.HasPrefix(value, ConstSessionCookieName+"=") {
+			cookies = append(cookies, value)
+		}
+	}
+	responseWriter.Header()["Set-Cookie"] = cookies
+
+	var flagSecure = true
+	if iniConfig := env.GetIniConfig(); iniConfig != nil {
+		if iniValue := iniConfig.GetValue("secure_cookie", ""); iniValue != "" {
+			flagSecure, _ = strconv.ParseBool(iniValue)
+		}
+	}
+
+	// Session Cookie Declaration
+	// - expires in 1 year
+	// - Domain defaults to the full subdomain path
+	cookieExpires := time.Now().Add(365 * 24 * time.Hour)
+	var cookie = &http.Cookie{
+		Name:     ConstSessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		Secure:   flagSecure,
+		HttpOnly: true,
+		Expires:  cookieExpires,
+	}
+	if sessionID == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(responseWriter, cookie)
+}
+
+// FinishSession passes session token to client for session services keeping session data on client side, it should
+// be called after request handler but before response output
+func FinishSession(context InterfaceApplicationContext) error {
+	tokenizer, ok := currentSessionService.(InterfaceSessionTokenizer)
+	if !ok {
+		return nil
+	}
+
+	session := context.GetSession()
+	if session == nil {
+		return nil
+	}
+
+	token, err := tokenizer.GetToken(session.GetID())
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if _, ok := context.GetRequest().(*http.Request); ok {
+		setSessionCookie(context, token)
+	}
+	_ = context.SetResponseSetting(ConstSessionCookieName, token)
+
+	return nil
+}
+
+// RegenerateSession replaces session of current context with a new one keeping session data, it should be called
+// on privilege change (login) to prevent session fixation
+func RegenerateSession(context InterfaceApplicationContext) (InterfaceSession, error) {
+	currentSession := context.GetSession()
+	if currentSession == nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d7a41c5e-9b3f-4e28-a6d0-2c8f5b1e7a94", "context has no session to regenerate")
+	}
+
+	newSession, err := currentSessionService.Regenerate(currentSession.GetID())
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// objects bound to session id (like guest cart) should follow the new one
+	env.Event("session.regenerate", map[string]interface{}{
+		"session":  newSession,
+		"previous": currentSession.GetID(),
+		"context":  context,
+	})
+
+	if err := context.SetSession(newSession); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// client could use cookie or header to pass session id, so both are updated
+	if _, ok := context.GetRequest().(*http.Request); ok {
+		setSessionCookie(context, newSession.GetID())
+	}
+	_ = context.SetResponseSetting(ConstSessionCookieName, newSession.GetID())
+
+	return newSession, nil
+}
+
 // NewSession returns new session instance
 func NewSession() (InterfaceSession, error) {
 	return currentSessionService.New()
@@ -103,6 +192,44 @@ func GetSessionByID(sessionID string, create bool) (InterfaceSession, error) {
 	return sessionInstance, err
 }
 
+// ResolveStore returns id of store request was made to, blank value means default store
+func ResolveStore(context InterfaceApplicationContext) string {
+	if currentStoreResolver == nil {
+		return ""
+	}
+	return currentStoreResolver(context)
+}
+
+// GetCurrentStoreID returns id of store current call stack works for, blank value means default store (or no request)
+func GetCurrentStoreID() string {
+	storeID, _ := LookupCurrentStoreID()
+	return storeID
+}
+
+// LookupCurrentStoreID returns id of store current call stack works for, false flag means call stack was not made
+// by request (cron task, event handler of background job, etc.), so store is not known at all
+func LookupCurrentStoreID() (string, bool) {
+	if callContext := apicontext.GetContext(); callContext != nil {
+		if storeID, present := callContext[ConstContextKeyStoreID]; present {
+			return utils.InterfaceToString(storeID), true
+		}
+	}
+	return "", false
+}
+
+// IsRouteEnabled checks API resource (route pattern without leading slash) is currently available
+func IsRouteEnabled(resource string) bool {
+	if currentRouteGuard == nil {
+		return true
+	}
+	return currentRouteGuard(resource)
+}
+
+// IsRouteSessionless checks API resource (route pattern without leading slash) should be served without session
+func IsRouteSessionless(resource string) bool {
+	return sessionlessRoutes[resource]
+}
+
 // ValidateAdminRights returns nil if session contains admin rights
 func ValidateAdminRights(context InterfaceApplicationContext) error {
 
@@ -150,9 +277,18 @@ func IsAdminSession(context InterfaceApplicationContext) bool {
 
 // AsyncHandler runs FuncAPIHandler in async.
 // If resultHandler declared, the result of call will be put to it.
+// Application waits for started handlers on shutdown, and refuses new ones.
 func AsyncHandler(nextHandler FuncAPIHandler, resultHandler FuncAPIResultHandler) FuncAPIHandler {
 	return func(context InterfaceApplicationContext) (interface{}, error) {
+		finish, err := StartOperation()
+		if err != nil {
+			context.SetResponseStatus(http.StatusServiceUnavailable)
+			return nil, err
+		}
+
 		go (func(){
+			defer finish()
+
 			if resultHandler != nil {
 				result, err := nextHandler(context)
 				resultHandler(context, result, err)
@@ -3,6 +3,7 @@ package api
 import (
 	"io"
 	"net/http"
+	"time"
 )
 
 // InterfaceSessionService is an interface to access session managing service
@@ -21,6 +22,19 @@ type InterfaceSessionService interface {
 
 	GetKey(sessionID string, key string) interface{}
 	SetKey(sessionID string, key string, value interface{})
+
+	Regenerate(sessionID string) (InterfaceSession, error)
+
+	SetOwner(sessionID string, ownerID string) error
+	GetOwner(sessionID string) string
+	ListOwnerSessions(ownerID string) []StructSessionInfo
+	CloseOwnerSessions(ownerID string, exceptSessionID string) error
+}
+
+// InterfaceSessionTokenizer is an optional InterfaceSessionService extension for services keeping session data on
+// client side, session token is passed to client instead of session id at the end of request
+type InterfaceSessionTokenizer interface {
+	GetToken(sessionID string) (string, error)
 }
 
 // InterfaceSession is an interface represents private storage for particular API request
@@ -41,6 +55,8 @@ type InterfaceRestService interface {
 	GetName() string
 
 	Run() error
+	Stop(timeout time.Duration) error
+
 	GET(resource string, handler FuncAPIHandler)
 	PUT(resource string, handler FuncAPIHandler)
 	POST(resource string, handler FuncAPIHandler)
@@ -8,6 +8,9 @@ import (
 var (
 	currentRestService          InterfaceRestService    // currently registered RESTFul service in system
 	currentSessionService       InterfaceSessionService // currently registered session service in system
+	currentStoreResolver        FuncStoreResolver       // currently registered store resolver in system
+	currentRouteGuard           FuncRouteGuard          // currently registered route guard in system
+	sessionlessRoutes           = make(map[string]bool) // API resources served without session
 	callbacksOnRestServiceStart = []func() error{}      // set of callback function on RESTFul service start
 )
 
@@ -48,6 +51,33 @@ func RegisterSessionService(newService InterfaceSessionService) error {
 	return nil
 }
 
+// RegisterStoreResolver registers function resolving store of request (by host header, etc.) in the system
+//   - will cause error if there are couple candidates for that role
+func RegisterStoreResolver(resolver FuncStoreResolver) error {
+	if currentStoreResolver != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4c9e2a7f-d318-4b65-a0e7-9f3b6d1c8a52", "store resolver was already registered")
+	}
+	currentStoreResolver = resolver
+	return nil
+}
+
+// RegisterRouteGuard registers function checking API resources availability in the system
+//   - will cause error if there are couple candidates for that role
+func RegisterRouteGuard(guard FuncRouteGuard) error {
+	if currentRouteGuard != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8e5a2c7f-b419-4d63-9a0e-3f7c1d6b2e84", "route guard was already registered")
+	}
+	currentRouteGuard = guard
+	return nil
+}
+
+// RegisterSessionlessRoute marks API resource (route pattern without leading slash) to be served without session, so
+// requests like health probes do not make sessions and cookies
+//   - it should be called along with route registration, within RegisterOnRestServiceStart() callback
+func RegisterSessionlessRoute(resource string) {
+	sessionlessRoutes[resource] = true
+}
+
 // GetRestService returns currently using RESTFul service implementation
 func GetRestService() InterfaceRestService {
 	return currentRestService
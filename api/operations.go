@@ -0,0 +1,74 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// background operations application should wait for on shutdown
+var (
+	operationsCount    int
+	operationsStopping bool
+	operationsMutex    sync.Mutex
+)
+
+// StartOperation registers background operation (async API call, scheduled task, etc.) which application should
+// wait for on shutdown, returned function should be called on operation finish
+//   - error returned if application is stopping, so operation should not be started
+func StartOperation() (func(), error) {
+	operationsMutex.Lock()
+	defer operationsMutex.Unlock()
+
+	if operationsStopping {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8e3f1b6d-c952-4a07-b1d4-6f2a9e7c3b58", "application is stopping, operation can not be started")
+	}
+
+	operationsCount++
+
+	var once sync.Once
+	finish := func() {
+		once.Do(func() {
+			operationsMutex.Lock()
+			operationsCount--
+			operationsMutex.Unlock()
+		})
+	}
+
+	return finish, nil
+}
+
+// IsStopping returns true if application is waiting for background operations to stop
+func IsStopping() bool {
+	operationsMutex.Lock()
+	defer operationsMutex.Unlock()
+	return operationsStopping
+}
+
+// StopOperations prevents new background operations start and waits for running ones to finish,
+// returns amount of operations still running after timeout
+func StopOperations(timeout time.Duration) int {
+	operationsMutex.Lock()
+	operationsStopping = true
+	operationsMutex.Unlock()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		operationsMutex.Lock()
+		count := operationsCount
+		operationsMutex.Unlock()
+
+		if count == 0 || !time.Now().Before(deadline) {
+			return count
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// ResumeOperations allows background operations to start again (application restarted)
+func ResumeOperations() {
+	operationsMutex.Lock()
+	operationsStopping = false
+	operationsMutex.Unlock()
+}
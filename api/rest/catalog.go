@@ -0,0 +1,37 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// setupErrorCatalog registers errors of API layer within error catalog
+func setupErrorCatalog() error {
+	items := []env.StructErrorCatalogItem{
+		{
+			Code:          "2f3438ba-7fb7-4811-b8a5-7acf36910d3d",
+			Name:          "admin_rights_required",
+			Module:        "api",
+			HTTPStatus:    http.StatusForbidden,
+			Message:       "Administrator rights are required for this operation.",
+			Documentation: "Request was made to admin only endpoint within session which have no admin rights. Login as administrator and repeat the request.",
+		},
+		{
+			Code:          "28d7ef2f-631f-4f38-a916-579bf822908b",
+			Name:          "api_call_failed",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusInternalServerError,
+			Message:       "Request can not be processed due to internal error.",
+			Documentation: "API handler failed unexpectedly. Use trace ID to find error details within logs.",
+		},
+	}
+
+	for _, item := range items {
+		if err := env.ErrorRegisterCatalogItem(item); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
@@ -3,15 +3,18 @@ package rest
 import (
 	"io"
 	"net/http"
+	"sync"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/metrics"
 )
 
 // Package global constants
 const (
-	ConstDebugLogStorage = "rest.log" // log storage for debug log records
+	ConstDebugLogStorage  = "rest.log" // log storage for debug log records
+	ConstLogHiddenContent = "******"   // replacement for request content which should not be logged
 
 	ConstErrorModule = "api/rest"
 	ConstErrorLevel  = env.ConstErrorLevelService
@@ -20,6 +23,8 @@ const (
 	ConstConfigPathAPILog        = "api.log"
 	ConstConfigPathAPILogEnable  = "api.log.enable"
 	ConstConfigPathAPILogExclude = "api.log.exclude"
+
+	ConstTraceIDHeader = "X-Trace-ID" // response header containing identifier of request within logs
 )
 
 // DefaultRestService is a default implementer of InterfaceRestService
@@ -28,6 +33,23 @@ type DefaultRestService struct {
 	ListenOn string
 	Router   *httprouter.Router
 	Handlers []string
+
+	server *http.Server
+}
+
+// Package global variables
+var (
+	metricRequests         = metrics.NewCounter("ottemo_http_requests_total", "Amount of served API requests.", "method", "route", "status")
+	metricRequestDuration  = metrics.NewHistogram("ottemo_http_request_duration_seconds", "API requests processing duration.", nil, "method", "route")
+	metricRequestsInFlight = metrics.NewGauge("ottemo_http_requests_in_flight", "Amount of API requests being processed.")
+
+	serverMutex sync.Mutex // synchronizes HTTP server start and stop
+)
+
+// ResponseWriter is a wrapper around http.ResponseWriter which remembers response status
+type ResponseWriter struct {
+	http.ResponseWriter
+	status int
 }
 
 // DefaultRestApplicationContext is a structure to hold API request related information
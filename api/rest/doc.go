@@ -23,6 +23,7 @@ is a HTTP Request content. Notice that URL arguments are both - REST resource re
 	  categoryID="5488485b49c43d4283000067", action="count", sku="~10" (with string values)
 
 Session specification addressed to "OTTEMOSESSION=[sessionID]" COOKIE value. Each request with unspecified session will
-be supplied with new one session. SessionID will be returned in mentioned COOKIE value.
+be supplied with new one session. SessionID will be returned in mentioned COOKIE value. Stateless session service could
+return session token instead of SessionID, it is updated at the end of each request.
 */
 package rest
@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// newTraceID returns random identifier for request, so error reported by client could be found within logs
+func newTraceID() string {
+	data := make([]byte, 16)
+	if _, err := rand.Read(data); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(data)
+}
+
+// makeErrorResponse converts error to structure API clients receive, and returns HTTP status for it
+//   - message is rendered from error catalog template if error code registered there
+//   - returned status is 0 for errors not registered within error catalog
+func makeErrorResponse(err error, traceID string) (map[string]interface{}, int) {
+	if _, ok := err.(env.InterfaceOttemoError); !ok {
+		err = env.ErrorDispatch(err)
+	}
+
+	ottemoError, ok := err.(env.InterfaceOttemoError)
+	if !ok {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "bdbb8627-18e8-4969-a048-c8b482235f39", "can't convert error to ottemoError")
+		return map[string]interface{}{
+			"code":     "896810b9-9b54-471a-830c-b77b33379adc",
+			"name":     "",
+			"message":  err.Error(),
+			"level":    env.ConstErrorLevelAPI,
+			"details":  map[string]interface{}{},
+			"trace_id": traceID,
+		}, 0
+	}
+
+	details := ottemoError.ErrorDetails()
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+
+	result := map[string]interface{}{
+		"code":     ottemoError.ErrorCode(),
+		"name":     "",
+		"message":  ottemoError.Error(),
+		"level":    ottemoError.ErrorLevel(),
+		"details":  details,
+		"trace_id": traceID,
+	}
+
+	catalogItem, present := env.ErrorGetCatalogItem(ottemoError.ErrorCode())
+	if !present {
+		return result, 0
+	}
+
+	result["name"] = catalogItem.Name
+	if catalogItem.Message != "" {
+		message, err := utils.TextTemplate(catalogItem.Message, details)
+		if err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7c2d5e91-3f4b-4a8e-b6d0-1e9f8a3c7b25", "error catalog message for '"+catalogItem.Name+"' can't be rendered: "+err.Error())
+		} else {
+			result["message"] = message
+		}
+	}
+
+	return result, catalogItem.HTTPStatus
+}
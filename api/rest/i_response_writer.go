@@ -0,0 +1,22 @@
+package rest
+
+// WriteHeader sends HTTP response header with given status code and remembers it
+func (it *ResponseWriter) WriteHeader(status int) {
+	if it.status == 0 {
+		it.status = status
+	}
+	it.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes data to connection, implicitly sending 200 status if it was not sent before
+func (it *ResponseWriter) Write(data []byte) (int, error) {
+	if it.status == 0 {
+		it.status = 200
+	}
+	return it.ResponseWriter.Write(data)
+}
+
+// GetStatus returns HTTP status sent to client, or 0 if nothing was sent yet
+func (it *ResponseWriter) GetStatus() int {
+	return it.status
+}
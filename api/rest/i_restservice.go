@@ -1,6 +1,7 @@
 package rest
 
 import (
+	gocontext "context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,9 +34,28 @@ func (it *DefaultRestService) GetName() string {
 // 1. Handles the Referrer cookie
 // 1. Calls handler on context
 // 1. Handle redirects and response encoding (json/xml)
-func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprouter.Handle {
+// 1. Updates request metrics for route handler registered on
+func (it *DefaultRestService) wrappedHandler(route string, handler api.FuncAPIHandler) httprouter.Handle {
 	// httprouter supposes other format of handler than we use, so we need wrapper
-	wrappedHandler := func(resp http.ResponseWriter, req *http.Request, params httprouter.Params) {
+	wrappedHandler := func(responseWriter http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		resp := &ResponseWriter{ResponseWriter: responseWriter}
+
+		// request metrics, route is a pattern handler registered on, so amount of label values is limited
+		metricRequestsInFlight.Inc()
+		defer func(startTime time.Time) {
+			status := resp.GetStatus()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			metricRequestsInFlight.Dec()
+			metricRequests.Inc(req.Method, route, strconv.Itoa(status))
+			metricRequestDuration.ObserveDuration(startTime, req.Method, route)
+		}(time.Now())
+
+		// trace id allows to find request within logs by error client received
+		traceID := newTraceID()
+		resp.Header().Set(ConstTraceIDHeader, traceID)
 
 		// catching API handler fails
 		defer func() {
@@ -180,15 +201,22 @@ func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprou
 			}
 		}
 
-		// starting session for request
-		currentSession, err := api.StartSession(applicationContext)
-		if err != nil {
-			err = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c8a3bbf8-215f-4dff-b0e7-3d0d102ad02d", "Session init fail: "+err.Error())
-			_ = env.ErrorDispatch(err)
-		}
+		// starting session for request, sessionless routes (health probes, metrics) only take existing one
+		sessionless := api.IsRouteSessionless(strings.TrimPrefix(route, "/"))
 
-		utils.SyncScalarLock(currentSession.GetID())
-		defer utils.SyncScalarUnlock(currentSession.GetID())
+		var currentSession api.InterfaceSession
+		if sessionless {
+			currentSession = api.GetExistingSession(applicationContext)
+		} else {
+			currentSession, err = api.StartSession(applicationContext)
+			if err != nil {
+				err = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c8a3bbf8-215f-4dff-b0e7-3d0d102ad02d", "Session init fail: "+err.Error())
+				_ = env.ErrorDispatch(err)
+			}
+
+			utils.SyncScalarLock(currentSession.GetID())
+			defer utils.SyncScalarUnlock(currentSession.GetID())
+		}
 
 		applicationContext.Session = currentSession
 
@@ -206,14 +234,20 @@ func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprou
 				}
 			}
 			if allowLog {
-				env.Log(ConstDebugLogStorage, "REQUEST_"+debugRequestIdentifier, fmt.Sprintf("%s [%s]\n%#v\n", req.RequestURI, currentSession.GetID(), content))
+				// requests changing secret config values should not get to logs
+				logContent := content
+				if configPath, present := reqArguments["path"]; present && strings.Contains(req.URL.Path, "config/") && env.ConfigIsSecret(configPath) {
+					logContent = ConstLogHiddenContent
+				}
+
+				env.Log(ConstDebugLogStorage, "REQUEST_"+debugRequestIdentifier, fmt.Sprintf("%s [%s]\n%#v\n", req.RequestURI, currentSession.GetID(), logContent))
 				env.LogEvent(env.LogFields{
 					"request_thread_id": debugRequestIdentifier,
 					"session_id":        currentSession.GetID(),
 
 					"uri":          req.RequestURI,
 					"verb":         req.Method,
-					"content":      content,
+					"content":      logContent,
 					"agent":        req.UserAgent(),
 					"clientip":     req.RemoteAddr,
 					"httpversion":  req.Proto,
@@ -232,10 +266,20 @@ func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprou
 		context.MakeContext(func() {
 			if callContext := context.GetContext(); callContext != nil {
 				callContext["is_admin"] = api.IsAdminSession(applicationContext)
+
+				storeID := api.ResolveStore(applicationContext)
+				callContext[api.ConstContextKeyStoreID] = storeID
+				applicationContext.SetContextValue(api.ConstContextKeyStoreID, storeID)
 			} else {
 				err = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "6b94a499-9d71-403e-9f67-06fd90d6250d", "can not get context for API handler")
 			}
 
+			// resource could be disabled at runtime (extension was disabled), it responds as not existing one then
+			if err == nil && !api.IsRouteEnabled(strings.TrimPrefix(route, "/")) {
+				applicationContext.SetResponseStatusNotFound()
+				err = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c2f7a9e4-5b16-4d83-8e0a-6d1b3f9c7e25", "resource "+route+" is not available")
+			}
+
 			if err == nil {
 				// API handler processing
 				result, err = handler(applicationContext)
@@ -247,6 +291,7 @@ func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprou
 			env.LogEvent(env.LogFields{
 				"request_thread_id": debugRequestIdentifier,
 				"session_id":        currentSession.GetID(),
+				"trace_id":          traceID,
 
 				"uri":        req.RequestURI,
 				"error_dump": err,
@@ -262,6 +307,13 @@ func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprou
 		env.Event("api.response", eventData)
 		result = eventData["response"]
 
+		// session could be kept on client side, so it goes to response headers
+		if !sessionless {
+			if err := api.FinishSession(applicationContext); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+
 		// result conversion before output
 		redirectLocation := ""
 		if redirect, ok := result.(api.StructRestRedirect); ok {
@@ -282,23 +334,12 @@ func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprou
 			if resp.Header().Get("Content-Type") == "application/json" {
 				var errorMsg map[string]interface{}
 				if err != nil {
-					if _, ok := err.(env.InterfaceOttemoError); !ok {
-						err = env.ErrorDispatch(err)
-					}
+					var status int
+					errorMsg, status = makeErrorResponse(err, traceID)
 
-					if ottemoError, ok := err.(env.InterfaceOttemoError); ok {
-						errorMsg = map[string]interface{}{
-							"message": ottemoError.Error(),
-							"level":   ottemoError.ErrorLevel(),
-							"code":    ottemoError.ErrorCode(),
-						}
-					} else {
-						_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "bdbb8627-18e8-4969-a048-c8b482235f39", "can't convert error to ottemoError")
-						errorMsg = map[string]interface{}{
-							"message": err.Error(),
-							"level":   env.ConstErrorLevelAPI,
-							"code":    "896810b9-9b54-471a-830c-b77b33379adc",
-						}
+					// handler could already set own status, it takes precedence over catalog one
+					if status != 0 && resp.GetStatus() == 0 {
+						resp.WriteHeader(status)
 					}
 				}
 
@@ -349,7 +390,7 @@ func (it *DefaultRestService) wrappedHandler(handler api.FuncAPIHandler) httprou
 // GET is a wrapper for the HTTP GET verb
 func (it *DefaultRestService) GET(resource string, handler api.FuncAPIHandler) {
 	path := "/" + resource
-	it.Router.GET(path, it.wrappedHandler(handler))
+	it.Router.GET(path, it.wrappedHandler(path, handler))
 
 	it.Handlers = append(it.Handlers, path+" {GET}")
 }
@@ -357,7 +398,7 @@ func (it *DefaultRestService) GET(resource string, handler api.FuncAPIHandler) {
 // PUT is a wrapper for the HTTP PUT verb
 func (it *DefaultRestService) PUT(resource string, handler api.FuncAPIHandler) {
 	path := "/" + resource
-	it.Router.PUT(path, it.wrappedHandler(handler))
+	it.Router.PUT(path, it.wrappedHandler(path, handler))
 
 	it.Handlers = append(it.Handlers, path+" {PUT}")
 }
@@ -365,7 +406,7 @@ func (it *DefaultRestService) PUT(resource string, handler api.FuncAPIHandler) {
 // POST is a wrapper for the HTTP POST verb
 func (it *DefaultRestService) POST(resource string, handler api.FuncAPIHandler) {
 	path := "/" + resource
-	it.Router.POST(path, it.wrappedHandler(handler))
+	it.Router.POST(path, it.wrappedHandler(path, handler))
 
 	it.Handlers = append(it.Handlers, path+" {POST}")
 }
@@ -373,7 +414,7 @@ func (it *DefaultRestService) POST(resource string, handler api.FuncAPIHandler)
 // DELETE is a wrapper for the HTTP DELETE verb
 func (it *DefaultRestService) DELETE(resource string, handler api.FuncAPIHandler) {
 	path := "/" + resource
-	it.Router.DELETE(path, it.wrappedHandler(handler))
+	it.Router.DELETE(path, it.wrappedHandler(path, handler))
 
 	it.Handlers = append(it.Handlers, path+" {DELETE}")
 }
@@ -406,10 +447,38 @@ func (it DefaultRestService) ServeHTTP(responseWriter http.ResponseWriter, reque
 // Run is the Ottemo REST server startup function, analogous to "ListenAndServe"
 func (it *DefaultRestService) Run() error {
 	fmt.Println("REST API Service [HTTPRouter] starting to listen on " + it.ListenOn)
-	err := http.ListenAndServe(it.ListenOn, it)
-	if err != nil {
+
+	serverMutex.Lock()
+	server := &http.Server{Addr: it.ListenOn, Handler: it}
+	it.server = server
+	serverMutex.Unlock()
+
+	err := server.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
 		return env.ErrorDispatch(err)
 	}
 
 	return nil
 }
+
+// Stop closes server listener, so new connections are not accepted, and waits for in-flight requests
+// up to given timeout
+func (it *DefaultRestService) Stop(timeout time.Duration) error {
+	serverMutex.Lock()
+	server := it.server
+	it.server = nil
+	serverMutex.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "f4a82d6c-3b1e-4c97-8d05-a7e9c3b16f42", "in-flight requests were not finished in "+timeout.String()+": "+err.Error())
+	}
+
+	return nil
+}
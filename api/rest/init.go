@@ -20,6 +20,7 @@ func init() {
 		_ = env.ErrorDispatch(err)
 	}
 	env.RegisterOnConfigIniStart(instance.startup)
+	env.RegisterOnConfigIniStart(setupErrorCatalog)
 }
 
 // service pre-initialization stuff
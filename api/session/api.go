@@ -0,0 +1,59 @@
+package session
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// init registers package API
+func init() {
+	api.RegisterOnRestServiceStart(setupAPI)
+}
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("session/storages", api.IsAdminHandler(APIListStorages))
+	service.POST("session/migrate", api.IsAdminHandler(APIMigrateSessions))
+
+	return nil
+}
+
+// APIListStorages returns session storages available for migration and storage of running session service
+//   - admin rights are required
+func APIListStorages(context api.InterfaceApplicationContext) (interface{}, error) {
+	return map[string]interface{}{
+		"current":  SessionService.GetName(),
+		"storages": GetStorages(),
+	}, nil
+}
+
+// APIMigrateSessions copies sessions from one storage to another
+//   - "from" and "to" storage names should be specified
+//   - admin rights are required
+func APIMigrateSessions(context api.InterfaceApplicationContext) (interface{}, error) {
+	requestData, err := api.GetRequestContentAsMap(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	from := utils.InterfaceToString(requestData["from"])
+	to := utils.InterfaceToString(requestData["to"])
+	if from == "" || to == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "0e6c3a9f-2b71-4d58-9f4e-b8d1a7c5e362", "'from' and 'to' storages should be specified")
+	}
+
+	count, err := MigrateSessions(from, to)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return map[string]interface{}{
+		"from":     from,
+		"to":       to,
+		"migrated": count,
+	}, nil
+}
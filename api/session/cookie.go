@@ -0,0 +1,201 @@
+// "cookie.go" contains session token encoding routines for a session service keeping session data within client
+// cookie, token is encrypted and signed with the first of cookie keys, other keys are accepted to allow key rotation
+
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// cookieKey is a set of keys derived from secret to encrypt and sign session tokens
+type cookieKey struct {
+	id         string
+	encryptKey []byte
+	signKey    []byte
+}
+
+// cookiePayload is a session representation within session token
+type cookiePayload struct {
+	ID        string                 `json:"i"`
+	Data      map[string]interface{} `json:"d"`
+	UpdatedAt time.Time              `json:"u"`
+	OwnerID   string                 `json:"o,omitempty"`
+	ExpiresAt time.Time              `json:"e"`
+}
+
+// cookie keys used by token routines, first key is current one
+var (
+	cookieKeys      []*cookieKey
+	cookieKeysMutex sync.RWMutex
+)
+
+// deriveKey makes HMAC-SHA256 of given data with given key
+func deriveKey(key []byte, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// setCookieKeys updates keys for session tokens, first secret is used to make new tokens, others are accepted only
+//   - application crypt key is used if no secrets given
+func setCookieKeys(secrets []string) {
+	var keys []*cookieKey
+	for _, secret := range secrets {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			keys = append(keys, newCookieKey([]byte(secret)))
+		}
+	}
+
+	if len(keys) == 0 {
+		keys = append(keys, newCookieKey(utils.GetKey()))
+	}
+
+	cookieKeysMutex.Lock()
+	cookieKeys = keys
+	cookieKeysMutex.Unlock()
+}
+
+// newCookieKey makes token keys from given secret
+func newCookieKey(secret []byte) *cookieKey {
+	hash := sha256.Sum256(secret)
+
+	return &cookieKey{
+		id:         hex.EncodeToString(hash[:4]),
+		encryptKey: deriveKey(secret, []byte("encrypt")),
+		signKey:    deriveKey(secret, []byte("sign")),
+	}
+}
+
+// getCookieKey returns current cookie key or key by given id, nil if not found
+func getCookieKey(keyID string) *cookieKey {
+	cookieKeysMutex.RLock()
+	defer cookieKeysMutex.RUnlock()
+
+	if len(cookieKeys) == 0 {
+		return nil
+	}
+
+	if keyID == "" {
+		return cookieKeys[0]
+	}
+
+	for _, key := range cookieKeys {
+		if key.id == keyID {
+			return key
+		}
+	}
+	return nil
+}
+
+// isCookieToken checks if given value is a session token but not a session id
+func isCookieToken(value string) bool {
+	return strings.HasPrefix(value, ConstCookieTokenPrefix)
+}
+
+// encodeCookieToken makes session token from session instance, token is not accepted after given expiration time
+//   - token format is "[prefix][key id].[base64: nonce + encrypted data + signature]", data is encrypted with key
+//     derived from nonce, so tokens do not share key stream
+func encodeCookieToken(sessionInstance *DefaultSessionContainer, expiresAt time.Time) (string, error) {
+	key := getCookieKey("")
+	if key == nil {
+		setCookieKeys(nil)
+		key = getCookieKey("")
+	}
+
+	sessionInstance.mutex.Lock()
+	data, err := json.Marshal(cookiePayload{
+		ID:        sessionInstance.id,
+		Data:      sessionInstance.Data,
+		UpdatedAt: sessionInstance.UpdatedAt,
+		OwnerID:   sessionInstance.OwnerID,
+		ExpiresAt: expiresAt,
+	})
+	sessionInstance.mutex.Unlock()
+
+	if err != nil {
+		return "", env.ErrorDispatch(err)
+	}
+
+	nonce := make([]byte, ConstCookieNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", env.ErrorDispatch(err)
+	}
+
+	encryptedData, err := utils.EncryptDataWithKey(data, deriveKey(key.encryptKey, nonce))
+	if err != nil {
+		return "", env.ErrorDispatch(err)
+	}
+
+	header := ConstCookieTokenPrefix + key.id + "."
+	body := append(nonce, encryptedData...)
+	signature := deriveKey(key.signKey, append([]byte(header), body...))
+
+	return header + base64.RawURLEncoding.EncodeToString(append(body, signature...)), nil
+}
+
+// decodeCookieToken verifies session token and makes session instance from it
+func decodeCookieToken(token string) (*DefaultSessionContainer, error) {
+	if !isCookieToken(token) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3b8f6d2a-c415-4e97-9a0d-7e2c5f1b8d43", "not a session token")
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(token, ConstCookieTokenPrefix), ".", 2)
+	if len(parts) != 2 {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "e9a27c4f-1d83-4b56-b0e8-2f6d9c3a7e15", "invalid session token")
+	}
+
+	key := getCookieKey(parts[0])
+	if key == nil {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "74d1e8b3-6f29-4a0c-8b57-c3e9a2d6f184", "session token key is unknown or retired")
+	}
+
+	value, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(value) < ConstCookieNonceSize+sha256.Size {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b5c38e1d-9a74-4f26-8d0b-6e1f7a3c9d52", "invalid session token")
+	}
+
+	body := value[:len(value)-sha256.Size]
+	signature := value[len(value)-sha256.Size:]
+	header := ConstCookieTokenPrefix + key.id + "."
+
+	if !hmac.Equal(signature, deriveKey(key.signKey, append([]byte(header), body...))) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "2f9d6a1c-e837-4b05-a4c2-8d5b3e7f1a69", "session token signature mismatch")
+	}
+
+	nonce := body[:ConstCookieNonceSize]
+	data, err := utils.DecryptDataWithKey(body[ConstCookieNonceSize:], deriveKey(key.encryptKey, nonce))
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var payload cookiePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if payload.ID == "" || !time.Now().Before(payload.ExpiresAt) || time.Now().Sub(payload.UpdatedAt).Seconds() >= ConstSessionLifeTime {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c6e04b9f-3d52-4a81-9e7c-1b4f8d2a6e37", "session token expired")
+	}
+
+	if payload.Data == nil {
+		payload.Data = make(map[string]interface{})
+	}
+
+	return &DefaultSessionContainer{
+		id:        payload.ID,
+		Data:      payload.Data,
+		UpdatedAt: payload.UpdatedAt,
+		OwnerID:   payload.OwnerID,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCookieToken tests session token encoding, key rotation and tampering detection
+func TestCookieToken(t *testing.T) {
+	setCookieKeys([]string{"first secret"})
+
+	sessionInstance := &DefaultSessionContainer{
+		id:        "testSessionID",
+		Data:      map[string]interface{}{"visitor_id": "123"},
+		UpdatedAt: time.Now(),
+		OwnerID:   "123"}
+
+	expiresAt := time.Now().Add(time.Hour)
+	token, err := encodeCookieToken(sessionInstance, expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// token should be readable with current key
+	decodedInstance, err := decodeCookieToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedInstance.id != sessionInstance.id || decodedInstance.OwnerID != "123" || decodedInstance.Data["visitor_id"] != "123" {
+		t.Error("decoded session not matches:", decodedInstance)
+	}
+
+	// tokens should not repeat for the same data
+	if otherToken, _ := encodeCookieToken(sessionInstance, expiresAt); otherToken == token {
+		t.Error("same token made twice")
+	}
+
+	// previous key is accepted after rotation, retired key is not
+	setCookieKeys([]string{"second secret", "first secret"})
+	if _, err := decodeCookieToken(token); err != nil {
+		t.Error("token of previous key is not accepted:", err)
+	}
+
+	setCookieKeys([]string{"second secret"})
+	if _, err := decodeCookieToken(token); err == nil {
+		t.Error("token of retired key is accepted")
+	}
+
+	// modified token should be rejected
+	setCookieKeys([]string{"first secret"})
+	middle := len(token) / 2
+	replacement := "A"
+	if token[middle:middle+1] == replacement {
+		replacement = "B"
+	}
+	if _, err := decodeCookieToken(token[:middle] + replacement + token[middle+1:]); err == nil {
+		t.Error("modified token is accepted")
+	}
+
+	// token should not be accepted after expiration time
+	expiredToken, err := encodeCookieToken(sessionInstance, time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decodeCookieToken(expiredToken); err == nil {
+		t.Error("expired token is accepted")
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/metrics"
 )
 
 // Package global constants
@@ -21,11 +22,32 @@ const (
 
 	ConstStorageFolder = "./var/session/"
 	ConstCryptSession  = false
+
+	ConstOwnerRecordPrefix = "owner." // storage record id prefix for owner sessions index, it can't be generated as session id
+
+	ConstDBCollectionName  = "session" // database collection for "dbsession" storage
+	ConstDBWriteInterval   = 5         // interval pending sessions are written to database (in sec)
+	ConstDBCleanupInterval = 60        // minimal interval expired sessions are removed from database (in sec)
+
+	ConstCookieTokenPrefix     = "c1." // session token prefix, session ids can't contain "."
+	ConstCookieNonceSize       = 16    // session token nonce size (in bytes)
+	ConstCookieMaxSize         = 3800  // session token size limit, larger sessions are kept within fallback storage
+	ConstCookieFallbackStorage = "db"  // default fallback storage for "cookiesession" service, should be shared by nodes
+
+	ConstCookieTokenLifeTime     = 86400      // session token validity period (in sec), token is re-issued on each response
+	ConstCookieRevokedPrefix     = "revoked." // fallback storage record id prefix for closed sessions, tokens are revoked
+	ConstCookieRevocationRecheck = 10         // interval session revocation is re-checked within fallback storage (in sec)
 )
 
 // Package global variables
 var (
 	SessionService api.InterfaceSessionService
+
+	storages = make(map[string]FuncStorageConstructor) // storages available for sessions migration
+
+	metricSessionsCreated = metrics.NewCounter("ottemo_sessions_created_total", "Amount of created sessions.")
+	metricSessionsClosed  = metrics.NewCounter("ottemo_sessions_closed_total", "Amount of closed or expired sessions.")
+	metricStorageDuration = metrics.NewHistogram("ottemo_session_storage_duration_seconds", "Session storage operations duration.", nil, "storage", "operation")
 )
 
 // DefaultSession is a default implementer of InterfaceSession declared in
@@ -38,8 +60,10 @@ type DefaultSessionService struct {
 	sessions map[string]*DefaultSessionContainer // active sessions set
 	mutex    sync.Mutex                          // synchronization on Sessions variable modification
 
-	// package supports "memcache", "redis", "memsession" build tags to change default (filesystem) storage location
+	// package supports "memcache", "redis", "memsession", "dbsession", "cookiesession" build tags to change default (filesystem) storage location
 	storage InterfaceServiceStorage
+
+	ownersMutex sync.Mutex // synchronization on owner sessions index records update
 }
 
 // DefaultSessionContainer is a structure to hold session related information
@@ -48,6 +72,7 @@ type DefaultSessionContainer struct {
 	mutex     sync.Mutex
 	Data      map[string]interface{}
 	UpdatedAt time.Time
+	OwnerID   string
 }
 
 // InterfaceServiceStorage session storage layer for a session service
@@ -56,4 +81,13 @@ type InterfaceServiceStorage interface {
 
 	LoadSession(sessionID string) (*DefaultSessionContainer, error)
 	FlushSession(sessionID string) error
+	DeleteSession(sessionID string) error
 }
+
+// InterfaceStorageLister is a session storage able to enumerate stored sessions, so it could be a migration source
+type InterfaceStorageLister interface {
+	ListSessionIDs() ([]string, error)
+}
+
+// FuncStorageConstructor returns session service instance with particular storage, used for sessions migration
+type FuncStorageConstructor func() (*DefaultSessionService, error)
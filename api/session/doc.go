@@ -8,5 +8,22 @@ Sessions are API call related storage. So, each call to API function supplied wi
 a values related to that particular action. By default sessions have a lifetime, within that period application routines
 can hold information fo future usage for either themselves or other API calls. In order to use previously created session
 API call should specify sessionID within application context.
+
+Session id should be regenerated on privilege change (login) with "api.RegenerateSession", data is moved to a new
+session then. Sessions could be assigned to an owner (visitor), owners index is kept within the same storage (a record
+per owner, re-read on each change, so nodes sharing storage see each other sessions), so owner sessions could be listed
+and closed together ("log out everywhere").
+
+Sessions storage is selected with build tags: filesystem is default, "dbsession" keeps sessions within database
+("session" collection, written in batches each few seconds), "redis", "memcache" and "memsession" are others. Sessions
+could be moved between storages without logging visitors out by "MigrateSessions" (POST session/migrate API or "session migrate" command), source
+storage should be able to list sessions, so memcache could be migration destination only.
+
+"cookiesession" build tag makes service stateless: session data is passed to client within encrypted and signed cookie
+(token), so any node can serve the request. Tokens are signed with the first of "session.cookie.keys" ini secrets,
+others are accepted to allow key rotation. Sessions which do not fit cookie are kept within "session.cookie.fallback"
+storage ("db" by default). Tokens expire a day after they were issued (each response re-issues token). Closed session
+(logout, "log out everywhere", login session regeneration) is revoked with a record within fallback storage, tokens of
+revoked session are rejected by any node.
 */
 package session
@@ -0,0 +1,177 @@
+package session
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ottemo/foundation/cli"
+	"github.com/ottemo/foundation/env"
+)
+
+// init registers sessions migration command and database session storage migration
+func init() {
+	if err := cli.RegisterCommand(cli.StructCommand{
+		Name:        "session migrate",
+		Usage:       "from to",
+		Description: "copies sessions between storages (db, filesystem, redis, memcache) without logging visitors out",
+		Handler:     commandMigrateSessions,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := cli.RegisterMigration(cli.StructCommand{
+		Name:        "session db storage",
+		Description: "prepares database session storage collection, so sessions could be migrated to \"db\" storage",
+		Handler:     migrateDBStorage,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// migrateDBStorage prepares database session storage collection regardless of session storage application uses
+func migrateDBStorage(args []string, output io.Writer) error {
+	flagSet := flag.NewFlagSet("session db storage", flag.ContinueOnError)
+	flagSet.SetOutput(os.Stderr)
+	testMode := flagSet.Bool("test", false, "output collection to be prepared without changing it")
+	if err := flagSet.Parse(args); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if !*testMode {
+		if err := setupDB(); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+	fmt.Fprintln(output, ConstDBCollectionName+": collection prepared")
+
+	return nil
+}
+
+// registerStorage makes session storage available for sessions migration
+func registerStorage(name string, constructor FuncStorageConstructor) {
+	storages[name] = constructor
+}
+
+// GetStorages returns names of session storages available for sessions migration
+func GetStorages() []string {
+	var result []string
+	for name := range storages {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// storeSession writes session instance to storage of given session service without keeping it in service memory
+func storeSession(service *DefaultSessionService, sessionInstance *DefaultSessionContainer) error {
+	// storages are flushing sessions map items
+	service.syncSet(sessionInstance.id, sessionInstance)
+	err := service.flushSession(sessionInstance.id)
+	service.syncDel(sessionInstance.id)
+
+	return err
+}
+
+// flushPending writes sessions buffered by storage with write-behind (database storage)
+func flushPending(service *DefaultSessionService) error {
+	if writer, ok := service.storage.(interface {
+		writePending() error
+	}); ok {
+		return writer.writePending()
+	}
+	return nil
+}
+
+// MigrateSessions copies sessions between two storages while application is running, returns amount of copied
+// sessions
+//   - source storage should be able to enumerate sessions (memcache is not), sessions of running service are flushed
+//     before copy
+//   - existing sessions within destination storage are overwritten
+func MigrateSessions(from string, to string) (int, error) {
+	if from == to {
+		return 0, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7d3f9a2c-5e81-4b46-a0c9-1e6b8d4f2a73", "source and destination storages should differ")
+	}
+
+	sourceConstructor, present := storages[from]
+	if !present {
+		return 0, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e4b1c7d9-3a52-4f08-9d6e-8c2f5a1b7e94", "unknown session storage '"+from+"'")
+	}
+
+	destinationConstructor, present := storages[to]
+	if !present {
+		return 0, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "92c6e1f4-b83d-4a75-8e0b-5d7a3c9f1b26", "unknown session storage '"+to+"'")
+	}
+
+	source, err := sourceConstructor()
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	destination, err := destinationConstructor()
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	sourceLister, ok := source.storage.(InterfaceStorageLister)
+	if !ok {
+		return 0, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5a8e2d6b-c197-4f3e-b4a0-7f1d9c3e6b58", "session storage '"+from+"' can't list sessions")
+	}
+
+	// in-memory sessions of running service should get to storage first
+	if runningService, ok := SessionService.(interface {
+		flushAll() error
+	}); ok {
+		if err := runningService.flushAll(); err != nil {
+			return 0, env.ErrorDispatch(err)
+		}
+	}
+	if err := flushPending(source); err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	sessionIDs, err := sourceLister.ListSessionIDs()
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	count := 0
+	for _, sessionID := range sessionIDs {
+		sessionInstance, err := source.storage.LoadSession(sessionID)
+		if err != nil || sessionInstance == nil {
+			continue
+		}
+
+		if err := storeSession(destination, sessionInstance); err != nil {
+			_ = env.ErrorDispatch(err)
+			continue
+		}
+		count++
+	}
+
+	// storage with write-behind should not keep migrated sessions in buffer only
+	if err := flushPending(destination); err != nil {
+		return count, env.ErrorDispatch(err)
+	}
+
+	return count, nil
+}
+
+// commandMigrateSessions copies sessions between storages given as arguments
+func commandMigrateSessions(args []string, output io.Writer) error {
+	if len(args) != 2 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b5e2a8c1-4d79-4f36-9a0e-c3f7d1b6e482", "source and destination storages should be specified")
+	}
+
+	count, err := MigrateSessions(args[0], args[1])
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	fmt.Fprintln(output, count, "sessions migrated from", args[0], "to", args[1])
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package session
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// owners index routines
+// ---------------------
+
+// isOwnerRecordID checks storage record id belongs to owner sessions index, such ids can't be generated as session id
+func isOwnerRecordID(recordID string) bool {
+	return strings.HasPrefix(recordID, ConstOwnerRecordPrefix)
+}
+
+// loadOwnerSessions reads owner sessions index record from storage, so sessions assigned by other nodes are taken
+//   - returns session ids along with time they were assigned to owner
+//   - should be called under ownersMutex lock
+func (it *DefaultSessionService) loadOwnerSessions(ownerID string) map[string]time.Time {
+	result := make(map[string]time.Time)
+
+	recordID := ConstOwnerRecordPrefix + ownerID
+	if it.storage == it {
+		// memory storage, record is kept within sessions map
+		if record := it.syncGet(recordID); record != nil {
+			for sessionID, value := range record.Data {
+				result[sessionID] = utils.InterfaceToTime(value)
+			}
+		}
+		return result
+	}
+
+	record, err := it.loadSession(recordID)
+	if err != nil || record == nil {
+		return result
+	}
+
+	for sessionID, value := range record.Data {
+		result[sessionID] = utils.InterfaceToTime(value)
+	}
+	return result
+}
+
+// storeOwnerSessions writes owner sessions index record to storage, sessions assigned longer than session lifetime
+// ago are dropped, so record does not grow with sessions expired without logout
+//   - should be called under ownersMutex lock
+func (it *DefaultSessionService) storeOwnerSessions(ownerID string, sessions map[string]time.Time) error {
+	recordID := ConstOwnerRecordPrefix + ownerID
+
+	data := make(map[string]interface{})
+	for sessionID, assignedAt := range sessions {
+		if time.Now().Sub(assignedAt).Seconds() < ConstSessionLifeTime {
+			data[sessionID] = assignedAt
+		}
+	}
+
+	if len(data) == 0 {
+		it.syncDel(recordID)
+		return it.storage.DeleteSession(recordID)
+	}
+
+	// storages are flushing sessions map items, so record temporary placed there
+	recordInstance := &DefaultSessionContainer{
+		id:        recordID,
+		Data:      data,
+		UpdatedAt: time.Now()}
+
+	it.syncSet(recordID, recordInstance)
+	if it.storage == it {
+		return nil
+	}
+	defer it.syncDel(recordID)
+
+	return it.flushSession(recordID)
+}
+
+// addOwnerSession adds session to owner sessions index
+func (it *DefaultSessionService) addOwnerSession(ownerID string, sessionID string) error {
+	it.ownersMutex.Lock()
+	defer it.ownersMutex.Unlock()
+
+	sessions := it.loadOwnerSessions(ownerID)
+	sessions[sessionID] = time.Now()
+
+	return it.storeOwnerSessions(ownerID, sessions)
+}
+
+// removeOwnerSession removes session from owner sessions index
+func (it *DefaultSessionService) removeOwnerSession(ownerID string, sessionID string) error {
+	it.ownersMutex.Lock()
+	defer it.ownersMutex.Unlock()
+
+	sessions := it.loadOwnerSessions(ownerID)
+	if _, present := sessions[sessionID]; !present {
+		return nil
+	}
+	delete(sessions, sessionID)
+
+	return it.storeOwnerSessions(ownerID, sessions)
+}
+
+// getOwnerSessionIDs returns owner session ids from index
+func (it *DefaultSessionService) getOwnerSessionIDs(ownerID string) []string {
+	it.ownersMutex.Lock()
+	defer it.ownersMutex.Unlock()
+
+	var result []string
+	for sessionID := range it.loadOwnerSessions(ownerID) {
+		result = append(result, sessionID)
+	}
+	return result
+}
+
+// InterfaceSessionService implementation (owners related)
+// -------------------------------------------------------
+
+// Regenerate makes new session with data of given one and closes given session, it is supposed to be used on
+// privilege change to prevent session fixation
+func (it *DefaultSessionService) Regenerate(sessionID string) (api.InterfaceSession, error) {
+	if _, err := it.Get(sessionID, false); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	currentInstance := it.syncGet(sessionID)
+	if currentInstance == nil {
+		return it.New()
+	}
+
+	newSessionID, err := GenerateSessionID()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	currentInstance.mutex.Lock()
+	newInstance := &DefaultSessionContainer{
+		id:        newSessionID,
+		Data:      make(map[string]interface{}),
+		UpdatedAt: time.Now(),
+		OwnerID:   currentInstance.OwnerID}
+
+	for key, value := range currentInstance.Data {
+		newInstance.Data[key] = value
+	}
+	currentInstance.mutex.Unlock()
+
+	if err := it.allocateSessionInstance(newInstance); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	metricSessionsCreated.Inc()
+
+	if newInstance.OwnerID != "" {
+		if err := it.addOwnerSession(newInstance.OwnerID, newSessionID); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	if err := it.Close(sessionID); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return DefaultSession(newSessionID), nil
+}
+
+// SetOwner assigns session to owner (visitor), so owner sessions could be listed and closed together,
+// blank ownerID un-assigns session
+func (it *DefaultSessionService) SetOwner(sessionID string, ownerID string) error {
+	if _, err := it.Get(sessionID, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	sessionInstance := it.syncGet(sessionID)
+	if sessionInstance == nil {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "1c8e5f3a-7b24-4d96-a0e1-6f9b2d4c8a57", "session "+sessionID+" not found")
+	}
+
+	previousOwnerID := sessionInstance.GetOwnerID()
+	if previousOwnerID == ownerID {
+		return nil
+	}
+
+	sessionInstance.SetOwnerID(ownerID)
+	sessionInstance.SetUpdatedAt(time.Now())
+
+	if ConstSessionUpdateTime <= 0 {
+		if err := it.flushSession(sessionID); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	if previousOwnerID != "" {
+		if err := it.removeOwnerSession(previousOwnerID, sessionID); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	if ownerID != "" {
+		if err := it.addOwnerSession(ownerID, sessionID); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// GetOwner returns owner (visitor) id session was assigned to or blank string
+func (it *DefaultSessionService) GetOwner(sessionID string) string {
+	if _, err := it.Get(sessionID, false); err != nil {
+		return ""
+	}
+
+	if sessionInstance := it.syncGet(sessionID); sessionInstance != nil {
+		return sessionInstance.GetOwnerID()
+	}
+	return ""
+}
+
+// ListOwnerSessions returns active sessions of given owner (visitor)
+//   - expired or re-assigned sessions are removed from index
+func (it *DefaultSessionService) ListOwnerSessions(ownerID string) []api.StructSessionInfo {
+	var result []api.StructSessionInfo
+
+	for _, sessionID := range it.getOwnerSessionIDs(ownerID) {
+		session, _ := it.Get(sessionID, false)
+		sessionInstance := it.syncGet(sessionID)
+
+		if session == nil || sessionInstance == nil || sessionInstance.GetOwnerID() != ownerID {
+			if err := it.removeOwnerSession(ownerID, sessionID); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+			continue
+		}
+
+		result = append(result, api.StructSessionInfo{
+			ID:        sessionID,
+			UpdatedAt: sessionInstance.GetUpdatedAt(),
+		})
+	}
+
+	return result
+}
+
+// CloseOwnerSessions closes all sessions of given owner (visitor) except specified one ("log out everywhere")
+func (it *DefaultSessionService) CloseOwnerSessions(ownerID string, exceptSessionID string) error {
+	for _, sessionID := range it.getOwnerSessionIDs(ownerID) {
+		if sessionID == exceptSessionID {
+			continue
+		}
+
+		if err := it.Close(sessionID); err != nil {
+			return env.ErrorDispatch(err)
+		}
+
+		// session could be already expired, so index is not updated on close
+		if err := it.removeOwnerSession(ownerID, sessionID); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
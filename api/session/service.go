@@ -2,16 +2,33 @@ package session
 
 import (
 	"crypto/rand"
+	"strings"
+	"time"
+
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
-	"os"
-	"time"
+	"github.com/ottemo/foundation/env/metrics"
 )
 
 const (
 	alphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz1234567890" // sessionID allowed symbols
 )
 
+// init makes package self-initialization routine
+func init() {
+	// gauge reflects registered service only, as instances made for sessions migration are not serving requests
+	if err := metrics.NewGaugeFunc("ottemo_sessions_in_memory", "Amount of sessions kept in application memory.", func() float64 {
+		if counter, ok := SessionService.(interface {
+			syncCount() int
+		}); ok {
+			return float64(counter.syncCount())
+		}
+		return 0
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
 // InitDefaultSessionService makes a new instance of DefaultSessionService
 //   - makes internal fields initialization
 func InitDefaultSessionService() *DefaultSessionService {
@@ -36,6 +53,19 @@ func GenerateSessionID() (string, error) {
 	return string(sessionID), nil
 }
 
+// isSessionID checks value could be a session id made by GenerateSessionID()
+func isSessionID(value string) bool {
+	if len(value) != 32 {
+		return false
+	}
+	for _, char := range value {
+		if !strings.ContainsRune(alphanumeric, char) {
+			return false
+		}
+	}
+	return true
+}
+
 // synchronized access to session container
 // ----------------------------------------
 
@@ -78,6 +108,18 @@ func (it *DefaultSessionContainer) SetID(value string) {
 	it.mutex.Unlock()
 }
 
+func (it *DefaultSessionContainer) GetOwnerID() string {
+	defer it.mutex.Unlock()
+	it.mutex.Lock()
+	return it.OwnerID
+}
+
+func (it *DefaultSessionContainer) SetOwnerID(value string) {
+	it.mutex.Lock()
+	it.OwnerID = value
+	it.mutex.Unlock()
+}
+
 // synchronized access to sessions map
 // -----------------------------------
 
@@ -150,9 +192,47 @@ func (it *DefaultSessionService) FlushSession(sessionID string) error {
 	return nil
 }
 
+// DeleteSession is a stub function for no action
+func (it *DefaultSessionService) DeleteSession(sessionID string) error {
+	return nil
+}
+
 // InterfaceSessionService implementation
 // --------------------------------------
 
+// loadSession takes session from storage and updates storage metrics
+func (it *DefaultSessionService) loadSession(sessionID string) (*DefaultSessionContainer, error) {
+	defer metricStorageDuration.ObserveDuration(time.Now(), it.storage.GetStorageName(), "load")
+	return it.storage.LoadSession(sessionID)
+}
+
+// flushSession writes session to storage and updates storage metrics
+func (it *DefaultSessionService) flushSession(sessionID string) error {
+	defer metricStorageDuration.ObserveDuration(time.Now(), it.storage.GetStorageName(), "flush")
+	return it.storage.FlushSession(sessionID)
+}
+
+// flushAll writes all not expired in-memory sessions to storage, used on application stop
+func (it *DefaultSessionService) flushAll() error {
+	currentTime := time.Now()
+
+	it.syncLoop(
+		func(sessionInstance *DefaultSessionContainer) bool {
+			// session expiration check
+			if currentTime.Sub(sessionInstance.UpdatedAt).Seconds() >= ConstSessionLifeTime {
+				return false
+			}
+
+			// flushing session
+			if err := it.flushSession(sessionInstance.id); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+			return false
+		})
+
+	return nil
+}
+
 // GetName returns implementation name of session service
 func (it *DefaultSessionService) GetName() string {
 	return it.storage.GetStorageName()
@@ -169,7 +249,7 @@ func (it *DefaultSessionService) allocateSessionInstance(sessionInstance *Defaul
 		if numOfSessionsToClean >= 0 {
 			it.syncLoop(
 				func(item *DefaultSessionContainer) bool {
-					if err := it.storage.FlushSession(item.id); err != nil {
+					if err := it.flushSession(item.id); err != nil {
 						_ = env.ErrorDispatch(err)
 					}
 					numOfSessionsToClean--
@@ -185,7 +265,7 @@ func (it *DefaultSessionService) allocateSessionInstance(sessionInstance *Defaul
 	it.syncSet(sessionInstance.id, sessionInstance)
 
 	if ConstSessionUpdateTime <= 0 {
-		if err := it.storage.FlushSession(sessionInstance.id); err != nil {
+		if err := it.flushSession(sessionInstance.id); err != nil {
 			_ = env.ErrorDispatch(err)
 		}
 	}
@@ -202,6 +282,9 @@ func (it *DefaultSessionService) Get(sessionID string, create bool) (api.Interfa
 	if sessionID == "" {
 		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "15fc38db-0848-4992-897e-82b93513f4c6", "blank session id")
 	}
+	if isOwnerRecordID(sessionID) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "f2b86d1e-4a39-4c7f-8e15-9d3a7c6b2e08", "invalid session id")
+	}
 	replaceInstanceFlag := false
 
 	// taking application instance of session
@@ -215,7 +298,7 @@ func (it *DefaultSessionService) Get(sessionID string, create bool) (api.Interfa
 
 	// session taking from storage for case of "immediate" mode and if no session in memory
 	if sessionInstance == nil || ConstSessionUpdateTime == 0 {
-		storedInstance, err := it.storage.LoadSession(sessionID)
+		storedInstance, err := it.loadSession(sessionID)
 		if storedInstance != nil && err == nil {
 			// checking that loaded session is newer then we already have
 			if sessionInstance == nil || storedInstance.UpdatedAt.After(sessionInstance.GetUpdatedAt()) {
@@ -236,6 +319,7 @@ func (it *DefaultSessionService) Get(sessionID string, create bool) (api.Interfa
 		if err != nil {
 			return nil, env.ErrorDispatch(err)
 		}
+		metricSessionsCreated.Inc()
 
 		resultError = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "11670fe2-ee1c-45c9-a732-1349737b53f6", "new session created")
 		replaceInstanceFlag = true
@@ -246,7 +330,7 @@ func (it *DefaultSessionService) Get(sessionID string, create bool) (api.Interfa
 		it.syncSet(sessionID, sessionInstance)
 
 		if ConstSessionUpdateTime <= 0 {
-			if err := it.storage.FlushSession(sessionID); err != nil {
+			if err := it.flushSession(sessionID); err != nil {
 				_ = env.ErrorDispatch(err)
 			}
 		}
@@ -278,6 +362,7 @@ func (it *DefaultSessionService) New() (api.InterfaceSession, error) {
 	if err != nil {
 		return nil, env.ErrorDispatch(err)
 	}
+	metricSessionsCreated.Inc()
 
 	return DefaultSession(sessionID), nil
 }
@@ -294,7 +379,7 @@ func (it *DefaultSessionService) Touch(sessionID string) error {
 			sessionInstance.SetUpdatedAt(time.Now())
 
 			if ConstSessionUpdateTime <= 0 {
-				if err := it.storage.FlushSession(sessionID); err != nil {
+				if err := it.flushSession(sessionID); err != nil {
 					_ = env.ErrorDispatch(err)
 				}
 			}
@@ -305,21 +390,38 @@ func (it *DefaultSessionService) Touch(sessionID string) error {
 }
 
 // Close makes current session instance expired
+//   - session is removed from storage as well, so it can't be restored by id anymore
 func (it *DefaultSessionService) Close(sessionID string) error {
 
-	if it.syncGet(sessionID) != nil {
+	// session could be flushed and released from memory, so owner is taken from storage then
+	sessionInstance := it.syncGet(sessionID)
+	if sessionInstance == nil {
+		sessionInstance, _ = it.loadSession(sessionID)
+	}
 
-		// removing file
-		filename := ConstStorageFolder + sessionID
-		if _, err := os.Stat(filename); err == nil {
-			err := os.Remove(filename)
-			if err != nil {
-				_ = env.ErrorDispatch(err)
-			}
+	// storage keeping sessions on client side should revoke them, as session could be not known on server side
+	if revoker, ok := it.storage.(interface {
+		revokeSession(sessionID string) error
+	}); ok {
+		if err := revoker.revokeSession(sessionID); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	if sessionInstance != nil {
+		if err := it.storage.DeleteSession(sessionID); err != nil {
+			_ = env.ErrorDispatch(err)
 		}
 
 		// releasing memory
 		it.syncDel(sessionID)
+		metricSessionsClosed.Inc()
+
+		if ownerID := sessionInstance.GetOwnerID(); ownerID != "" {
+			if err := it.removeOwnerSession(ownerID, sessionID); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
 	}
 
 	return nil
@@ -350,7 +452,7 @@ func (it *DefaultSessionService) SetKey(sessionID string, key string, value inte
 			sessionInstance.SetUpdatedAt(time.Now())
 
 			if ConstSessionUpdateTime <= 0 {
-				if err := it.storage.FlushSession(sessionID); err != nil {
+				if err := it.flushSession(sessionID); err != nil {
 					_ = env.ErrorDispatch(err)
 				}
 			}
@@ -374,7 +476,7 @@ func (it *DefaultSessionService) GC() error {
 
 			// updating sessions information in a storage
 			if secondsAfterLastUpdate > ConstSessionUpdateTime {
-				err := it.storage.FlushSession(sessionInstance.id)
+				err := it.flushSession(sessionInstance.id)
 				if err != nil {
 					_ = env.ErrorDispatch(err)
 				}
@@ -388,9 +490,12 @@ func (it *DefaultSessionService) GC() error {
 // IsEmpty checks if session contains data
 func (it *DefaultSessionService) IsEmpty(sessionID string) bool {
 
-	_, err := it.Get(sessionID, false)
-	if err != nil {
-		_ = env.ErrorDispatch(err)
+	// owners index is not accessible as a session, but it is flushed to storage through the same routines
+	if !isOwnerRecordID(sessionID) {
+		_, err := it.Get(sessionID, false)
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+		}
 	}
 
 	if sessionInstance := it.syncGet(sessionID); sessionInstance != nil {
@@ -0,0 +1,379 @@
+//go:build cookiesession
+// +build cookiesession
+
+// "service_cookie.go" is a stateless session service keeping session data within encrypted and signed client cookie -
+// "cookiesession" build tag should be specified in order to use it
+//   - session which does not fit cookie is kept within fallback storage ("db" by default), cookie holds session id then
+//   - application memory holds sessions during request processing and releases them after idle period
+
+package session
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
+)
+
+// CookieSessionService is a client cookie based storage implementer based on "DefaultSessionService"
+type CookieSessionService struct {
+	*DefaultSessionService
+
+	fallback      *DefaultSessionService // server side storage for sessions not fitting cookie
+	fallbackName  string                 // name of fallback storage within sessions migration storages
+	fallbackMutex sync.Mutex             // synchronization on fallback storage initialization
+
+	stored      map[string]bool // sessions currently kept within fallback storage
+	storedMutex sync.Mutex      // synchronization on stored sessions access
+
+	revocations      map[string]*cookieRevocation // known revocation state of sessions
+	revocationsMutex sync.Mutex                   // synchronization on revocation states access
+}
+
+// cookieRevocation is a revocation state of session taken from fallback storage
+type cookieRevocation struct {
+	revoked   bool
+	checkedAt time.Time
+}
+
+// init makes package self-initialization routine
+func init() {
+
+	cookieService := new(CookieSessionService)
+	cookieService.DefaultSessionService = InitDefaultSessionService()
+	cookieService.DefaultSessionService.storage = cookieService
+	cookieService.fallbackName = ConstCookieFallbackStorage
+	cookieService.stored = make(map[string]bool)
+	cookieService.revocations = make(map[string]*cookieRevocation)
+
+	SessionService = cookieService
+
+	// starting timer if session update time specified and service supports garbage collection
+	if ConstSessionUpdateTime > 0 {
+		timerInterval := time.Second * ConstSessionUpdateTime
+		ticker := time.NewTicker(timerInterval)
+		go func() {
+			for _ = range ticker.C {
+				if err := SessionService.GC(); err != nil {
+					_ = env.ErrorDispatch(err)
+				}
+			}
+		}()
+	}
+
+	// service registration within system
+	if err := api.RegisterSessionService(SessionService); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	app.OnAppStart(startup)
+	app.OnAppEnd(shutdown)
+}
+
+// startup is a CookieSessionService initialization routines
+//   - "session.cookie.keys" ini value is a comma separated list of secrets, first one is used to make new tokens,
+//     others are accepted only (key rotation)
+//   - "session.cookie.fallback" ini value is a name of fallback storage, it should be shared between nodes
+func startup() error {
+
+	cookieService, ok := SessionService.(*CookieSessionService)
+	if !ok {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5e2b9d7c-a146-4f83-b0d9-3c7e1a6f8b25", "unexpected session service instance")
+	}
+
+	var secrets []string
+	if iniConfig := env.GetIniConfig(); iniConfig != nil {
+		if iniValue := iniConfig.GetValue("session.cookie.keys", ""); iniValue != "" {
+			secrets = strings.Split(iniValue, ",")
+		}
+		if iniValue := iniConfig.GetValue("session.cookie.fallback", cookieService.fallbackName); iniValue != "" {
+			cookieService.fallbackName = iniValue
+		}
+	}
+	setCookieKeys(secrets)
+
+	if _, present := storages[cookieService.fallbackName]; !present {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a83f1c6e-2d97-4b50-9e4a-7f5c2b8d1e63", "unknown session fallback storage '"+cookieService.fallbackName+"'")
+	}
+
+	if err := health.RegisterProbe("session", health.ConstKindReadiness, true, probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// probe is a readiness health probe checking session fallback storage is accessible
+func probe() error {
+	cookieService, ok := SessionService.(*CookieSessionService)
+	if !ok {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "d19c7e4b-5f28-4a36-8b0e-6a2d9f3c7e51", "unexpected session service instance")
+	}
+
+	if _, err := cookieService.getFallback(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// shutdown is a CookieSessionService shutdown routines
+//   - sessions are kept by clients or written to fallback storage at the end of request, so nothing to flush
+func shutdown() error {
+	return nil
+}
+
+// getFallback returns fallback storage session service, it is initialized on first access as database could be not
+// connected at application start
+func (it *CookieSessionService) getFallback() (*DefaultSessionService, error) {
+	it.fallbackMutex.Lock()
+	defer it.fallbackMutex.Unlock()
+
+	if it.fallback == nil {
+		constructor, present := storages[it.fallbackName]
+		if !present {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6c4a8e2f-b917-4d35-a0c6-e1f5b9d3a728", "unknown session fallback storage '"+it.fallbackName+"'")
+		}
+
+		fallback, err := constructor()
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		it.fallback = fallback
+	}
+
+	return it.fallback, nil
+}
+
+// setStored marks session as kept within fallback storage
+func (it *CookieSessionService) setStored(sessionID string, value bool) {
+	it.storedMutex.Lock()
+	if value {
+		it.stored[sessionID] = true
+	} else {
+		delete(it.stored, sessionID)
+	}
+	it.storedMutex.Unlock()
+}
+
+// isStored checks if session is kept within fallback storage
+func (it *CookieSessionService) isStored(sessionID string) bool {
+	it.storedMutex.Lock()
+	defer it.storedMutex.Unlock()
+	return it.stored[sessionID]
+}
+
+// writeFallback writes session instance to fallback storage immediately, so other nodes could read it
+func (it *CookieSessionService) writeFallback(sessionInstance *DefaultSessionContainer) error {
+	fallback, err := it.getFallback()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := storeSession(fallback, sessionInstance); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return flushPending(fallback)
+}
+
+// revokeSession writes revocation record to fallback storage, so tokens of closed session are not accepted by any
+// node until they expire
+func (it *CookieSessionService) revokeSession(sessionID string) error {
+	it.revocationsMutex.Lock()
+	it.revocations[sessionID] = &cookieRevocation{revoked: true, checkedAt: time.Now()}
+	it.revocationsMutex.Unlock()
+
+	return it.writeFallback(&DefaultSessionContainer{
+		id:        ConstCookieRevokedPrefix + sessionID,
+		Data:      map[string]interface{}{"revoked_at": time.Now()},
+		UpdatedAt: time.Now()})
+}
+
+// isRevoked checks session was closed on this or other node, revocation state is re-checked within fallback storage
+// each few seconds
+func (it *CookieSessionService) isRevoked(sessionID string) bool {
+	it.revocationsMutex.Lock()
+	state, present := it.revocations[sessionID]
+	it.revocationsMutex.Unlock()
+
+	if present && (state.revoked || time.Now().Sub(state.checkedAt).Seconds() < ConstCookieRevocationRecheck) {
+		return state.revoked
+	}
+
+	fallback, err := it.getFallback()
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return present && state.revoked
+	}
+
+	record, _ := fallback.storage.LoadSession(ConstCookieRevokedPrefix + sessionID)
+	state = &cookieRevocation{revoked: record != nil, checkedAt: time.Now()}
+
+	it.revocationsMutex.Lock()
+	it.revocations[sessionID] = state
+	it.revocationsMutex.Unlock()
+
+	return state.revoked
+}
+
+// InterfaceSessionTokenizer implementation
+// ----------------------------------------
+
+// GetToken returns value client should pass to identify session: session token or session id if session does not fit
+// cookie, blank value returned for closed session
+func (it *CookieSessionService) GetToken(sessionID string) (string, error) {
+	if _, err := it.DefaultSessionService.Get(sessionID, false); err != nil {
+		return "", nil
+	}
+
+	sessionInstance := it.syncGet(sessionID)
+	if sessionInstance == nil {
+		return "", nil
+	}
+
+	token, err := encodeCookieToken(sessionInstance, time.Now().Add(ConstCookieTokenLifeTime*time.Second))
+	if err != nil {
+		return "", env.ErrorDispatch(err)
+	}
+
+	if len(token) <= ConstCookieMaxSize {
+		// session became small enough to leave fallback storage
+		if it.isStored(sessionID) {
+			if err := it.DeleteSession(sessionID); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+		return token, nil
+	}
+
+	if err := it.writeFallback(sessionInstance); err != nil {
+		return "", env.ErrorDispatch(err)
+	}
+	it.setStored(sessionID, true)
+
+	return sessionID, nil
+}
+
+// InterfaceServiceStorage implementation
+// --------------------------------------
+
+// GetStorageName returns storage implementation name for a session service
+func (it *CookieSessionService) GetStorageName() string {
+	return "CookieSessionService"
+}
+
+// LoadSession reads session from fallback storage, sessions kept by client are not accessible by id
+func (it *CookieSessionService) LoadSession(sessionID string) (*DefaultSessionContainer, error) {
+	fallback, err := it.getFallback()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	sessionInstance, err := fallback.storage.LoadSession(sessionID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if sessionInstance != nil && !isOwnerRecordID(sessionID) {
+		it.setStored(sessionID, true)
+	}
+
+	return sessionInstance, nil
+}
+
+// FlushSession releases application memory, sessions are written to client cookie or fallback storage at the end of
+// request, so only owners index records are written to fallback storage here
+func (it *CookieSessionService) FlushSession(sessionID string) error {
+	sessionInstance := it.syncGet(sessionID)
+	if sessionInstance == nil {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "8f5d2b9a-e361-4c74-b1a8-9d6e3f7c2b40", "session "+sessionID+" not found")
+	}
+
+	if isOwnerRecordID(sessionID) {
+		if err := it.writeFallback(sessionInstance); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	// releasing application memory
+	it.syncDel(sessionID)
+
+	return nil
+}
+
+// DeleteSession removes session from fallback storage
+//   - session token kept by client is revoked on session close, see revokeSession()
+func (it *CookieSessionService) DeleteSession(sessionID string) error {
+	it.setStored(sessionID, false)
+
+	fallback, err := it.getFallback()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return fallback.storage.DeleteSession(sessionID)
+}
+
+// InterfaceSessionService implementation (overrides)
+// --------------------------------------------------
+
+// Get returns session object for given session token or session id
+//   - session from token replaces application memory one if it is newer (request was served by other node)
+//   - tokens of closed sessions are rejected, as they are revoked within fallback storage
+func (it *CookieSessionService) Get(sessionID string, create bool) (api.InterfaceSession, error) {
+	if !isCookieToken(sessionID) {
+		return it.DefaultSessionService.Get(sessionID, create)
+	}
+
+	tokenInstance, err := decodeCookieToken(sessionID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if it.isRevoked(tokenInstance.id) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4a7d2e9c-b158-4f63-8e0a-d3c6f1b9a725", "session token revoked")
+	}
+
+	sessionInstance := it.syncGet(tokenInstance.id)
+	if sessionInstance == nil || tokenInstance.UpdatedAt.After(sessionInstance.GetUpdatedAt()) {
+		it.syncSet(tokenInstance.id, tokenInstance)
+	}
+
+	return DefaultSession(tokenInstance.id), nil
+}
+
+// GC flushes out of date sessions, forgets revocation states which are re-checked or revoked tokens of which are
+// expired, and runs fallback storage cleanup
+func (it *CookieSessionService) GC() error {
+	if err := it.DefaultSessionService.GC(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	it.revocationsMutex.Lock()
+	for sessionID, state := range it.revocations {
+		passed := time.Now().Sub(state.checkedAt).Seconds()
+		if (!state.revoked && passed >= ConstCookieRevocationRecheck) || passed >= ConstCookieTokenLifeTime {
+			delete(it.revocations, sessionID)
+		}
+	}
+	it.revocationsMutex.Unlock()
+
+	it.fallbackMutex.Lock()
+	fallback := it.fallback
+	it.fallbackMutex.Unlock()
+
+	// fallback storage is not initialized before first use
+	if fallback != nil {
+		if collector, ok := fallback.storage.(interface {
+			GC() error
+		}); ok {
+			return collector.GC()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+//go:build dbsession
+// +build dbsession
+
+// "service_db.go" is a database based session service - "dbsession" build tag should be specified in order to use it
+
+package session
+
+import (
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
+)
+
+// init makes package self-initialization routine
+func init() {
+
+	dbService := newDBSessionService()
+
+	SessionService = dbService
+
+	// starting timer if session update time specified and service supports garbage collection
+	if ConstSessionUpdateTime > 0 {
+		timerInterval := time.Second * ConstSessionUpdateTime
+		ticker := time.NewTicker(timerInterval)
+		go func() {
+			for _ = range ticker.C {
+				if err := SessionService.GC(); err != nil {
+					_ = env.ErrorDispatch(err)
+				}
+			}
+		}()
+	}
+
+	// pending sessions write timer
+	writeTicker := time.NewTicker(time.Second * ConstDBWriteInterval)
+	go func() {
+		for _ = range writeTicker.C {
+			if err := dbService.writePending(); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+	}()
+
+	// service registration within system
+	if err := api.RegisterSessionService(SessionService); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	db.RegisterOnDatabaseStart(setupDB)
+
+	app.OnAppStart(startup)
+	app.OnAppStop(dbService.flushAll)
+	app.OnAppStop(dbService.writePending)
+	app.OnAppEnd(shutdown)
+}
+
+// startup is a DBSessionService initialization routines
+func startup() error {
+	if err := health.RegisterProbe("session", health.ConstKindReadiness, true, probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// probe is a readiness health probe checking session collection is accessible
+func probe() error {
+	if _, err := db.GetCollection(ConstDBCollectionName); err != nil {
+		return err
+	}
+	return nil
+}
+
+// shutdown is a DBSessionService shutdown routines
+//   - sessions are written to database earlier, on application stop event
+func shutdown() error {
+	return nil
+}
@@ -1,12 +1,10 @@
-// +build !redis,!memcache,!memsession
+// +build !redis,!memcache,!memsession,!dbsession,!cookiesession
 
-// "service_filesystem.go" is a filesystem based session storage implementation - default option if no tags specified
+// "service_filesystem.go" is a filesystem based session service - default option if no tags specified
 
 package session
 
 import (
-	"encoding/json"
-	"io"
 	"io/ioutil"
 	"os"
 	"time"
@@ -14,14 +12,9 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
-	"github.com/ottemo/foundation/utils"
+	"github.com/ottemo/foundation/env/health"
 )
 
-// FilesystemSessionService is a filesystem based storage implementer based on "DefaultSessionService"
-type FilesystemSessionService struct {
-	*DefaultSessionService
-}
-
 // init makes package self-initialization routine
 func init() {
 
@@ -50,6 +43,7 @@ func init() {
 	}
 
 	app.OnAppStart(startup)
+	app.OnAppStop(filesystemService.flushAll)
 	app.OnAppEnd(shutdown)
 }
 
@@ -85,153 +79,27 @@ func startup() error {
 		}
 	}
 
-	return nil
-}
-
-// Shutdown is a FilesystemSessionService shutdown routines
-func shutdown() error {
-
-	filesystemService, ok := SessionService.(*FilesystemSessionService)
-	if !ok {
-		err := env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a95b6e53-1fb5-480a-a8c6-a6169a7da9fa", "unexpected session service instance")
-		return env.ErrorDispatch(err)
+	if err := health.RegisterProbe("session", health.ConstKindReadiness, true, probe); err != nil {
+		_ = env.ErrorDispatch(err)
 	}
 
-	currentTime := time.Now()
-
-	// saving all session to storage
-	filesystemService.syncLoop(
-		func(sessionInstance *DefaultSessionContainer) bool {
-			// session expiration check
-			if currentTime.Sub(sessionInstance.UpdatedAt).Seconds() >= ConstSessionLifeTime {
-				return false
-			}
-
-			// flushing session
-			if err := filesystemService.FlushSession(sessionInstance.id); err != nil {
-				_ = env.ErrorDispatch(err)
-			}
-			return false
-		})
-
 	return nil
 }
 
-// InterfaceServiceStorage implementation
-// --------------------------------------
-
-// GetStorageName returns storage implementation name for a session service
-func (it *FilesystemSessionService) GetStorageName() string {
-	return "MemcacheSessionService"
-}
-
-// LoadSession de-serializes file from filesystem storage, returns nil on error
-func (it *FilesystemSessionService) LoadSession(sessionID string) (*DefaultSessionContainer, error) {
-
-	// making new session holder instance
-	sessionInstance := &DefaultSessionContainer{id: sessionID}
-
-	// checking file exists in file system
-	filename := ConstStorageFolder + sessionID
-	fileInfo, err := os.Stat(filename)
-	if err != nil {
-		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "363cd5a8-1a3d-4163-a7d3-cb96dbaff01c", "session "+sessionID+" not found")
-	}
-
-	// checking file modification time - expired session case
-	if time.Now().Sub(fileInfo.ModTime()).Seconds() >= ConstSessionLifeTime {
-		err := os.Remove(filename)
-		if err != nil {
-			return nil, err
-		}
-		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "7aee9352-a08b-420a-a725-7f32a17495a8", "session "+sessionID+" expired")
-	}
-
-	// file not expired - loading data from it
-	sessionFile, err := os.OpenFile(filename, os.O_RDONLY, 0660)
-	if err != nil {
-		return nil, env.ErrorDispatch(err)
-	}
-
-	// defer file close with error handling
-	defer func(c io.Closer){
-		if err := sessionFile.Close(); err != nil {
-			_ = env.ErrorDispatch(err)
-		}
-	}(sessionFile)
-
-	var reader io.Reader = sessionFile
-	if ConstCryptSession {
-		reader, err = utils.EncryptReader(reader)
-		if err != nil {
-			return nil, env.ErrorDispatch(err)
-		}
-	}
-
-	jsonDecoder := json.NewDecoder(reader)
-	err = jsonDecoder.Decode(sessionInstance)
+// probe is a readiness health probe checking session storage folder is accessible
+func probe() error {
+	fileInfo, err := os.Stat(ConstStorageFolder)
 	if err != nil {
-		return nil, env.ErrorDispatch(err)
+		return err
 	}
-
-	if sessionInstance.Data == nil {
-		sessionInstance.Data = make(map[string]interface{})
-	}
-
-	if sessionInstance.UpdatedAt.IsZero() {
-		sessionInstance.UpdatedAt = fileInfo.ModTime()
+	if !fileInfo.IsDir() {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "9d4b2e6f-1a7c-4c38-b5e0-8f3a6d1c7b94", "session storage '"+ConstStorageFolder+"' is not a folder")
 	}
-
-	return sessionInstance, nil
+	return nil
 }
 
-// FlushSession serializes session into filesystem storage
-//   - routine not checks session expiration or modification time - it just flushes data to storage
-func (it *FilesystemSessionService) FlushSession(sessionID string) error {
-	sessionInstance := it.syncGet(sessionID)
-	if sessionInstance == nil {
-		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b04bb3cd-fcaa-4a78-bf8a-ecdeb7dceaf3", "session "+sessionID+" not found")
-	}
-
-	// skipping flush for empty sessions
-	if SessionService.IsEmpty(sessionID) {
-		return nil
-	}
-
-	// serializing session data to file
-	sessionFile, err := os.OpenFile(ConstStorageFolder+sessionID, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0660)
-	if err != nil {
-		return env.ErrorDispatch(err)
-	}
-
-	defer func() {
-		if err := sessionFile.Close(); err != nil {
-			_ = env.ErrorDispatch(err)
-		}
-		updatedAt := sessionInstance.GetUpdatedAt()
-		if err := os.Chtimes(sessionFile.Name(), updatedAt, updatedAt); err != nil {
-			_ = env.ErrorDispatch(err)
-		}
-	}()
-
-	var writer io.Writer = sessionFile
-	if ConstCryptSession {
-		writer, err = utils.EncryptWriter(writer)
-		if err != nil {
-			return env.ErrorDispatch(err)
-		}
-	}
-
-	sessionInstance.mutex.Lock()
-	jsonEncoder := json.NewEncoder(writer)
-	err = jsonEncoder.Encode(sessionInstance)
-	if err != nil {
-		return env.ErrorDispatch(err)
-	}
-	sessionInstance.mutex.Unlock()
-
-	// releasing application memory
-	it.syncDel(sessionID)
-
+// Shutdown is a FilesystemSessionService shutdown routines
+//   - sessions are saved to storage earlier, on application stop event
+func shutdown() error {
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -46,7 +47,10 @@ func init() {
 	// service registration within system
 	api.RegisterSessionService(SessionService)
 
+	registerStorage("memcache", func() (*DefaultSessionService, error) { return memcacheService.DefaultSessionService, nil })
+
 	app.OnAppStart(startup)
+	app.OnAppStop(memcacheService.flushAll)
 	app.OnAppEnd(shutdown)
 }
 
@@ -68,6 +72,18 @@ func startup() error {
 
 	memcacheService.memcacheClient = memcache.New(serversList)
 
+	if err := health.RegisterProbe("session", health.ConstKindReadiness, true, memcacheService.probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// probe is a readiness health probe checking memcache server is reachable (cache miss is fine)
+func (it *MemcacheSessionService) probe() error {
+	if _, err := it.memcacheClient.Get("health_probe"); err != nil && err != memcache.ErrCacheMiss {
+		return err
+	}
 	return nil
 }
 
@@ -132,7 +148,7 @@ func (it *MemcacheSessionService) FlushSession(sessionID string) error {
 	}
 
 	// skipping flush for empty sessions
-	if SessionService.IsEmpty(sessionID) {
+	if it.IsEmpty(sessionID) {
 		return nil
 	}
 
@@ -166,3 +182,12 @@ func (it *MemcacheSessionService) FlushSession(sessionID string) error {
 
 	return nil
 }
+
+// DeleteSession removes session from memcache server
+func (it *MemcacheSessionService) DeleteSession(sessionID string) error {
+	if err := it.memcacheClient.Delete(sessionID); err != nil && err != memcache.ErrCacheMiss {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -46,7 +47,10 @@ func init() {
 	// service registration within system
 	api.RegisterSessionService(SessionService)
 
+	registerStorage("redis", func() (*DefaultSessionService, error) { return redisService.DefaultSessionService, nil })
+
 	app.OnAppStart(startup)
+	app.OnAppStop(redisService.flushAll)
 	app.OnAppEnd(shutdown)
 }
 
@@ -69,6 +73,10 @@ func startup() error {
 	redisService.redisClient = redis.New(serversList)
 	error := redisService.redisClient.Ping()
 
+	if err := health.RegisterProbe("session", health.ConstKindReadiness, true, redisService.redisClient.Ping); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
 	return error
 }
 
@@ -134,7 +142,7 @@ func (it *RedisSessionService) FlushSession(sessionID string) error {
 	}
 
 	// skipping flush for empty sessions
-	if SessionService.IsEmpty(sessionID) {
+	if it.IsEmpty(sessionID) {
 		return nil
 	}
 
@@ -168,3 +176,30 @@ func (it *RedisSessionService) FlushSession(sessionID string) error {
 
 	return nil
 }
+
+// DeleteSession removes session from redis server
+func (it *RedisSessionService) DeleteSession(sessionID string) error {
+	if _, err := it.redisClient.Del(sessionID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// ListSessionIDs returns ids of sessions within redis storage, so it could be a sessions migration source
+//   - redis database could be shared, so keys which are not session ids or owners index records are skipped
+func (it *RedisSessionService) ListSessionIDs() ([]string, error) {
+	keys, err := it.redisClient.Keys("*")
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var result []string
+	for _, key := range keys {
+		if isSessionID(key) || isOwnerRecordID(key) {
+			result = append(result, key)
+		}
+	}
+
+	return result, nil
+}
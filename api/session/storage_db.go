@@ -0,0 +1,347 @@
+// "storage_db.go" is a database based session storage, it is used by session service with "dbsession" build tag and
+// available as sessions migration source/destination with any build tags
+//   - sessions are written to database in batches (write-behind), so request handling is not waiting for database
+
+package session
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// DBSessionService is a database based storage implementer based on "DefaultSessionService"
+type DBSessionService struct {
+	*DefaultSessionService
+
+	pending      map[string]map[string]interface{} // serialized sessions waiting to be written to database
+	pendingMutex sync.Mutex                        // synchronization on pending sessions access
+	writeMutex   sync.Mutex                        // synchronization of pending sessions write and sessions removal
+
+	lastCleanup time.Time // time of last expired sessions removal from database
+}
+
+// init registers database storage for sessions migration
+func init() {
+	registerStorage("db", newDBStorage)
+}
+
+// newDBSessionService makes new database based session service instance
+func newDBSessionService() *DBSessionService {
+	dbService := new(DBSessionService)
+	dbService.DefaultSessionService = InitDefaultSessionService()
+	dbService.DefaultSessionService.storage = dbService
+	dbService.pending = make(map[string]map[string]interface{})
+
+	return dbService
+}
+
+// newDBStorage returns database storage, running session service is used if it is database based
+func newDBStorage() (*DefaultSessionService, error) {
+	if dbService, ok := SessionService.(*DBSessionService); ok {
+		return dbService.DefaultSessionService, nil
+	}
+
+	if err := setupDB(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return newDBSessionService().DefaultSessionService, nil
+}
+
+// setupDB prepares system database for database session storage usage
+func setupDB() error {
+	collection, err := db.GetCollection(ConstDBCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddColumn("session_id", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("owner_id", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("data", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("updated_at", db.ConstTypeDatetime, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// decodeRecord makes session holder instance from database record or pending item
+func decodeRecord(sessionID string, record map[string]interface{}) (*DefaultSessionContainer, error) {
+	data := utils.InterfaceToString(record["data"])
+	if ConstCryptSession {
+		data = utils.DecryptString(data)
+	}
+
+	sessionInstance := &DefaultSessionContainer{id: sessionID}
+	if err := json.Unmarshal([]byte(data), sessionInstance); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if sessionInstance.Data == nil {
+		sessionInstance.Data = make(map[string]interface{})
+	}
+
+	if sessionInstance.UpdatedAt.IsZero() {
+		sessionInstance.UpdatedAt = utils.InterfaceToTime(record["updated_at"])
+	}
+
+	return sessionInstance, nil
+}
+
+// writePending writes pending sessions to database within one batch
+//   - sessions are not removed while batch is written, so removed session is not written or restored back
+func (it *DBSessionService) writePending() error {
+	it.writeMutex.Lock()
+	defer it.writeMutex.Unlock()
+
+	it.pendingMutex.Lock()
+	pending := it.pending
+	it.pending = make(map[string]map[string]interface{})
+	it.pendingMutex.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// returns not written sessions back unless they were updated meanwhile
+	restore := func(sessionIDs []string) {
+		it.pendingMutex.Lock()
+		for _, sessionID := range sessionIDs {
+			if _, present := it.pending[sessionID]; !present {
+				it.pending[sessionID] = pending[sessionID]
+			}
+		}
+		it.pendingMutex.Unlock()
+	}
+
+	var sessionIDs []string
+	for sessionID := range pending {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+
+	collection, err := db.GetCollection(ConstDBCollectionName)
+	if err != nil {
+		restore(sessionIDs)
+		return env.ErrorDispatch(err)
+	}
+
+	// looking for existing records to update them instead of insert
+	if err := collection.AddFilter("session_id", "in", sessionIDs); err != nil {
+		restore(sessionIDs)
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.SetResultColumns("_id", "session_id"); err != nil {
+		restore(sessionIDs)
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		restore(sessionIDs)
+		return env.ErrorDispatch(err)
+	}
+
+	for _, record := range records {
+		if item, present := pending[utils.InterfaceToString(record["session_id"])]; present {
+			item["_id"] = record["_id"]
+		}
+	}
+
+	var failed []string
+	for sessionID, item := range pending {
+		if _, err := collection.Save(item); err != nil {
+			_ = env.ErrorDispatch(err)
+			failed = append(failed, sessionID)
+		}
+	}
+
+	if len(failed) > 0 {
+		restore(failed)
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3f7a9c1e-6d28-4b50-8e4f-a2c9d7b1e536", "some sessions were not written to database")
+	}
+
+	return nil
+}
+
+// InterfaceServiceStorage implementation
+// --------------------------------------
+
+// GetStorageName returns storage implementation name for a session service
+func (it *DBSessionService) GetStorageName() string {
+	return "DBSessionService"
+}
+
+// LoadSession reads session from pending sessions or database storage, returns nil on error
+//   - missing session is a usual case (expired cookie, revocation check), so error is not dispatched
+func (it *DBSessionService) LoadSession(sessionID string) (*DefaultSessionContainer, error) {
+	it.pendingMutex.Lock()
+	item, present := it.pending[sessionID]
+	it.pendingMutex.Unlock()
+
+	if present {
+		return decodeRecord(sessionID, item)
+	}
+
+	collection, err := db.GetCollection(ConstDBCollectionName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("session_id", "=", sessionID); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if len(records) == 0 {
+		return nil, env.Error(ConstErrorModule, env.ConstErrorLevelAPI, "c81e5b3d-9a47-4f62-b0d8-7e3a1c6f9b24", "session "+sessionID+" not found")
+	}
+
+	record := records[0]
+	if time.Now().Sub(utils.InterfaceToTime(record["updated_at"])).Seconds() >= ConstSessionLifeTime {
+		if err := it.DeleteSession(sessionID); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "6b2d8f4a-1e93-4c75-a9b0-5d7e3f1c8a62", "session "+sessionID+" expired")
+	}
+
+	return decodeRecord(sessionID, record)
+}
+
+// FlushSession serializes session and puts it to pending sessions to be written to database
+//   - routine not checks session expiration or modification time - it just flushes data to storage
+func (it *DBSessionService) FlushSession(sessionID string) error {
+	sessionInstance := it.syncGet(sessionID)
+	if sessionInstance == nil {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "a4e9c2b7-5d31-4f86-8b0e-9c6f1d3a7e25", "session "+sessionID+" not found")
+	}
+
+	// skipping flush for empty sessions
+	if it.IsEmpty(sessionID) {
+		return nil
+	}
+
+	sessionInstance.mutex.Lock()
+	data, err := json.Marshal(sessionInstance)
+	ownerID := sessionInstance.OwnerID
+	updatedAt := sessionInstance.UpdatedAt
+	sessionInstance.mutex.Unlock()
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	encodedData := string(data)
+	if ConstCryptSession {
+		encodedData = utils.EncryptString(encodedData)
+	}
+
+	it.pendingMutex.Lock()
+	it.pending[sessionID] = map[string]interface{}{
+		"session_id": sessionID,
+		"owner_id":   ownerID,
+		"data":       encodedData,
+		"updated_at": updatedAt,
+	}
+	it.pendingMutex.Unlock()
+
+	// releasing application memory
+	it.syncDel(sessionID)
+
+	return nil
+}
+
+// DeleteSession removes session from pending sessions and database storage
+//   - it waits for pending sessions write in progress, which could contain the session
+func (it *DBSessionService) DeleteSession(sessionID string) error {
+	it.writeMutex.Lock()
+	defer it.writeMutex.Unlock()
+
+	it.pendingMutex.Lock()
+	delete(it.pending, sessionID)
+	it.pendingMutex.Unlock()
+
+	collection, err := db.GetCollection(ConstDBCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("session_id", "=", sessionID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if _, err := collection.Delete(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// ListSessionIDs returns ids of sessions within database storage
+func (it *DBSessionService) ListSessionIDs() ([]string, error) {
+	if err := it.writePending(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	collection, err := db.GetCollection(ConstDBCollectionName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	values, err := collection.Distinct("session_id")
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var result []string
+	for _, value := range values {
+		result = append(result, utils.InterfaceToString(value))
+	}
+
+	return result, nil
+}
+
+// InterfaceSessionService implementation (overrides)
+// --------------------------------------------------
+
+// GC flushes out of date sessions and removes expired sessions from database (not more often than cleanup interval)
+func (it *DBSessionService) GC() error {
+	if err := it.DefaultSessionService.GC(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if time.Now().Sub(it.lastCleanup) < ConstDBCleanupInterval*time.Second {
+		return nil
+	}
+	it.lastCleanup = time.Now()
+
+	collection, err := db.GetCollection(ConstDBCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	expireTime := time.Now().Add(-ConstSessionLifeTime * time.Second)
+	if err := collection.AddFilter("updated_at", "<", expireTime); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if _, err := collection.Delete(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+// "storage_filesystem.go" is a filesystem based session storage, it is used by default session service (if no tags
+// specified) and available as sessions migration source/destination with any build tags
+
+package session
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// FilesystemSessionService is a filesystem based storage implementer based on "DefaultSessionService"
+type FilesystemSessionService struct {
+	*DefaultSessionService
+}
+
+// init registers filesystem storage for sessions migration
+func init() {
+	registerStorage("filesystem", newFilesystemStorage)
+}
+
+// newFilesystemStorage returns filesystem storage, running session service is used if it is filesystem based
+func newFilesystemStorage() (*DefaultSessionService, error) {
+	if filesystemService, ok := SessionService.(*FilesystemSessionService); ok {
+		return filesystemService.DefaultSessionService, nil
+	}
+
+	if err := os.MkdirAll(ConstStorageFolder, os.ModePerm); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	filesystemStorage := new(FilesystemSessionService)
+	filesystemStorage.DefaultSessionService = InitDefaultSessionService()
+	filesystemStorage.DefaultSessionService.storage = filesystemStorage
+
+	return filesystemStorage.DefaultSessionService, nil
+}
+
+// InterfaceServiceStorage implementation
+// --------------------------------------
+
+// GetStorageName returns storage implementation name for a session service
+func (it *FilesystemSessionService) GetStorageName() string {
+	return "FilesystemSessionService"
+}
+
+// LoadSession de-serializes file from filesystem storage, returns nil on error
+//   - missing session is a usual case (expired cookie, revocation check), so error is not dispatched
+func (it *FilesystemSessionService) LoadSession(sessionID string) (*DefaultSessionContainer, error) {
+
+	// making new session holder instance
+	sessionInstance := &DefaultSessionContainer{id: sessionID}
+
+	// checking file exists in file system
+	filename := ConstStorageFolder + sessionID
+	fileInfo, err := os.Stat(filename)
+	if err != nil {
+		return nil, env.Error(ConstErrorModule, env.ConstErrorLevelAPI, "363cd5a8-1a3d-4163-a7d3-cb96dbaff01c", "session "+sessionID+" not found")
+	}
+
+	// checking file modification time - expired session case
+	if time.Now().Sub(fileInfo.ModTime()).Seconds() >= ConstSessionLifeTime {
+		err := os.Remove(filename)
+		if err != nil {
+			return nil, err
+		}
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "7aee9352-a08b-420a-a725-7f32a17495a8", "session "+sessionID+" expired")
+	}
+
+	// file not expired - loading data from it
+	sessionFile, err := os.OpenFile(filename, os.O_RDONLY, 0660)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// defer file close with error handling
+	defer func(c io.Closer) {
+		if err := sessionFile.Close(); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}(sessionFile)
+
+	var reader io.Reader = sessionFile
+	if ConstCryptSession {
+		reader, err = utils.EncryptReader(reader)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	jsonDecoder := json.NewDecoder(reader)
+	err = jsonDecoder.Decode(sessionInstance)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if sessionInstance.Data == nil {
+		sessionInstance.Data = make(map[string]interface{})
+	}
+
+	if sessionInstance.UpdatedAt.IsZero() {
+		sessionInstance.UpdatedAt = fileInfo.ModTime()
+	}
+
+	return sessionInstance, nil
+}
+
+// FlushSession serializes session into filesystem storage
+//   - routine not checks session expiration or modification time - it just flushes data to storage
+func (it *FilesystemSessionService) FlushSession(sessionID string) error {
+	sessionInstance := it.syncGet(sessionID)
+	if sessionInstance == nil {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b04bb3cd-fcaa-4a78-bf8a-ecdeb7dceaf3", "session "+sessionID+" not found")
+	}
+
+	// skipping flush for empty sessions
+	if it.IsEmpty(sessionID) {
+		return nil
+	}
+
+	// serializing session data to file
+	sessionFile, err := os.OpenFile(ConstStorageFolder+sessionID, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	defer func() {
+		if err := sessionFile.Close(); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+		updatedAt := sessionInstance.GetUpdatedAt()
+		if err := os.Chtimes(sessionFile.Name(), updatedAt, updatedAt); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}()
+
+	var writer io.Writer = sessionFile
+	if ConstCryptSession {
+		writer, err = utils.EncryptWriter(writer)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	sessionInstance.mutex.Lock()
+	jsonEncoder := json.NewEncoder(writer)
+	err = jsonEncoder.Encode(sessionInstance)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	sessionInstance.mutex.Unlock()
+
+	// releasing application memory
+	it.syncDel(sessionID)
+
+	return nil
+}
+
+// DeleteSession removes session file from filesystem storage
+func (it *FilesystemSessionService) DeleteSession(sessionID string) error {
+	filename := ConstStorageFolder + sessionID
+	if _, err := os.Stat(filename); err == nil {
+		if err := os.Remove(filename); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// ListSessionIDs returns ids of sessions within filesystem storage
+func (it *FilesystemSessionService) ListSessionIDs() ([]string, error) {
+	files, err := ioutil.ReadDir(ConstStorageFolder)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var result []string
+	for _, fileInfo := range files {
+		if !fileInfo.IsDir() {
+			result = append(result, fileInfo.Name())
+		}
+	}
+
+	return result, nil
+}
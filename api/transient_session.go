@@ -0,0 +1,47 @@
+package api
+
+import (
+	"sync"
+)
+
+// transientSession is a session which is not stored by session service, it lives within one request only
+type transientSession struct {
+	data  map[string]interface{}
+	mutex sync.Mutex
+}
+
+// GetID returns blank id, as session is not stored
+func (it *transientSession) GetID() string {
+	return ""
+}
+
+// Get returns session value by a given key or nil - if not set
+func (it *transientSession) Get(key string) interface{} {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	return it.data[key]
+}
+
+// Set assigns value to session key
+func (it *transientSession) Set(key string, value interface{}) {
+	it.mutex.Lock()
+	it.data[key] = value
+	it.mutex.Unlock()
+}
+
+// IsEmpty checks if session contains data
+func (it *transientSession) IsEmpty() bool {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	return len(it.data) == 0
+}
+
+// Touch does nothing, as session is not stored
+func (it *transientSession) Touch() error {
+	return nil
+}
+
+// Close does nothing, as session is not stored
+func (it *transientSession) Close() error {
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/blog/post"
@@ -19,6 +20,14 @@ func init() {
 
 	db.RegisterOnDatabaseStart(setupDB)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "blog",
+		Description: "Blog posts",
+		Routes:      []string{"blog/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
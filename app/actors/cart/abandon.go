@@ -0,0 +1,498 @@
+package cart
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/order"
+	"github.com/ottemo/foundation/app/models/visitor"
+)
+
+func scheduleAbandonCartEmails() error {
+	if scheduler := env.GetScheduler(); scheduler != nil {
+		if err := scheduler.RegisterTask(ConstAbandonCartTaskName, abandonCartTask); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if _, err := scheduler.ScheduleRepeat("0 * * * *", ConstAbandonCartTaskName, nil); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// abandonCartTask sends next campaign step email to abandoned carts which are due for it
+func abandonCartTask(params map[string]interface{}) error {
+	steps := getAbandonCampaignSteps()
+	if len(steps) == 0 {
+		return nil
+	}
+
+	// carts are taken not later than hour after last step is due
+	currentTime := time.Now()
+	firstStepDate := currentTime.Add(-time.Duration(steps[0].Delay) * time.Hour)
+	lastStepDate := currentTime.Add(-time.Duration(steps[len(steps)-1].Delay) * time.Hour)
+	resultCarts := getAbandonedCarts(firstStepDate, lastStepDate.Add(-time.Hour))
+
+	sentCount := 0
+	for _, resultCart := range resultCarts {
+		customInfo := utils.InterfaceToMap(resultCart["custom_info"])
+		stepIdx, due := getDueAbandonStep(steps, customInfo, utils.InterfaceToTime(resultCart["updated_at"]), currentTime)
+		if !due {
+			continue
+		}
+		step := steps[stepIdx]
+
+		emailData, ok := getCartEmailData(resultCart)
+		if !ok || len(emailData.Cart.Items) == 0 || isUnsubscribed(emailData.Visitor.Email) {
+			continue
+		}
+
+		if err := sendAbandonEmail(emailData, stepIdx+1, step); err != nil {
+			_ = env.ErrorDispatch(err)
+			continue
+		}
+		sentCount++
+	}
+
+	env.LogEvent(env.LogFields{"abandonCartCount": len(resultCarts), "sentCount": sentCount}, "abandon-cart-task")
+
+	return nil
+}
+
+// getAbandonCampaignSteps returns steps of abandoned cart campaign, if campaign is not set single step is made of
+// abandoned cart email send time setting
+func getAbandonCampaignSteps() []StructAbandonCampaignStep {
+	var steps []StructAbandonCampaignStep
+
+	if campaign := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathCartAbandonCampaign)); campaign != "" {
+		if err := json.Unmarshal([]byte(campaign), &steps); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "0f7c2b65-d841-4e39-a6b2-93e5d170c84a", err.Error())
+			return nil
+		}
+	}
+
+	// send time setting is a negative number of hours
+	if len(steps) == 0 {
+		if sendTime := utils.InterfaceToInt(env.ConfigGetValue(ConstConfigPathCartAbandonEmailSendTime)); sendTime != 0 {
+			steps = append(steps, StructAbandonCampaignStep{Delay: -sendTime})
+		}
+	}
+
+	return steps
+}
+
+// getAbandonStep returns number of campaign steps made for cart
+func getAbandonStep(customInfo map[string]interface{}) int {
+	if step, present := customInfo[ConstCustomInfoAbandonStep]; present {
+		return utils.InterfaceToInt(step)
+	}
+
+	// carts emailed before campaigns were introduced
+	if utils.InterfaceToBool(customInfo["is_abandon_email_sent"]) {
+		return 1
+	}
+
+	return 0
+}
+
+// getDueAbandonStep returns index of campaign step to be made for cart updated at given time, false if all steps
+// were made or next one is not due yet
+func getDueAbandonStep(steps []StructAbandonCampaignStep, customInfo map[string]interface{}, updatedAt time.Time, currentTime time.Time) (int, bool) {
+	stepIdx := getAbandonStep(customInfo)
+	if stepIdx >= len(steps) {
+		return stepIdx, false
+	}
+
+	stepDate := currentTime.Add(-time.Duration(steps[stepIdx].Delay) * time.Hour)
+	if updatedAt.After(stepDate) {
+		return stepIdx, false
+	}
+
+	return stepIdx, true
+}
+
+// Get the abandoned carts
+// - active
+// - were updated in our time frame
+func getAbandonedCarts(beforeDate time.Time, sinceDate time.Time) []map[string]interface{} {
+	dbEngine := db.GetDBEngine()
+	cartCollection, _ := dbEngine.GetCollection(ConstCartCollectionName)
+	if err := cartCollection.AddFilter("active", "=", true); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+	if err := cartCollection.AddFilter("updated_at", "<", beforeDate); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+	if err := cartCollection.AddFilter("updated_at", ">=", sinceDate); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+	if err := cartCollection.AddSort("updated_at", true); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	resultCarts, err := cartCollection.Load()
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return resultCarts
+}
+
+// getCartEmailData returns details needed to send abandoned cart email, false if there is no email to contact
+func getCartEmailData(resultCart map[string]interface{}) (AbandonCartEmailData, bool) {
+	var email, firstName, lastName string
+	cartID := utils.InterfaceToString(resultCart["_id"])
+	sessionID := utils.InterfaceToString(resultCart["session_id"])
+	visitorID := utils.InterfaceToString(resultCart["visitor_id"])
+
+	// try to get by visitor_id
+	if visitorID != "" {
+		vModel, _ := visitor.LoadVisitorByID(visitorID)
+		// TODO: handle this a better way or cleanse carts with nil visitorIDs
+		// for now, ignore nil visitors
+		if vModel != nil {
+			email = vModel.GetEmail()
+			firstName = vModel.GetFirstName()
+			lastName = vModel.GetLastName()
+		}
+	} else if sessionID != "" {
+		create := false
+		sessionWrapper, _ := api.GetSessionService().Get(sessionID, create)
+		if sessionWrapper != nil {
+			sCheckout := utils.InterfaceToMap(sessionWrapper.Get(checkout.ConstSessionKeyCurrentCheckout))
+
+			scInfo := utils.InterfaceToMap(sCheckout["Info"])
+			email = utils.InterfaceToString(scInfo["customer_email"])
+		}
+	}
+
+	// no email address for us to contact, move along
+	if email == "" {
+		return AbandonCartEmailData{}, false
+	}
+
+	cartEmailData := AbandonCartEmailData{
+		Visitor: AbandonVisitor{
+			Email:     email,
+			FirstName: firstName,
+			LastName:  lastName,
+		},
+		Cart: AbandonCart{
+			ID: cartID,
+		},
+	}
+
+	abandonedCart, err := cart.LoadCartByID(cartID)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return cartEmailData, false
+	}
+
+	for _, cartItem := range abandonedCart.GetItems() {
+		if itemProduct := cartItem.GetProduct(); itemProduct != nil {
+			cartEmailData.Cart.Items = append(cartEmailData.Cart.Items, AbandonCartItem{
+				Name:  itemProduct.GetName(),
+				SKU:   itemProduct.GetSku(),
+				Price: itemProduct.GetPrice(),
+				Qty:   cartItem.GetQty(),
+			})
+		}
+	}
+
+	return cartEmailData, true
+}
+
+// isUnsubscribed checks email to be unsubscribed from abandoned cart emails
+func isUnsubscribed(email string) bool {
+	unsubscribeCollection, err := db.GetCollection(ConstCartAbandonUnsubscribeCollectionName)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return false
+	}
+
+	if err := unsubscribeCollection.AddFilter("email", "=", strings.ToLower(email)); err != nil {
+		_ = env.ErrorDispatch(err)
+		return false
+	}
+
+	count, err := unsubscribeCollection.Count()
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return false
+	}
+
+	return count > 0
+}
+
+// unsubscribeEmail stops abandoned cart emails to be sent to given email
+func unsubscribeEmail(email string) error {
+	if isUnsubscribed(email) {
+		return nil
+	}
+
+	unsubscribeCollection, err := db.GetCollection(ConstCartAbandonUnsubscribeCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	_, err = unsubscribeCollection.Save(map[string]interface{}{
+		"email":      strings.ToLower(email),
+		"created_at": time.Now(),
+	})
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// makeAbandonEmailToken returns token of abandoned cart email links signed with given key and valid until given time
+func makeAbandonEmailToken(key []byte, emailID string, expiresAt time.Time) string {
+	payload := emailID + "." + strconv.FormatInt(expiresAt.Unix(), 36)
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(ConstCartAbandonEmailCollectionName + ":" + payload))
+
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parseAbandonEmailToken returns id of abandoned cart email token was made for
+//   - returns error if token is not signed with given key or is expired at given time
+func parseAbandonEmailToken(key []byte, token string, currentTime time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || key == nil {
+		return "", env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4e9a1c73-b625-4f08-9d3e-7c18b0f5a2d6", "link is not valid")
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 36, 64)
+	if err != nil || !hmac.Equal([]byte(makeAbandonEmailToken(key, parts[0], time.Unix(expiresAt, 0))), []byte(token)) {
+		return "", env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b71d3e08-5c94-4a2f-8e6b-0f2c9a7d4e51", "link is not valid")
+	}
+
+	if currentTime.Unix() > expiresAt {
+		return "", env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "62f0a9c4-d3b7-4e15-a8c6-9b1e7f5d2a03", "link has expired")
+	}
+
+	return parts[0], nil
+}
+
+// getAbandonEmailToken returns token of restore cart and unsubscribe links of abandoned cart email, blank if
+// signing secret is not set
+func getAbandonEmailToken(emailID string) string {
+	key := getSigningKey("abandon")
+	if key == nil {
+		return ""
+	}
+
+	return makeAbandonEmailToken(key, emailID, time.Now().Add(ConstAbandonEmailLinkTTL))
+}
+
+// loadAbandonEmail returns abandoned cart email record token was made for
+func loadAbandonEmail(token string) (map[string]interface{}, error) {
+	emailID, err := parseAbandonEmailToken(getSigningKey("abandon"), token, time.Now())
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	emailCollection, err := db.GetCollection(ConstCartAbandonEmailCollectionName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	record, err := emailCollection.LoadByID(emailID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return record, nil
+}
+
+// sendAbandonEmail sends campaign step email to abandoned cart owner, single use coupon is requested if step has
+// coupon settings
+//   - coupon code is a part of email, so coupon is made before email is sent and revoked if email was not sent
+func sendAbandonEmail(emailData AbandonCartEmailData, stepNumber int, step StructAbandonCampaignStep) error {
+	subject := step.Subject
+	if subject == "" {
+		subject = "It looks like you forgot something in your cart"
+	}
+
+	template := step.Template
+	if template == "" {
+		template = utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathCartAbandonEmailTemplate))
+	}
+	if template == "" {
+		return env.ErrorDispatch(env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1756ec63-7cd7-4764-a8ff-64b142fc3f9f", "Abandon cart emails want to send but the template is empty"))
+	}
+
+	emailCollection, err := db.GetCollection(ConstCartAbandonEmailCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	var couponCode string
+	if step.CouponAmount > 0 || step.CouponPercent > 0 {
+		eventData := map[string]interface{}{
+			"cart_id": emailData.Cart.ID,
+			"email":   emailData.Visitor.Email,
+			"amount":  step.CouponAmount,
+			"percent": step.CouponPercent,
+			"days":    step.CouponDays,
+		}
+		env.Event(cart.ConstEventAbandonCoupon, eventData)
+		couponCode = utils.InterfaceToString(eventData["code"])
+	}
+
+	emailID, err := emailCollection.Save(map[string]interface{}{
+		"cart_id":     emailData.Cart.ID,
+		"email":       emailData.Visitor.Email,
+		"step":        stepNumber,
+		"coupon_code": couponCode,
+		"created_at":  time.Now(),
+		"order_id":    "",
+		"revenue":     0,
+	})
+	if err != nil {
+		revokeAbandonCoupon(couponCode)
+		return env.ErrorDispatch(err)
+	}
+
+	// links lead to storefront pages, which confirm action and post it to API
+	token := getAbandonEmailToken(emailID)
+
+	templateData := utils.InterfaceToMap(emailData)
+	templateData["Step"] = stepNumber
+	templateData["Coupon"] = couponCode
+	templateData["RestoreUrl"] = app.GetStorefrontURL("cart/restore/" + token)
+	templateData["UnsubscribeUrl"] = app.GetStorefrontURL("cart/unsubscribe/" + token)
+	templateData["Site"] = map[string]interface{}{
+		"Url": app.GetStorefrontURL(""),
+	}
+
+	body, err := utils.TextTemplate(template, templateData)
+	if err == nil && token == "" {
+		err = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e0c85b3a-97d1-4f26-b4a8-3d6f1c2e9b70", "cart signing secret is not set")
+	}
+	if err == nil {
+		err = app.SendMail(emailData.Visitor.Email, subject, body)
+	}
+	if err != nil {
+		if err := emailCollection.DeleteByID(emailID); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+		revokeAbandonCoupon(couponCode)
+		return env.ErrorDispatch(err)
+	}
+
+	return flagCartAsEmailed(emailData.Cart.ID, stepNumber, emailID)
+}
+
+// revokeAbandonCoupon requests removal of coupon made for abandoned cart email which was not sent, so coupon nobody
+// received does not stay usable
+func revokeAbandonCoupon(couponCode string) {
+	if couponCode == "" {
+		return
+	}
+	env.Event(cart.ConstEventAbandonCouponRevoke, map[string]interface{}{"code": couponCode})
+}
+
+// flagCartAsEmailed will set campaign step and email made on carts that have been sent an abandoned cart email
+//   - cart record is updated directly, so cart update time which campaign steps are counted from is kept
+func flagCartAsEmailed(cartID string, stepNumber int, emailID string) error {
+	cartCollection, err := db.GetCollection(ConstCartCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	record, err := cartCollection.LoadByID(cartID)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	info := utils.InterfaceToMap(record["custom_info"])
+	info["is_abandon_email_sent"] = true
+	info["abandon_email_sent_at"] = time.Now()
+	info[ConstCustomInfoAbandonStep] = stepNumber
+	info[ConstCustomInfoAbandonEmail] = emailID
+	record["custom_info"] = info
+
+	if _, err := cartCollection.Save(record); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// restoreAbandonedCart copies items of abandoned cart to given cart and deactivates abandoned one, so campaign is
+// over for it, restored cart keeps email it was restored by for recovery attribution
+//   - items which can not be added anymore are skipped
+func restoreAbandonedCart(abandonedCart cart.InterfaceCart, currentCart cart.InterfaceCart, emailID string) error {
+	if abandonedCart.GetID() != currentCart.GetID() {
+		for _, cartItem := range abandonedCart.GetItems() {
+			if _, err := currentCart.AddItem(cartItem.GetProductID(), cartItem.GetQty(), cartItem.GetOptions()); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+
+		if err := abandonedCart.Deactivate(); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if err := abandonedCart.Save(); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	customInfo := currentCart.GetCustomInfo()
+	if customInfo == nil {
+		customInfo = make(map[string]interface{})
+	}
+	customInfo[ConstCustomInfoAbandonEmail] = emailID
+	currentCart.SetCustomInfo(customInfo)
+
+	if err := currentCart.Save(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// attributeRecoveredOrder stores order made of cart which was sent abandoned cart email within the email record
+func attributeRecoveredOrder(checkoutCart cart.InterfaceCart, checkoutOrder order.InterfaceOrder) error {
+	emailID := utils.InterfaceToString(checkoutCart.GetCustomInfo()[ConstCustomInfoAbandonEmail])
+	if emailID == "" {
+		return nil
+	}
+
+	emailCollection, err := db.GetCollection(ConstCartAbandonEmailCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	record, err := emailCollection.LoadByID(emailID)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	record["order_id"] = checkoutOrder.GetID()
+	record["revenue"] = checkoutOrder.GetGrandTotal()
+	record["recovered_at"] = time.Now()
+
+	if _, err := emailCollection.Save(record); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
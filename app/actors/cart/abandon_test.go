@@ -0,0 +1,129 @@
+package cart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/utils"
+
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/order"
+)
+
+// TestGetDueAbandonStep tests campaign step to be made for abandoned cart
+func TestGetDueAbandonStep(t *testing.T) {
+	steps := []StructAbandonCampaignStep{{Delay: 1}, {Delay: 24}, {Delay: 72}}
+	currentTime := time.Now()
+
+	var tests = []struct {
+		customInfo  map[string]interface{}
+		updatedAgo  time.Duration
+		expectedIdx int
+		expectedDue bool
+	}{
+		{map[string]interface{}{}, 30 * time.Minute, 0, false},
+		{map[string]interface{}{}, 2 * time.Hour, 0, true},
+		{map[string]interface{}{ConstCustomInfoAbandonStep: 1}, 2 * time.Hour, 1, false},
+		{map[string]interface{}{ConstCustomInfoAbandonStep: 1}, 25 * time.Hour, 1, true},
+		{map[string]interface{}{"is_abandon_email_sent": true}, 25 * time.Hour, 1, true},
+		{map[string]interface{}{ConstCustomInfoAbandonStep: 2}, 73 * time.Hour, 2, true},
+		{map[string]interface{}{ConstCustomInfoAbandonStep: 3}, 100 * time.Hour, 3, false},
+	}
+
+	for idx, test := range tests {
+		stepIdx, due := getDueAbandonStep(steps, test.customInfo, currentTime.Add(-test.updatedAgo), currentTime)
+		if stepIdx != test.expectedIdx || due != test.expectedDue {
+			t.Errorf("test %d: expected step %d due %v, got %d %v", idx, test.expectedIdx, test.expectedDue, stepIdx, due)
+		}
+	}
+}
+
+// TestAbandonEmailToken tests abandoned cart email links token verification
+func TestAbandonEmailToken(t *testing.T) {
+	key := []byte("test key")
+	currentTime := time.Now()
+	token := makeAbandonEmailToken(key, "email-id", currentTime.Add(time.Hour))
+
+	if emailID, err := parseAbandonEmailToken(key, token, currentTime); err != nil || emailID != "email-id" {
+		t.Errorf("valid token should be accepted, got %q %v", emailID, err)
+	}
+
+	var tests = []struct {
+		key   []byte
+		token string
+		time  time.Time
+	}{
+		{key, token, currentTime.Add(2 * time.Hour)},
+		{[]byte("other key"), token, currentTime},
+		{nil, token, currentTime},
+		{key, "other-id" + token[len("email-id"):], currentTime},
+		{key, makeAbandonEmailToken(key, "email-id", currentTime.Add(time.Hour))[:len(token)-1], currentTime},
+		{key, "email-id", currentTime},
+		{key, "", currentTime},
+	}
+
+	for idx, test := range tests {
+		if _, err := parseAbandonEmailToken(test.key, test.token, test.time); err == nil {
+			t.Errorf("test %d: token %q should not be accepted", idx, test.token)
+		}
+	}
+}
+
+// TestAttributeRecoveredOrder tests order made of emailed cart to be stored within the email record
+func TestAttributeRecoveredOrder(t *testing.T) {
+	emailCollection, err := db.GetCollection(ConstCartAbandonEmailCollectionName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emailID, err := emailCollection.Save(map[string]interface{}{
+		"cart_id":    "",
+		"email":      "recovered@example.com",
+		"step":       1,
+		"created_at": time.Now(),
+		"order_id":   "",
+		"revenue":    0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := emailCollection.DeleteByID(emailID); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	checkoutCart, err := cart.GetCartModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkoutOrder, err := order.GetOrderModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkoutOrder.SetID("recovered-order"); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkoutOrder.Set("grand_total", 42.5); err != nil {
+		t.Fatal(err)
+	}
+
+	// cart which was not emailed is not attributed
+	if err := attributeRecoveredOrder(checkoutCart, checkoutOrder); err != nil {
+		t.Error(err)
+	}
+
+	checkoutCart.SetCustomInfo(map[string]interface{}{ConstCustomInfoAbandonEmail: emailID})
+	if err := attributeRecoveredOrder(checkoutCart, checkoutOrder); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := emailCollection.LoadByID(emailID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record["order_id"] != "recovered-order" || utils.InterfaceToFloat64(record["revenue"]) != 42.5 {
+		t.Errorf("order should be attributed to email, got order %v revenue %v", record["order_id"], record["revenue"])
+	}
+}
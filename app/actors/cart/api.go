@@ -2,11 +2,15 @@ package cart
 
 import (
 	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/media"
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
+	"github.com/ottemo/foundation/app/models/visitor"
 )
 
 // setupAPI setups package related API endpoint routines
@@ -18,6 +22,22 @@ func setupAPI() error {
 	service.POST("cart/item", APICartItemAdd)
 	service.PUT("cart/item/:itemIdx/:qty", APICartItemUpdate)
 	service.DELETE("cart/item/:itemIdx", APICartItemDelete)
+	service.POST("cart/restore/:token", APIRestoreAbandonedCart)
+	service.POST("cart/unsubscribe/:token", APIUnsubscribeAbandonedCart)
+
+	for cartType, route := range listRoutes {
+		service.GET(route, cartTypeHandler(cartType, APIListVisitorCarts))
+		service.POST(route, cartTypeHandler(cartType, APICreateVisitorCart))
+		service.GET(route+"/:cartID", cartTypeHandler(cartType, APIGetVisitorCart))
+		service.PUT(route+"/:cartID", cartTypeHandler(cartType, APIUpdateVisitorCart))
+		service.DELETE(route+"/:cartID", cartTypeHandler(cartType, APIDeleteVisitorCart))
+		service.POST(route+"/:cartID/items", cartTypeHandler(cartType, APIAddVisitorCartItem))
+		service.DELETE(route+"/:cartID/items/:itemIdx", cartTypeHandler(cartType, APIRemoveVisitorCartItem))
+		service.POST(route+"/:cartID/items/:itemIdx/cart", cartTypeHandler(cartType, APIMoveItemToCart))
+		service.POST(route+"/:cartID/cart-items/:itemIdx", cartTypeHandler(cartType, APIMoveItemFromCart))
+		service.POST(route+"/:cartID/share", cartTypeHandler(cartType, APIShareVisitorCart))
+		service.DELETE(route+"/:cartID/share", cartTypeHandler(cartType, APIUnshareVisitorCart))
+	}
 
 	return nil
 }
@@ -37,47 +57,19 @@ func APICartInfo(context api.InterfaceApplicationContext) (interface{}, error) {
 	}
 
 	if currentCart != nil {
+		currencyCode := currency.GetCurrentCurrency(context)
 
-		mediaStorage, err := media.GetMediaStorage()
+		items, err = getCartItemsInfo(currentCart, currencyCode)
 		if err != nil {
 			return nil, env.ErrorDispatch(err)
 		}
 
-		cartItems := currentCart.GetItems()
-		for _, cartItem := range cartItems {
-
-			item := make(map[string]interface{})
-
-			item["_id"] = cartItem.GetID()
-			item["idx"] = cartItem.GetIdx()
-			item["qty"] = cartItem.GetQty()
-			item["pid"] = cartItem.GetProductID()
-			item["options"] = cartItem.GetOptions()
-
-			if product := cartItem.GetProduct(); product != nil {
-
-				productData := make(map[string]interface{})
-
-				productData["name"] = product.GetName()
-				productData["sku"] = product.GetSku()
-				productData["price"] = product.GetPrice()
-				productData["weight"] = product.GetWeight()
-				productData["options"] = product.GetOptions()
-
-				productData["image"], err = mediaStorage.GetSizes(product.GetModelName(), product.GetID(), "image", product.GetDefaultImage())
-				if err != nil {
-					_ = env.ErrorDispatch(err)
-				}
-
-				item["product"] = productData
-			}
-
-			items = append(items, item)
-		}
-
+		result["currency"] = currencyCode
 		result["visitor_id"] = currentCart.GetVisitorID()
 		result["cart_info"] = currentCart.GetCartInfo()
 		result["items"] = items
+
+		result["violations"] = getCheckoutViolations(context)
 	}
 
 	return result, nil
@@ -85,6 +77,7 @@ func APICartInfo(context api.InterfaceApplicationContext) (interface{}, error) {
 
 // APICartItemAdd adds specified product to cart
 //   - "productID" and "qty" should be specified as arguments
+//   - checkout restriction rules violations of updated cart are returned
 func APICartItemAdd(context api.InterfaceApplicationContext) (interface{}, error) {
 
 	// check request context
@@ -136,11 +129,12 @@ func APICartItemAdd(context api.InterfaceApplicationContext) (interface{}, error
 	eventData = map[string]interface{}{"session": context.GetSession(), "cart": currentCart, "idx": nil, "pid": pid, "qty": qty, "options": options}
 	env.Event(ConstEventAPIUpdate, eventData)
 
-	return "ok", nil
+	return map[string]interface{}{"violations": getCheckoutViolations(context)}, nil
 }
 
 // APICartItemUpdate changes qty and/or option for cart item
 //   - "itemIdx" and "qty" should be specified as arguments
+//   - checkout restriction rules violations of updated cart are returned
 func APICartItemUpdate(context api.InterfaceApplicationContext) (interface{}, error) {
 
 	// check request context
@@ -198,7 +192,7 @@ func APICartItemUpdate(context api.InterfaceApplicationContext) (interface{}, er
 
 	env.Event(ConstEventAPIUpdate, eventData)
 
-	return "ok", nil
+	return map[string]interface{}{"violations": getCheckoutViolations(context)}, nil
 }
 
 // APICartItemDelete removes specified item from cart item from cart
@@ -234,3 +228,465 @@ func APICartItemDelete(context api.InterfaceApplicationContext) (interface{}, er
 
 	return "ok", nil
 }
+
+// APIRestoreAbandonedCart rebuilds abandoned cart from campaign email link in current session
+//   - "token" should be specified as argument
+func APIRestoreAbandonedCart(context api.InterfaceApplicationContext) (interface{}, error) {
+	emailRecord, err := loadAbandonEmail(context.GetRequestArgument("token"))
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// cart which was checked out or restored before has nothing to restore
+	abandonedCart, err := cart.LoadCartByID(utils.InterfaceToString(emailRecord["cart_id"]))
+	if err != nil || !abandonedCart.IsActive() {
+		return "ok", nil
+	}
+
+	currentCart, err := cart.GetCurrentCart(context, true)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := restoreAbandonedCart(abandonedCart, currentCart, utils.InterfaceToString(emailRecord["_id"])); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
+
+// APIUnsubscribeAbandonedCart stops abandoned cart emails to email campaign link was sent to
+//   - "token" should be specified as argument
+func APIUnsubscribeAbandonedCart(context api.InterfaceApplicationContext) (interface{}, error) {
+	emailRecord, err := loadAbandonEmail(context.GetRequestArgument("token"))
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := unsubscribeEmail(utils.InterfaceToString(emailRecord["email"])); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
+
+// getCheckoutViolations returns checkout restriction rules violations of current cart contents
+func getCheckoutViolations(context api.InterfaceApplicationContext) []checkout.StructViolation {
+	currentCheckout, err := checkout.GetCurrentCheckout(context, false)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return make([]checkout.StructViolation, 0)
+	}
+
+	return checkout.ValidateCheckout(currentCheckout)
+}
+
+// getCartItemsInfo returns cart items information with product details in given currency
+func getCartItemsInfo(currentCart cart.InterfaceCart, currencyCode string) ([]map[string]interface{}, error) {
+	var items []map[string]interface{}
+
+	mediaStorage, err := media.GetMediaStorage()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	for _, cartItem := range currentCart.GetItems() {
+
+		item := make(map[string]interface{})
+
+		item["_id"] = cartItem.GetID()
+		item["idx"] = cartItem.GetIdx()
+		item["qty"] = cartItem.GetQty()
+		item["pid"] = cartItem.GetProductID()
+		item["options"] = cartItem.GetOptions()
+
+		if product := cartItem.GetProduct(); product != nil {
+
+			productData := make(map[string]interface{})
+
+			productData["name"] = product.GetName()
+			productData["sku"] = product.GetSku()
+			productData["price"] = product.GetPrice()
+			productData["display_price"], err = currency.ConvertFromBase(currency.GetBaseEquivalentPrice(product, currencyCode), currencyCode)
+			if err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+			productData["weight"] = product.GetWeight()
+			productData["options"] = product.GetOptions()
+
+			productData["image"], err = mediaStorage.GetSizes(product.GetModelName(), product.GetID(), "image", product.GetDefaultImage())
+			if err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+
+			item["product"] = productData
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// cartTypeHandler makes API handler for saved carts or wishlists of given type
+func cartTypeHandler(cartType string, handler func(api.InterfaceApplicationContext, string) (interface{}, error)) api.FuncAPIHandler {
+	return func(context api.InterfaceApplicationContext) (interface{}, error) {
+		return handler(context, cartType)
+	}
+}
+
+// loadVisitorCart loads saved cart or wishlist specified in "cartID" argument
+//   - cart should belong to current visitor, or valid share signature should be specified if shared access allowed
+//   - returns loaded cart and flag it belongs to current visitor
+func loadVisitorCart(context api.InterfaceApplicationContext, cartType string, allowShared bool) (cart.InterfaceCart, bool, error) {
+	cartID := context.GetRequestArgument("cartID")
+	if cartID == "" {
+		return nil, false, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "0e6b3f72-94a1-4c5d-b8e0-7a2d15c9f346", "cartID should be specified")
+	}
+
+	listCart, err := cart.LoadCartByID(cartID)
+	if err != nil || listCart.GetType() != cartType {
+		return nil, false, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "8a3d1e65-2b7f-4c90-a5e4-f61c0d9b2783", "cart not found")
+	}
+
+	visitorID := visitor.GetCurrentVisitorID(context)
+	if visitorID != "" && listCart.GetVisitorID() == visitorID {
+		return listCart, true, nil
+	}
+
+	signature := utils.InterfaceToString(api.GetArgumentOrContentValue(context, ConstShareSignatureArgument))
+	if allowShared && checkShareSignature(listCart, signature) {
+		return listCart, false, nil
+	}
+
+	return nil, false, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c94f2a07-6e1b-4d38-9f5c-2b8e0a7d4c16", "Operation not allowed.")
+}
+
+// getVisitorCartInfo returns saved cart or wishlist information
+func getVisitorCartInfo(context api.InterfaceApplicationContext, listCart cart.InterfaceCart, owner bool) (map[string]interface{}, error) {
+	currencyCode := currency.GetCurrentCurrency(context)
+
+	items, err := getCartItemsInfo(listCart, currencyCode)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	result := map[string]interface{}{
+		"_id":        listCart.GetID(),
+		"type":       listCart.GetType(),
+		"name":       listCart.GetName(),
+		"owner":      owner,
+		"shared":     getShareSignature(listCart) != "",
+		"updated_at": listCart.GetLastUpdateTime(),
+		"subtotal":   listCart.GetSubtotal(),
+		"currency":   currencyCode,
+		"items":      items,
+	}
+
+	return result, nil
+}
+
+// getRequestedItemIdx returns item index specified in "itemIdx" argument
+func getRequestedItemIdx(context api.InterfaceApplicationContext) (int, error) {
+	reqItemIdx := context.GetRequestArgument("itemIdx")
+	if reqItemIdx == "" {
+		return 0, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "5d8e0c14-7a39-4b62-81f5-c3a9e6b2d047", "itemIdx should be specified")
+	}
+
+	itemIdx, err := utils.StringToInteger(reqItemIdx)
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	return itemIdx, nil
+}
+
+// APIListVisitorCarts returns saved carts or wishlists of current visitor
+func APIListVisitorCarts(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	visitorID := visitor.GetCurrentVisitorID(context)
+	if visitorID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "7f1c4d96-0a2e-4b85-93d7-e5b28c6a1f30", "not registered visitor")
+	}
+
+	cartIDs, err := getVisitorCarts(visitorID, cartType)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var result []map[string]interface{}
+	for _, cartID := range cartIDs {
+		listCart, err := cart.LoadCartByID(cartID)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+
+		result = append(result, map[string]interface{}{
+			"_id":        listCart.GetID(),
+			"name":       listCart.GetName(),
+			"shared":     getShareSignature(listCart) != "",
+			"updated_at": listCart.GetLastUpdateTime(),
+			"count":      len(listCart.GetItems()),
+		})
+	}
+
+	return result, nil
+}
+
+// APICreateVisitorCart creates named saved cart or wishlist for current visitor
+//   - "name" should be specified in content
+//   - "from_cart" set to true moves all items of current cart to created one
+func APICreateVisitorCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	visitorID := visitor.GetCurrentVisitorID(context)
+	if visitorID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3b9a6e20-d4c7-4f18-a2e5-08d1f7c3b694", "not registered visitor")
+	}
+
+	name := utils.InterfaceToString(api.GetArgumentOrContentValue(context, "name"))
+	if name == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "e2c80f5a-6b13-4d97-8a4c-91f3d0b7e526", "name should be specified")
+	}
+
+	listCart, err := cart.GetCartModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := listCart.SetType(cartType); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := listCart.SetName(name); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := listCart.SetVisitorID(visitorID); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := listCart.SetStoreID(api.GetCurrentStoreID()); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := listCart.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if utils.InterfaceToBool(api.GetArgumentOrContentValue(context, "from_cart")) {
+		currentCart, err := cart.GetCurrentCart(context, false)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		if currentCart != nil {
+			for _, item := range currentCart.GetItems() {
+				if _, err := moveCartItem(currentCart, listCart, item.GetIdx()); err != nil {
+					return nil, env.ErrorDispatch(err)
+				}
+			}
+		}
+	}
+
+	return getVisitorCartInfo(context, listCart, true)
+}
+
+// APIGetVisitorCart returns saved cart or wishlist information
+//   - "cartID" should be specified as argument
+//   - cart of other visitor is accessible with "signature" argument obtained by its owner
+func APIGetVisitorCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, owner, err := loadVisitorCart(context, cartType, true)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return getVisitorCartInfo(context, listCart, owner)
+}
+
+// APIUpdateVisitorCart renames saved cart or wishlist
+//   - "cartID" should be specified as argument, new "name" in content
+func APIUpdateVisitorCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	name := utils.InterfaceToString(api.GetArgumentOrContentValue(context, "name"))
+	if name == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "91d5b7e3-2c40-4a6f-b8d2-6e0f3a1c95b8", "name should be specified")
+	}
+
+	if err := listCart.SetName(name); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := listCart.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return getVisitorCartInfo(context, listCart, true)
+}
+
+// APIDeleteVisitorCart deletes saved cart or wishlist
+//   - "cartID" should be specified as argument
+func APIDeleteVisitorCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := listCart.Delete(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
+
+// APIAddVisitorCartItem adds product to saved cart or wishlist
+//   - "cartID" should be specified as argument
+//   - "pid", "qty" and "options" should be specified in content
+func APIAddVisitorCartItem(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	pid := utils.InterfaceToString(api.GetArgumentOrContentValue(context, "pid"))
+	if pid == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4a7c2e98-b1d6-4f05-9e3a-c8b0f5d21e67", "pid should be specified")
+	}
+
+	qty := 1
+	if requestedQty := api.GetArgumentOrContentValue(context, "qty"); requestedQty != nil && requestedQty != "" {
+		qty = utils.InterfaceToInt(requestedQty)
+	}
+
+	options := utils.InterfaceToMap(api.GetArgumentOrContentValue(context, "options"))
+
+	if _, err := listCart.AddItem(pid, qty, options); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	watchWishlistItems(listCart)
+	if err := listCart.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return getVisitorCartInfo(context, listCart, true)
+}
+
+// APIRemoveVisitorCartItem removes item from saved cart or wishlist
+//   - "cartID" and "itemIdx" should be specified as arguments
+func APIRemoveVisitorCartItem(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	itemIdx, err := getRequestedItemIdx(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := listCart.RemoveItem(itemIdx); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	watchWishlistItems(listCart)
+	if err := listCart.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return getVisitorCartInfo(context, listCart, true)
+}
+
+// APIMoveItemToCart moves item of saved cart or wishlist to current cart
+//   - "cartID" and "itemIdx" should be specified as arguments
+func APIMoveItemToCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	itemIdx, err := getRequestedItemIdx(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	currentCart, err := cart.GetCurrentCart(context, true)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	cartItem, err := moveCartItem(listCart, currentCart, itemIdx)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	eventData := map[string]interface{}{"session": context.GetSession(), "cart": currentCart, "idx": cartItem.GetIdx(), "pid": cartItem.GetProductID(), "qty": cartItem.GetQty(), "options": cartItem.GetOptions()}
+	env.Event(ConstEventAPIUpdate, eventData)
+
+	return getVisitorCartInfo(context, listCart, true)
+}
+
+// APIMoveItemFromCart moves item of current cart to saved cart or wishlist
+//   - "cartID" and "itemIdx" of current cart item should be specified as arguments
+func APIMoveItemFromCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	itemIdx, err := getRequestedItemIdx(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	currentCart, err := cart.GetCurrentCart(context, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if currentCart == nil {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b6e03d58-1f9c-4a27-8d41-7c5a2e9f0b13", "there is no current cart")
+	}
+
+	if _, err := moveCartItem(currentCart, listCart, itemIdx); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	eventData := map[string]interface{}{"session": context.GetSession(), "cart": currentCart, "idx": itemIdx, "qty": 0}
+	env.Event(ConstEventAPIUpdate, eventData)
+
+	return getVisitorCartInfo(context, listCart, true)
+}
+
+// APIShareVisitorCart makes signed link to saved cart or wishlist, links made before become invalid
+//   - "cartID" should be specified as argument
+func APIShareVisitorCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := shareCart(listCart); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := listCart.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	signature := getShareSignature(listCart)
+	result := map[string]interface{}{
+		"signature": signature,
+		"url":       app.GetFoundationURL(listRoutes[cartType] + "/" + listCart.GetID() + "?" + ConstShareSignatureArgument + "=" + signature),
+	}
+
+	return result, nil
+}
+
+// APIUnshareVisitorCart revokes links to saved cart or wishlist
+//   - "cartID" should be specified as argument
+func APIUnshareVisitorCart(context api.InterfaceApplicationContext, cartType string) (interface{}, error) {
+	listCart, _, err := loadVisitorCart(context, cartType, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	unshareCart(listCart)
+	if err := listCart.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
@@ -1,7 +1,14 @@
 package cart
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
 )
 
 func setupConfig() error {
@@ -36,7 +43,44 @@ func setupConfig() error {
 		Editor:      "multiline_text",
 		Options:     "",
 		Label:       "Abandoned Cart Email - Template",
-		Description: "",
+		Description: "Template data: Visitor, Cart (ID, Items), Step, Coupon, RestoreUrl, UnsubscribeUrl and Site.",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:    ConstConfigPathCartAbandonCampaign,
+		Value:   `[]`,
+		Type:    env.ConstConfigTypeJSON,
+		Editor:  "multiline_text",
+		Options: "",
+		Label:   "Abandoned Cart Campaign",
+		Description: `Abandoned cart email steps, pattern:
+[
+	{"delay": 1, "subject": "You left something in your cart"},
+	{"delay": 24, "subject": "Still thinking?", "template": "...", "coupon_percent": 10, "coupon_days": 3},
+	...
+]
+delay is counted in hours since cart was updated, blank template means "Abandoned Cart Email - Template" value,
+make it "[]" to send single email using "Abandoned Cart Email - Send Time" setting`,
+		Image: "",
+	}, env.FuncConfigValueValidator(validateAbandonCampaign))
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathWishlistNotifications,
+		Value:       false,
+		Type:        env.ConstConfigTypeBoolean,
+		Editor:      "boolean",
+		Options:     nil,
+		Label:       "Wishlist Notifications",
+		Description: "Send email to visitor when products of their wishlist become cheaper or back in stock.",
 		Image:       "",
 	}, nil)
 
@@ -44,5 +88,86 @@ func setupConfig() error {
 		return env.ErrorDispatch(err)
 	}
 
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathWishlistEmailTemplate,
+		Value:       "",
+		Type:        env.ConstConfigTypeHTML,
+		Editor:      "multiline_text",
+		Options:     "",
+		Label:       "Wishlist Notification Email - Template",
+		Description: "Template data: Visitor, Wishlist, Items (Name, SKU, Price, OldPrice, PriceDrop, BackInStock) and Site.",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathCartSigningSecret,
+		Value:       "",
+		Type:        env.ConstConfigTypeSecret,
+		Editor:      "password",
+		Options:     nil,
+		Label:       "Cart Links Signing Secret",
+		Description: "Secret cart share and abandoned cart email links are signed with, new value revokes links given before. Random value is made if blank.",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	// signing secret is made on first start, so links are not signed with a key known beforehand
+	if utils.InterfaceToString(config.GetValue(ConstConfigPathCartSigningSecret)) == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if err := config.SetValue(ConstConfigPathCartSigningSecret, hex.EncodeToString(secret)); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
 	return nil
 }
+
+// getSigningKey returns key for cart links signature of given purpose, nil if signing secret is not set
+//   - key is derived from signing secret, so signatures made for one purpose are not valid for other one
+func getSigningKey(purpose string) []byte {
+	secret := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathCartSigningSecret))
+	if secret == "" {
+		return nil
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(purpose))
+
+	return mac.Sum(nil)
+}
+
+// validateAbandonCampaign checks abandoned cart campaign steps to have increasing delays and sane coupon settings
+func validateAbandonCampaign(value interface{}) (interface{}, error) {
+	stringValue := utils.InterfaceToString(value)
+	if stringValue == "" {
+		stringValue = "[]"
+	}
+
+	var steps []StructAbandonCampaignStep
+	if err := json.Unmarshal([]byte(stringValue), &steps); err != nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6b2e9f14-83c7-4d0a-a5e1-c90f37d2b846", "campaign steps should be array of steps: "+err.Error())
+	}
+
+	previousDelay := 0
+	for _, step := range steps {
+		if step.Delay <= previousDelay {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e07d4c35-19fa-4b82-9d6e-5a3f812c07b9", "step delays should be positive and increasing")
+		}
+		if step.CouponAmount < 0 || step.CouponPercent < 0 || step.CouponPercent > 100 || step.CouponDays < 0 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a93f60d2-7b1e-4c58-8e04-d26b5e9a1f73", "wrong coupon settings of step")
+		}
+		previousDelay = step.Delay
+	}
+
+	return stringValue, nil
+}
@@ -23,14 +23,45 @@ const (
 
 	ConstConfigPathCartAbandonEmailSendTime = "general.checkout.abandonEmailSendTime"
 	ConstConfigPathCartAbandonEmailTemplate = "general.checkout.abandonEmailTemplate"
+	ConstConfigPathCartAbandonCampaign      = "general.checkout.abandonCampaign"
+
+	ConstCartAbandonEmailCollectionName       = "cart_abandon_email"
+	ConstCartAbandonUnsubscribeCollectionName = "cart_abandon_unsubscribe"
+
+	ConstAbandonCartTaskName = "abandonCartEmail"
+	ConstAbandonEmailLinkTTL = 30 * 24 * time.Hour // time restore cart and unsubscribe links of email are valid for
+
+	ConstConfigPathWishlistNotifications = "general.checkout.wishlistNotifications"
+	ConstConfigPathWishlistEmailTemplate = "general.checkout.wishlistEmailTemplate"
+
+	ConstWishlistNotificationTaskName = "wishlistNotification"
+
+	ConstConfigPathCartSigningSecret = "general.checkout.cartSigningSecret"
+
+	ConstCustomInfoShareSalt     = "share_salt"     // salt of saved cart or wishlist share link signature
+	ConstCustomInfoWishlistWatch = "wishlist_watch" // last known price and stock availability of wishlist items
+	ConstCustomInfoAbandonStep   = "abandon_step"   // number of abandoned cart campaign steps made for cart
+	ConstCustomInfoAbandonEmail  = "abandon_email"  // id of last abandoned cart email, used for recovery attribution
+
+	ConstShareSignatureArgument = "signature"
 )
 
+// listRoutes are API resources of visitor saved carts and wishlists
+var listRoutes = map[string]string{
+	cart.ConstCartTypeSaved:    "visit/carts",
+	cart.ConstCartTypeWishlist: "visit/wishlists",
+}
+
 // DefaultCart is a default implementer of InterfaceCart
 type DefaultCart struct {
 	id string
 
 	VisitorID string
 	SessionID string
+	StoreID   string
+
+	Type string
+	Name string
 
 	Info       map[string]interface{}
 	CustomInfo map[string]interface{}
@@ -55,6 +86,18 @@ type DefaultCartItem struct {
 	Cart *DefaultCart
 }
 
+// StructAbandonCampaignStep describes abandoned cart campaign step, delay is counted in hours since last cart update
+//   - blank template means template of "general.checkout.abandonEmailTemplate" setting
+//   - coupon amount or percent makes single use coupon valid for coupon days
+type StructAbandonCampaignStep struct {
+	Delay         int     `json:"delay"`
+	Subject       string  `json:"subject"`
+	Template      string  `json:"template"`
+	CouponAmount  float64 `json:"coupon_amount"`
+	CouponPercent float64 `json:"coupon_percent"`
+	CouponDays    int     `json:"coupon_days"`
+}
+
 // AbandonCartEmailData is a container for carts and visitors who have items in
 // their cart and still have a valid session.
 type AbandonCartEmailData struct {
@@ -70,15 +113,16 @@ type AbandonVisitor struct {
 	LastName  string
 }
 
-// AbandonCart is a struct holding the ID of the abandoned cart.
+// AbandonCart is a struct holding the ID and items of the abandoned cart.
 type AbandonCart struct {
-	ID string
-	// Items []AbandonCartItem
+	ID    string
+	Items []AbandonCartItem
 }
 
-// type AbandonCartItem struct {
-// 	Name  string
-// 	SKU   string
-// 	Price float64
-// 	Image string
-// }
+// AbandonCartItem is a struct holding product details of abandoned cart item.
+type AbandonCartItem struct {
+	Name  string
+	SKU   string
+	Price float64
+	Qty   int
+}
@@ -5,7 +5,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/app/models/visitor"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
@@ -213,14 +215,24 @@ func (it *DefaultCart) SetQty(itemIdx int, qty int) error {
 }
 
 // GetSubtotal returns subtotal for cart items
+//   - item amounts are rounded before summing up for per line rounding level
 func (it *DefaultCart) GetSubtotal() float64 {
 
 	if it.Subtotal == 0 {
+		roundingMode := checkout.GetRoundingMode()
+		perLineRounding := checkout.IsPerLineRounding()
+
+		var subtotal utils.Money
 		for _, cartItem := range it.Items {
 			if cartProduct := cartItem.GetProduct(); cartProduct != nil {
-				it.Subtotal += cartProduct.GetPrice() * float64(cartItem.GetQty())
+				itemAmount := utils.MoneyFromFloat(cartProduct.GetPrice()).Mul(cartItem.GetQty())
+				if perLineRounding {
+					itemAmount = itemAmount.Round(roundingMode)
+				}
+				subtotal += itemAmount
 			}
 		}
+		it.Subtotal = subtotal.Round(roundingMode).Float64()
 	}
 
 	return it.Subtotal
@@ -320,6 +332,9 @@ func (it *DefaultCart) MakeCartForVisitor(visitorID string) error {
 		if err := newCart.SetVisitorID(visitorID); err != nil {
 			return env.ErrorDispatch(err)
 		}
+		if err := newCart.SetStoreID(api.GetCurrentStoreID()); err != nil {
+			return env.ErrorDispatch(err)
+		}
 		if err := newCart.Activate(); err != nil {
 			return env.ErrorDispatch(err)
 		}
@@ -340,7 +355,11 @@ func (it *DefaultCart) MakeCartForVisitor(visitorID string) error {
 
 // Activate makes cart active
 //   - only one cart can be active for particular visitor
+//   - saved carts and wishlists can not be active
 func (it *DefaultCart) Activate() error {
+	if it.Type != cart.ConstCartTypeCart {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e4a71c29-0d5b-4f83-b6e2-91a7d3c05f48", "only cart of '"+cart.ConstCartTypeCart+"' type can be activated")
+	}
 	it.Active = true
 	return nil
 }
@@ -378,6 +397,43 @@ func (it *DefaultCart) SetSessionID(sessionID string) error {
 	return nil
 }
 
+// GetStoreID returns id of store cart was created in
+func (it *DefaultCart) GetStoreID() string {
+	return it.StoreID
+}
+
+// SetStoreID sets id of store cart belongs to
+func (it *DefaultCart) SetStoreID(storeID string) error {
+	it.StoreID = storeID
+	return nil
+}
+
+// GetType returns cart type: regular cart, saved cart or wishlist
+func (it *DefaultCart) GetType() string {
+	return it.Type
+}
+
+// SetType sets cart type, it should be one of cart.ConstCartType... values
+func (it *DefaultCart) SetType(cartType string) error {
+	switch cartType {
+	case cart.ConstCartTypeCart, cart.ConstCartTypeSaved, cart.ConstCartTypeWishlist:
+		it.Type = cartType
+		return nil
+	}
+	return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5b0e3c8a-7f2d-4e61-a9d4-3c81f6e02b57", "unknown cart type '"+cartType+"'")
+}
+
+// GetName returns name visitor gave to saved cart or wishlist
+func (it *DefaultCart) GetName() string {
+	return it.Name
+}
+
+// SetName sets name of saved cart or wishlist
+func (it *DefaultCart) SetName(name string) error {
+	it.Name = name
+	return nil
+}
+
 // GetLastUpdateTime returns cart last update time
 func (it *DefaultCart) GetLastUpdateTime() time.Time {
 	return it.UpdatedAt
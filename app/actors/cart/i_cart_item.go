@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/app/models/cart"
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/app/models/store"
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
 )
@@ -130,6 +131,15 @@ func (it *DefaultCartItem) ValidateProduct() error {
 		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "ab067cfd-a3a3-405c-9a5a-b39ad413f3c4", "Item with Product ID: "+it.GetProductID()+" is not currently available")
 	}
 
+	// products not assigned to store of cart can't be bought there, store of request is used for untagged carts
+	var storeID string
+	if it.Cart != nil {
+		storeID = it.Cart.GetStoreID()
+	}
+	if !store.IsVisibleInStore(storeID, store.ConstObjectTypeProduct, it.GetProductID()) {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9b4e1f7a-c352-4d86-a0e9-6f2c8d5b3a17", "Item with Product ID: "+it.GetProductID()+" is not currently available")
+	}
+
 	// checking for right options
 	if cartInstance := it.Cart; cartInstance != nil {
 		if err := cartInstance.checkOptions(cartProduct.GetOptions(), it.GetOptions()); err != nil {
@@ -137,9 +147,20 @@ func (it *DefaultCartItem) ValidateProduct() error {
 		}
 	}
 
+	// saved carts and wishlists could keep products which are out of stock
+	if it.Cart != nil && it.Cart.Type != cart.ConstCartTypeCart {
+		return nil
+	}
+
+	// qty reserved by other carts is not available, but cart could keep qty it has reserved itself
 	allowOversell := utils.InterfaceToBool(env.ConfigGetValue(checkout.ConstConfigPathOversell))
-	if !allowOversell && product.GetRegisteredStock() != nil {
-		if qty := utils.InterfaceToInt(cartProduct.Get("qty")); qty < it.GetQty() {
+	if stockManager := product.GetRegisteredStock(); !allowOversell && stockManager != nil {
+		var cartID string
+		if it.Cart != nil {
+			cartID = it.Cart.GetID()
+		}
+
+		if qty := stockManager.GetAvailableQty(it.GetProductID(), it.GetOptions(), cartID); qty < it.GetQty() {
 			var msg string
 			if qty == 0 {
 				msg = "No "
@@ -18,6 +18,7 @@ func (it *DefaultCart) GetImplementationName() string {
 // New makes new instance of model
 func (it *DefaultCart) New() (models.InterfaceModel, error) {
 	return &DefaultCart{
+		Type:       cart.ConstCartTypeCart,
 		Items:      make(map[int]cart.InterfaceCartItem),
 		Info:       make(map[string]interface{}),
 		CustomInfo: make(map[string]interface{}),
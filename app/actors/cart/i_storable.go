@@ -2,6 +2,8 @@ package cart
 
 import (
 	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/product"
 	"github.com/ottemo/foundation/env"
 
 	"time"
@@ -41,9 +43,17 @@ func (it *DefaultCart) Load(ID string) error {
 		it.Active = utils.InterfaceToBool(values["active"])
 		it.VisitorID = utils.InterfaceToString(values["visitor_id"])
 		it.SessionID = utils.InterfaceToString(values["session_id"])
+		it.StoreID = utils.InterfaceToString(values["store_id"])
+		it.Type = utils.InterfaceToString(values["type"])
+		it.Name = utils.InterfaceToString(values["name"])
 		it.UpdatedAt = utils.InterfaceToTime(values["updated_at"])
 		it.Info, _ = utils.DecodeJSONToStringKeyMap(values["info"])
 		it.CustomInfo = utils.InterfaceToMap(values["custom_info"])
+
+		// carts stored before saved carts and wishlists were introduced have no type
+		if it.Type == "" {
+			it.Type = cart.ConstCartTypeCart
+		}
 		it.Items = make(map[int]cart.InterfaceCartItem)
 		it.maxIdx = 0
 
@@ -127,8 +137,17 @@ func (it *DefaultCart) Delete() error {
 		return env.ErrorDispatch(err)
 	}
 	err = cartCollection.DeleteByID(it.GetID())
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if stockManager := product.GetRegisteredStock(); stockManager != nil {
+		if err := stockManager.ReleaseReservations(it.GetID()); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
 
-	return env.ErrorDispatch(err)
+	return nil
 }
 
 // Save stores current cart in DB
@@ -155,6 +174,9 @@ func (it *DefaultCart) Save() error {
 	cartStoringValues["_id"] = it.GetID()
 	cartStoringValues["visitor_id"] = it.VisitorID
 	cartStoringValues["session_id"] = it.SessionID
+	cartStoringValues["store_id"] = it.StoreID
+	cartStoringValues["type"] = it.Type
+	cartStoringValues["name"] = it.Name
 	cartStoringValues["active"] = it.Active
 	cartStoringValues["info"] = utils.EncodeToJSONString(it.Info)
 	cartStoringValues["custom_info"] = it.CustomInfo
@@ -188,5 +210,74 @@ func (it *DefaultCart) Save() error {
 		}
 	}
 
+	it.reserveItems()
+
+	return nil
+}
+
+// reserveItems renews stock reservations of active cart to match its items, reservations of inactive carts, saved
+// carts and wishlists are released
+//   - reservation of each product-options pair is updated in place, so cart does not lose reserved qty to other
+//     carts meanwhile, reservations of pairs no longer in cart are released
+//   - items which can not be reserved are left in cart, they are checked by cart validation
+func (it *DefaultCart) reserveItems() {
+	stockManager := product.GetRegisteredStock()
+	if stockManager == nil {
+		return
+	}
+
+	if !it.Active || it.Type != cart.ConstCartTypeCart || utils.InterfaceToBool(env.ConfigGetValue(checkout.ConstConfigPathOversell)) {
+		if err := stockManager.ReleaseReservations(it.GetID()); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+		return
+	}
+
+	// holder has one reservation per product-options pair, so items of the same pair are reserved together
+	var reservedItems []map[string]interface{}
+	for _, cartItem := range it.GetItems() {
+		if reservedItem := findReservedItem(reservedItems, cartItem.GetProductID(), cartItem.GetOptions()); reservedItem != nil {
+			reservedItem["qty"] = utils.InterfaceToInt(reservedItem["qty"]) + cartItem.GetQty()
+			continue
+		}
+		reservedItems = append(reservedItems, map[string]interface{}{
+			"product_id": cartItem.GetProductID(),
+			"options":    cartItem.GetOptions(),
+			"qty":        cartItem.GetQty(),
+		})
+	}
+
+	reservations, err := stockManager.GetReservations(it.GetID())
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+	for _, reservation := range reservations {
+		productID := utils.InterfaceToString(reservation["product_id"])
+		options := utils.InterfaceToMap(reservation["options"])
+		if findReservedItem(reservedItems, productID, options) == nil {
+			if err := stockManager.ReserveProductQty(it.GetID(), productID, options, 0); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+	}
+
+	for _, reservedItem := range reservedItems {
+		productID := utils.InterfaceToString(reservedItem["product_id"])
+		options := utils.InterfaceToMap(reservedItem["options"])
+		if err := stockManager.ReserveProductQty(it.GetID(), productID, options, utils.InterfaceToInt(reservedItem["qty"])); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+}
+
+// findReservedItem returns record of given product-options pair from reserved items list, nil if there is no such
+func findReservedItem(reservedItems []map[string]interface{}, productID string, options map[string]interface{}) map[string]interface{} {
+	for _, reservedItem := range reservedItems {
+		itemOptions := utils.InterfaceToMap(reservedItem["options"])
+		if utils.InterfaceToString(reservedItem["product_id"]) == productID &&
+			utils.MatchMapAValuesToMapB(itemOptions, options) && utils.MatchMapAValuesToMapB(options, itemOptions) {
+			return reservedItem
+		}
+	}
 	return nil
 }
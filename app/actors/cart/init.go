@@ -1,17 +1,16 @@
 package cart
 
 import (
-	"time"
-
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/cart"
-	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 )
 
@@ -38,6 +37,15 @@ func init() {
 	env.RegisterOnConfigStart(setupConfig)
 
 	db.RegisterOnDatabaseStart(onDatabaseStart)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "cart",
+		Description:  "Shopping cart",
+		Dependencies: []string{"product", "visitor"},
+		Core:         true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func onDatabaseStart() error {
@@ -51,6 +59,7 @@ func onDatabaseStart() error {
 	app.OnAppStart(setupEventListeners)
 	app.OnAppStart(cleanupGuestCarts)
 	app.OnAppStart(scheduleAbandonCartEmails)
+	app.OnAppStart(scheduleWishlistNotifications)
 
 	return nil
 }
@@ -80,6 +89,75 @@ func setupEventListeners() error {
 		return true
 	}
 	env.EventRegisterListener("session.close", sessionCloseListener)
+
+	// on session id regeneration (login) guest cart should be bound to new session id
+	sessionRegenerateListener := func(eventName string, data map[string]interface{}) bool {
+		sessionInstance, ok := data["session"].(api.InterfaceSession)
+		if !ok || sessionInstance == nil {
+			return true
+		}
+
+		cartID := sessionInstance.Get(cart.ConstSessionKeyCurrentCart)
+		if cartID == nil {
+			return true
+		}
+
+		cartModel, err := cart.LoadCartByID(utils.InterfaceToString(cartID))
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			return true
+		}
+
+		if cartModel.GetSessionID() == utils.InterfaceToString(data["previous"]) {
+			if err := cartModel.SetSessionID(sessionInstance.GetID()); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+			if err := cartModel.Save(); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+		return true
+	}
+	env.EventRegisterListener("session.regenerate", sessionRegenerateListener)
+
+	// on login guest cart should be merged into visitor cart, merge summary is returned as login details
+	visitorLoginListener := func(eventName string, data map[string]interface{}) bool {
+		sessionInstance, ok := data["session"].(api.InterfaceSession)
+		if !ok || sessionInstance == nil {
+			return true
+		}
+
+		summary, err := mergeGuestCart(sessionInstance, utils.InterfaceToString(data["visitor_id"]))
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			return true
+		}
+
+		if result, ok := data["result"].(map[string]interface{}); ok && summary != nil {
+			result["cart_merge"] = summary
+		}
+		return true
+	}
+	env.EventRegisterListener(visitor.ConstEventLogin, visitorLoginListener)
+
+	// order made of cart which was sent abandoned cart email is counted as recovered by campaign
+	checkoutSuccessListener := func(eventName string, data map[string]interface{}) bool {
+		checkoutCart, ok := data["cart"].(cart.InterfaceCart)
+		if !ok || checkoutCart == nil {
+			return true
+		}
+		checkoutOrder, ok := data["order"].(order.InterfaceOrder)
+		if !ok || checkoutOrder == nil {
+			return true
+		}
+
+		if err := attributeRecoveredOrder(checkoutCart, checkoutOrder); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+		return true
+	}
+	env.EventRegisterListener("checkout.success", checkoutSuccessListener)
+
 	return nil
 }
 
@@ -146,6 +224,15 @@ func (it *DefaultCart) setupDB() error {
 		if err := collection.AddColumn("session_id", db.ConstTypeID, true); err != nil {
 			return env.ErrorDispatch(err)
 		}
+		if err := collection.AddColumn("store_id", db.ConstTypeID, true); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if err := collection.AddColumn("type", db.TypeWPrecision(db.ConstTypeVarchar, 10), true); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if err := collection.AddColumn("name", db.TypeWPrecision(db.ConstTypeVarchar, 255), false); err != nil {
+			return env.ErrorDispatch(err)
+		}
 		if err := collection.AddColumn("updated_at", db.ConstTypeDatetime, true); err != nil {
 			return env.ErrorDispatch(err)
 		}
@@ -180,204 +267,51 @@ func (it *DefaultCart) setupDB() error {
 			return env.ErrorDispatch(err)
 		}
 
-	} else {
-		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "33076d0b-5c65-41dd-aa84-e4b68e1efa5b", "Can't get database engine")
-	}
-
-	return nil
-}
+		collection, err = dbEngine.GetCollection(ConstCartAbandonEmailCollectionName)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
 
-func scheduleAbandonCartEmails() error {
-	if scheduler := env.GetScheduler(); scheduler != nil {
-		if err := scheduler.RegisterTask("abandonCartEmail", abandonCartTask); err != nil {
+		if err := collection.AddColumn("cart_id", db.ConstTypeID, true); err != nil {
 			return env.ErrorDispatch(err)
 		}
-		if _, err := scheduler.ScheduleRepeat("0 * * * *", "abandonCartEmail", nil); err != nil {
+		if err := collection.AddColumn("email", db.TypeWPrecision(db.ConstTypeVarchar, 100), true); err != nil {
 			return env.ErrorDispatch(err)
 		}
-	}
-
-	return nil
-}
-
-func abandonCartTask(params map[string]interface{}) error {
-
-	// Get config for time to send
-	beforeDate, isEnabled := getConfigSendBefore()
-	if !isEnabled {
-		return nil
-	}
-
-	resultCarts := getAbandonedCarts(beforeDate)
-	actionableCarts := getActionableCarts(resultCarts)
-
-	env.LogEvent(env.LogFields{"abandonCartCount": len(resultCarts), "actionableCartCount": len(actionableCarts)}, "abandon-cart-task")
-
-	for _, aCart := range actionableCarts {
-		err := sendAbandonEmail(aCart)
-		if err != nil {
-			continue
+		if err := collection.AddColumn("step", db.ConstTypeInteger, false); err != nil {
+			return env.ErrorDispatch(err)
 		}
-
-		flagCartAsEmailed(aCart.Cart.ID)
-	}
-
-	return nil
-}
-
-// getConfigSendBefore will return the time to send out the abandoned cart emails
-func getConfigSendBefore() (time.Time, bool) {
-	var isEnabled bool
-	beforeConfig := utils.InterfaceToInt(env.ConfigGetValue(ConstConfigPathCartAbandonEmailSendTime))
-
-	// Flag it as enabled
-	if beforeConfig != 0 {
-		isEnabled = true
-	}
-
-	// Build out the time to send before, we are expecting a config
-	// that is a negative int
-	// 12 Aug 2016: Because of only one negative option available is -24
-	// we satisfy condition to take carts which are older than 24 hours.
-	beforeDuration := time.Duration(beforeConfig) * time.Hour
-	beforeDate := time.Now().Add(beforeDuration)
-
-	return beforeDate, isEnabled
-}
-
-// Get the abandoned carts
-// - active
-// - were updated in our time frame
-// - have not been sent an abandon cart email
-func getAbandonedCarts(beforeDate time.Time) []map[string]interface{} {
-	dbEngine := db.GetDBEngine()
-	cartCollection, _ := dbEngine.GetCollection(ConstCartCollectionName)
-	if err := cartCollection.AddFilter("active", "=", true); err != nil {
-		_ = env.ErrorDispatch(err)
-	}
-	if err := cartCollection.AddFilter("custom_info.is_abandon_email_sent", "!=", true); err != nil {
-		_ = env.ErrorDispatch(err)
-	}
-	if err := cartCollection.AddFilter("updated_at", "<", beforeDate); err != nil {
-		_ = env.ErrorDispatch(err)
-	}
-	// 12 Aug 2016: Take carts not older than 25 hours
-	if err := cartCollection.AddFilter("updated_at", ">=", beforeDate.Add(-time.Hour)); err != nil {
-		_ = env.ErrorDispatch(err)
-	}
-	if err := cartCollection.AddSort("updated_at", true); err != nil {
-		_ = env.ErrorDispatch(err)
-	}
-
-	resultCarts, err := cartCollection.Load()
-	if err != nil {
-		_ = env.ErrorDispatch(err)
-	}
-
-	return resultCarts
-}
-
-// getActionableCarts will return all carts we can send abandoned cart emails to.
-func getActionableCarts(resultCarts []map[string]interface{}) []AbandonCartEmailData {
-	allCartEmailData := []AbandonCartEmailData{}
-
-	// Determine which carts have an email we can use
-	for _, resultCart := range resultCarts {
-		var email, firstName, lastName string
-		cartID := utils.InterfaceToString(resultCart["_id"])
-		sessionID := utils.InterfaceToString(resultCart["session_id"])
-		visitorID := utils.InterfaceToString(resultCart["visitor_id"])
-
-		// try to get by visitor_id
-		if visitorID != "" {
-			vModel, _ := visitor.LoadVisitorByID(visitorID)
-			// TODO: handle this a better way or cleanse carts with nil visitorIDs
-			// for now, ignore nil visitors
-			if vModel != nil {
-				email = vModel.GetEmail()
-				firstName = vModel.GetFirstName()
-				lastName = vModel.GetLastName()
-			}
-		} else if sessionID != "" {
-			create := false
-			sessionWrapper, _ := api.GetSessionService().Get(sessionID, create)
-			sCheckout := utils.InterfaceToMap(sessionWrapper.Get(checkout.ConstSessionKeyCurrentCheckout))
-
-			scInfo := utils.InterfaceToMap(sCheckout["Info"])
-			email = utils.InterfaceToString(scInfo["customer_email"])
-			//NOTE: We have customer_name here as well, which we could split
-			//      or we could look to see if the address is filled out yet
+		if err := collection.AddColumn("coupon_code", db.TypeWPrecision(db.ConstTypeVarchar, 100), false); err != nil {
+			return env.ErrorDispatch(err)
 		}
-
-		// TODO: if we don't have an email then flag this cart as don't update?
-
-		// no email address for us to contact, move along
-		if email == "" {
-			continue
+		if err := collection.AddColumn("created_at", db.ConstTypeDatetime, true); err != nil {
+			return env.ErrorDispatch(err)
 		}
-
-		// Assemble the details needed for further actions
-		cartEmailData := AbandonCartEmailData{
-			Visitor: AbandonVisitor{
-				Email:     email,
-				FirstName: firstName,
-				LastName:  lastName,
-			},
-			Cart: AbandonCart{
-				ID: cartID,
-			},
+		if err := collection.AddColumn("order_id", db.ConstTypeID, true); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if err := collection.AddColumn("revenue", db.ConstTypeMoney, false); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if err := collection.AddColumn("recovered_at", db.ConstTypeDatetime, false); err != nil {
+			return env.ErrorDispatch(err)
 		}
 
-		// NOTE: In v1 we aren't including cart item details
-		// Get the cart items for the carts we are about to email
-		// cartItemsCollection, err := dbEngine.GetCollection(ConstCartItemsCollectionName)
-		// cartItemsCollection.AddFilter("cart_id", "=", it.GetID())
-		// cartItems, err := cartItemsCollection.Load()
-
-		allCartEmailData = append(allCartEmailData, cartEmailData)
-	}
-
-	return allCartEmailData
-}
-
-// sendAbandonEmail will send an email reminder to all carts with valid sessions
-// and email addresses
-func sendAbandonEmail(emailData AbandonCartEmailData) error {
-	subject := "It looks like you forgot something in your cart"
-	template := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathCartAbandonEmailTemplate))
-	if template == "" {
-		return env.ErrorDispatch(env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1756ec63-7cd7-4764-a8ff-64b142fc3f9f", "Abandon cart emails want to send but the template is empty"))
-	}
-
-	templateData := utils.InterfaceToMap(emailData)
-	templateData["Site"] = map[string]interface{}{
-		"Url": app.GetStorefrontURL(""),
-	}
+		collection, err = dbEngine.GetCollection(ConstCartAbandonUnsubscribeCollectionName)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
 
-	body, err := utils.TextTemplate(template, templateData)
-	if err != nil {
-		return env.ErrorDispatch(err)
-	}
+		if err := collection.AddColumn("email", db.TypeWPrecision(db.ConstTypeVarchar, 100), true); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if err := collection.AddColumn("created_at", db.ConstTypeDatetime, false); err != nil {
+			return env.ErrorDispatch(err)
+		}
 
-	err = app.SendMail(emailData.Visitor.Email, subject, body)
-	if err != nil {
-		return env.ErrorDispatch(err)
+	} else {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "33076d0b-5c65-41dd-aa84-e4b68e1efa5b", "Can't get database engine")
 	}
 
 	return nil
 }
-
-// flagCartAsEmailed will set a flag on carts that have been sent an abandoned
-// cart email.
-func flagCartAsEmailed(cartID string) {
-	iCart, _ := cart.LoadCartByID(cartID)
-
-	info := iCart.GetCustomInfo()
-	info["is_abandon_email_sent"] = true
-	info["abandon_email_sent_at"] = time.Now()
-
-	iCart.SetCustomInfo(info)
-	if err := iCart.Save(); err != nil {
-		_ = env.ErrorDispatch(err)
-	}
-}
@@ -0,0 +1,320 @@
+package cart
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// getShareSignature returns signature of saved cart or wishlist share link, blank string if cart is not shared
+//   - signature is made over cart type, id and share salt, so new salt revokes links given before
+func getShareSignature(listCart cart.InterfaceCart) string {
+	salt := utils.InterfaceToString(listCart.GetCustomInfo()[ConstCustomInfoShareSalt])
+	key := getSigningKey("share")
+	if salt == "" || key == nil {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(listCart.GetType() + ":" + listCart.GetID() + ":" + salt))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// checkShareSignature checks given signature to be valid for shared cart
+func checkShareSignature(listCart cart.InterfaceCart, signature string) bool {
+	expected := getShareSignature(listCart)
+	return expected != "" && hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// shareCart makes new share salt for cart, links with previous signature become invalid
+func shareCart(listCart cart.InterfaceCart) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	customInfo := listCart.GetCustomInfo()
+	if customInfo == nil {
+		customInfo = make(map[string]interface{})
+	}
+	customInfo[ConstCustomInfoShareSalt] = hex.EncodeToString(salt)
+	listCart.SetCustomInfo(customInfo)
+
+	return nil
+}
+
+// unshareCart removes share salt from cart, so it can not be accessed by links anymore
+func unshareCart(listCart cart.InterfaceCart) {
+	if customInfo := listCart.GetCustomInfo(); customInfo != nil {
+		delete(customInfo, ConstCustomInfoShareSalt)
+	}
+}
+
+// getVisitorCarts returns ids of visitor carts of given type ordered by name
+func getVisitorCarts(visitorID string, cartType string) ([]string, error) {
+	cartCollection, err := db.GetCollection(ConstCartCollectionName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := cartCollection.AddFilter("visitor_id", "=", visitorID); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := cartCollection.AddFilter("type", "=", cartType); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := cartCollection.AddSort("name", false); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := cartCollection.SetResultColumns("_id"); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	records, err := cartCollection.Load()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var result []string
+	for _, record := range records {
+		result = append(result, utils.InterfaceToString(record["_id"]))
+	}
+
+	return result, nil
+}
+
+// moveCartItem moves item from one cart to another, both carts are saved
+func moveCartItem(source cart.InterfaceCart, target cart.InterfaceCart, itemIdx int) (cart.InterfaceCartItem, error) {
+	var sourceItem cart.InterfaceCartItem
+	for _, item := range source.GetItems() {
+		if item.GetIdx() == itemIdx {
+			sourceItem = item
+			break
+		}
+	}
+	if sourceItem == nil {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "2c7f5e91-a3d8-4b06-8e14-5d9a0b6c73f2", "wrong itemIdx was specified")
+	}
+
+	targetItem, err := target.AddItem(sourceItem.GetProductID(), sourceItem.GetQty(), sourceItem.GetOptions())
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := target.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := source.RemoveItem(itemIdx); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := source.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return targetItem, nil
+}
+
+// getItemWatchState returns current price and stock availability of wishlist item product
+func getItemWatchState(item cart.InterfaceCartItem) (map[string]interface{}, bool) {
+	itemProduct := item.GetProduct()
+	if itemProduct == nil {
+		return nil, false
+	}
+
+	inStock := true
+	if stockManager := product.GetRegisteredStock(); stockManager != nil {
+		inStock = stockManager.GetAvailableQty(item.GetProductID(), item.GetOptions(), "") > 0
+	}
+
+	return map[string]interface{}{
+		"price":    itemProduct.GetPrice(),
+		"in_stock": inStock,
+	}, true
+}
+
+// watchWishlistItems remembers price and stock availability of wishlist items which are not watched yet, so
+// notification task has a state to compare with
+func watchWishlistItems(wishlist cart.InterfaceCart) {
+	if wishlist.GetType() != cart.ConstCartTypeWishlist {
+		return
+	}
+
+	customInfo := wishlist.GetCustomInfo()
+	if customInfo == nil {
+		customInfo = make(map[string]interface{})
+	}
+
+	watched := utils.InterfaceToMap(customInfo[ConstCustomInfoWishlistWatch])
+	actual := make(map[string]interface{})
+	for _, item := range wishlist.GetItems() {
+		key := utils.InterfaceToString(item.GetIdx())
+		if state, present := watched[key]; present {
+			actual[key] = state
+		} else if state, ok := getItemWatchState(item); ok {
+			actual[key] = state
+		}
+	}
+
+	customInfo[ConstCustomInfoWishlistWatch] = actual
+	wishlist.SetCustomInfo(customInfo)
+}
+
+// scheduleWishlistNotifications registers and schedules wishlist notifications task
+func scheduleWishlistNotifications() error {
+	if scheduler := env.GetScheduler(); scheduler != nil {
+		if err := scheduler.RegisterTask(ConstWishlistNotificationTaskName, wishlistNotificationTask); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if _, err := scheduler.ScheduleRepeat("30 * * * *", ConstWishlistNotificationTaskName, nil); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// wishlistNotificationTask sends price drop and back in stock emails to wishlist owners
+func wishlistNotificationTask(params map[string]interface{}) error {
+	template := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathWishlistEmailTemplate))
+	if !utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathWishlistNotifications)) || template == "" {
+		return nil
+	}
+
+	cartCollection, err := db.GetCollection(ConstCartCollectionName)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := cartCollection.AddFilter("type", "=", cart.ConstCartTypeWishlist); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := cartCollection.SetResultColumns("_id"); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := cartCollection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	sent := 0
+	for _, record := range records {
+		wishlist, err := cart.LoadCartByID(utils.InterfaceToString(record["_id"]))
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			continue
+		}
+
+		notified, err := notifyWishlistChanges(wishlist, template)
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			continue
+		}
+		if notified {
+			sent++
+		}
+	}
+
+	env.LogEvent(env.LogFields{"wishlistCount": len(records), "notificationCount": sent}, "wishlist-notification-task")
+
+	return nil
+}
+
+// notifyWishlistChanges compares wishlist items with their watched state, sends email if some products became
+// cheaper or available again and stores new state, returns true if email was sent
+func notifyWishlistChanges(wishlist cart.InterfaceCart, template string) (bool, error) {
+	customInfo := wishlist.GetCustomInfo()
+	if customInfo == nil {
+		customInfo = make(map[string]interface{})
+	}
+	watched := utils.InterfaceToMap(customInfo[ConstCustomInfoWishlistWatch])
+
+	var items []map[string]interface{}
+	actual := make(map[string]interface{})
+	for _, item := range wishlist.GetItems() {
+		state, ok := getItemWatchState(item)
+		if !ok {
+			continue
+		}
+
+		key := utils.InterfaceToString(item.GetIdx())
+		actual[key] = state
+
+		previous, present := watched[key].(map[string]interface{})
+		if !present {
+			continue
+		}
+
+		price := utils.InterfaceToFloat64(state["price"])
+		previousPrice := utils.InterfaceToFloat64(previous["price"])
+		priceDrop := price < previousPrice
+		backInStock := utils.InterfaceToBool(state["in_stock"]) && !utils.InterfaceToBool(previous["in_stock"])
+
+		if priceDrop || backInStock {
+			itemProduct := item.GetProduct()
+			items = append(items, map[string]interface{}{
+				"Name":        itemProduct.GetName(),
+				"SKU":         itemProduct.GetSku(),
+				"Price":       price,
+				"OldPrice":    previousPrice,
+				"PriceDrop":   priceDrop,
+				"BackInStock": backInStock,
+			})
+		}
+	}
+
+	notified := false
+	if len(items) > 0 {
+		if visitorInstance := wishlist.GetVisitor(); visitorInstance != nil && visitorInstance.GetEmail() != "" {
+			templateData := map[string]interface{}{
+				"Visitor": map[string]interface{}{
+					"Email":     visitorInstance.GetEmail(),
+					"FirstName": visitorInstance.GetFirstName(),
+					"LastName":  visitorInstance.GetLastName(),
+				},
+				"Wishlist": map[string]interface{}{
+					"ID":   wishlist.GetID(),
+					"Name": wishlist.GetName(),
+				},
+				"Items": items,
+				"Site": map[string]interface{}{
+					"Url": app.GetStorefrontURL(""),
+				},
+			}
+
+			body, err := utils.TextTemplate(template, templateData)
+			if err != nil {
+				return false, env.ErrorDispatch(err)
+			}
+
+			if err := app.SendMail(visitorInstance.GetEmail(), "Items on your wishlist have changed", body); err != nil {
+				return false, env.ErrorDispatch(err)
+			}
+			notified = true
+		}
+	}
+
+	if utils.EncodeToJSONString(actual) != utils.EncodeToJSONString(watched) {
+		customInfo[ConstCustomInfoWishlistWatch] = actual
+		if notified {
+			customInfo["wishlist_email_sent_at"] = time.Now()
+		}
+		wishlist.SetCustomInfo(customInfo)
+
+		if err := wishlist.Save(); err != nil {
+			return notified, env.ErrorDispatch(err)
+		}
+	}
+
+	return notified, nil
+}
@@ -0,0 +1,170 @@
+package cart
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// getAvailableQty returns qty of product with given options which could be placed to cart, -1 if it is not limited
+func getAvailableQty(productID string, options map[string]interface{}, cartID string) int {
+	stockManager := product.GetRegisteredStock()
+	if stockManager == nil || utils.InterfaceToBool(env.ConfigGetValue(checkout.ConstConfigPathOversell)) {
+		return -1
+	}
+
+	qty := stockManager.GetAvailableQty(productID, options, cartID)
+	if qty < 0 {
+		qty = 0
+	}
+	return qty
+}
+
+// mergeCarts moves items of source cart to target one, target cart is not saved
+//   - lines with identical product and options are combined
+//   - line qty is capped at available stock, lines having no stock are dropped
+//   - returns summary of changes made to target cart
+func mergeCarts(source cart.InterfaceCart, target cart.InterfaceCart) map[string]interface{} {
+	added := make([]map[string]interface{}, 0)
+	combined := make([]map[string]interface{}, 0)
+	capped := make([]map[string]interface{}, 0)
+	dropped := make([]map[string]interface{}, 0)
+
+	for _, sourceItem := range source.GetItems() {
+		productID := sourceItem.GetProductID()
+		options := sourceItem.GetOptions()
+		line := map[string]interface{}{"pid": productID, "options": options, "qty": sourceItem.GetQty()}
+
+		var targetItem cart.InterfaceCartItem
+		for _, item := range target.GetItems() {
+			if item.GetProductID() == productID && utils.EncodeToJSONString(item.GetOptions()) == utils.EncodeToJSONString(options) {
+				targetItem = item
+				break
+			}
+		}
+
+		qty := sourceItem.GetQty()
+		if targetItem != nil {
+			qty += targetItem.GetQty()
+		}
+
+		if available := getAvailableQty(productID, options, target.GetID()); available >= 0 && qty > available {
+			capped = append(capped, map[string]interface{}{"pid": productID, "options": options, "requested_qty": qty, "qty": available})
+			qty = available
+		}
+
+		var err error
+		switch {
+		case qty == 0 && targetItem != nil:
+			err = target.RemoveItem(targetItem.GetIdx())
+			line["reason"] = "out of stock"
+		case qty == 0:
+			line["reason"] = "out of stock"
+		case targetItem != nil:
+			previousQty := targetItem.GetQty()
+			line["previous_qty"] = previousQty
+			line["qty"] = qty
+
+			// item qty is changed before validation, so it should be returned back if new one is not valid
+			if err = targetItem.SetQty(qty); err != nil {
+				_ = targetItem.SetQty(previousQty)
+			}
+		default:
+			line["qty"] = qty
+			_, err = target.AddItem(productID, qty, options)
+		}
+
+		if err != nil {
+			line["reason"] = err.Error()
+			dropped = append(dropped, line)
+			continue
+		}
+
+		switch {
+		case qty == 0:
+			dropped = append(dropped, line)
+		case targetItem != nil:
+			combined = append(combined, line)
+		default:
+			added = append(added, line)
+		}
+	}
+
+	result := map[string]interface{}{
+		"cart_id":  target.GetID(),
+		"added":    added,
+		"combined": combined,
+		"capped":   capped,
+		"dropped":  dropped,
+	}
+
+	return result
+}
+
+// mergeGuestCart merges guest cart of session into visitor cart on login and emits cart merged event
+//   - returns merge summary or nil if session has no guest cart
+func mergeGuestCart(session api.InterfaceSession, visitorID string) (map[string]interface{}, error) {
+	guestCartID := utils.InterfaceToString(session.Get(cart.ConstSessionKeyCurrentCart))
+	if guestCartID == "" || visitorID == "" {
+		return nil, nil
+	}
+
+	guestCart, err := cart.LoadCartByID(guestCartID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if guestCart.GetVisitorID() != "" {
+		return nil, nil
+	}
+
+	visitorCart, err := cart.GetCartForVisitor(visitorID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	previousSessionID := visitorCart.GetSessionID()
+
+	// qty reserved by guest cart is moved to visitor cart, so it should not limit merge
+	if stockManager := product.GetRegisteredStock(); stockManager != nil {
+		if err := stockManager.ReleaseReservations(guestCart.GetID()); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	summary := mergeCarts(guestCart, visitorCart)
+
+	// cart restored from abandoned cart email keeps the email for recovery attribution
+	if emailID, present := guestCart.GetCustomInfo()[ConstCustomInfoAbandonEmail]; present {
+		customInfo := visitorCart.GetCustomInfo()
+		if customInfo == nil {
+			customInfo = make(map[string]interface{})
+		}
+		customInfo[ConstCustomInfoAbandonEmail] = emailID
+		visitorCart.SetCustomInfo(customInfo)
+	}
+
+	if err := visitorCart.SetSessionID(session.GetID()); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := visitorCart.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := guestCart.Delete(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	session.Set(cart.ConstSessionKeyCurrentCart, visitorCart.GetID())
+
+	eventData := map[string]interface{}{
+		"session":             session,
+		"cart":                visitorCart,
+		"visitor_id":          visitorID,
+		"previous_session_id": previousSessionID,
+		"summary":             summary,
+	}
+	env.Event(cart.ConstEventMerged, eventData)
+
+	return summary, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/category"
 	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/app/models/store"
 )
 
 // setupAPI setups package related API endpoint routines
@@ -72,6 +73,11 @@ func APIListCategories(context api.InterfaceApplicationContext) (interface{}, er
 		}
 	}
 
+	// excluding categories not assigned to store
+	if err := store.ApplyVisibilityFilter(context, categoryCollectionModel.GetDBCollection(), store.ConstObjectTypeCategory); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1a6d3f8c-e527-4b94-9c0e-7f2b5d8a3e61", err.Error())
+	}
+
 	// checking for a "count" request
 	if context.GetRequestArgument(api.ConstRESTActionParameter) == "count" {
 		return categoryCollectionModel.GetDBCollection().Count()
@@ -330,6 +336,10 @@ func APIGetCategoryProducts(context api.InterfaceApplicationContext) (interface{
 		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "9a6f080d-dfa4-4f8c-8a0c-ec31cbe1cd87", "category is not available")
 	}
 
+	if !store.IsVisible(context, store.ConstObjectTypeCategory, categoryModel.GetID()) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "5e9b2c7a-d184-4f36-a0e8-3b6f1d9c4a27", "category is not available")
+	}
+
 	productsCollection := categoryModel.GetProductsCollection()
 
 	if err := models.ApplyFilters(context, productsCollection.GetDBCollection()); err != nil {
@@ -346,6 +356,11 @@ func APIGetCategoryProducts(context api.InterfaceApplicationContext) (interface{
 		}
 	}
 
+	// not allowing to see products not assigned to store
+	if err := store.ApplyVisibilityFilter(context, productsCollection.GetDBCollection(), store.ConstObjectTypeProduct); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c8f4a1e6-3b92-4d57-b0e5-9a2d6c1f7b38", err.Error())
+	}
+
 	// checking for a "count" request
 	if context.GetRequestArgument(api.ConstRESTActionParameter) == "count" {
 		return productsCollection.GetDBCollection().Count()
@@ -459,6 +474,10 @@ func APIGetCategory(context api.InterfaceApplicationContext) (interface{}, error
 		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "80615e04-f43d-42a4-9482-39a5e7f8ccb7", "category is not available")
 	}
 
+	if !store.IsVisible(context, store.ConstObjectTypeCategory, categoryModel.GetID()) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "2d7c5f9e-a613-4b48-8e1d-6c3a9f2b5e74", "category is not available")
+	}
+
 	result := categoryModel.ToHashMap()
 
 	return result, nil
@@ -479,6 +498,11 @@ func APIGetCategoriesTree(context api.InterfaceApplicationContext) (interface{},
 		return nil, env.ErrorDispatch(err)
 	}
 
+	err = store.ApplyVisibilityFilter(context, collection, store.ConstObjectTypeCategory)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
 	err = collection.AddSort("path", false)
 	if err != nil {
 		return nil, env.ErrorDispatch(err)
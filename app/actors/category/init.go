@@ -6,6 +6,7 @@ import (
 	"github.com/ottemo/foundation/app/models/category"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init makes package self-initialization routine
@@ -24,6 +25,15 @@ func init() {
 
 	db.RegisterOnDatabaseStart(categoryInstance.setupDB)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "category",
+		Description:  "Catalog categories",
+		Dependencies: []string{"product"},
+		Core:         true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
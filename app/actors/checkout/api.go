@@ -9,6 +9,8 @@ import (
 
 	"github.com/ottemo/foundation/app/actors/payment/zeropay"
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
+	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 )
 
@@ -27,6 +29,10 @@ func setupAPI() error {
 	service.GET("checkout/shipping/methods", APIGetShippingMethods)
 	service.PUT("checkout/shipping/method/:method/:rate", APISetShippingMethod)
 
+	// Shipping groups
+	service.GET("checkout/shipping/groups", APIGetShippingGroups)
+	service.PUT("checkout/shipping/groups", APISetShippingGroups)
+
 	// Payment method
 	service.GET("checkout/payment/methods", APIGetPaymentMethods)
 	service.PUT("checkout/payment/method/:method", APISetPaymentMethod)
@@ -35,6 +41,13 @@ func setupAPI() error {
 	service.PUT("checkout", APISetCheckoutInfo)
 	service.POST("checkout/submit", APISubmitCheckout)
 
+	// Estimate
+	service.POST("checkout/estimate", APIEstimateCheckout)
+
+	// Calculation trace
+	service.GET("checkout/trace", api.IsAdminHandler(APIGetCalculationTrace))
+	service.GET("order/:orderID/trace", api.IsAdminHandler(APIGetOrderCalculationTrace))
+
 	return nil
 }
 
@@ -58,6 +71,7 @@ func APIGetCheckout(context api.InterfaceApplicationContext) (interface{}, error
 
 		"shipping_rate":   nil,
 		"shipping_amount": nil,
+		"shipping_groups": nil,
 
 		"discounts":       nil,
 		"discount_amount": nil,
@@ -68,6 +82,8 @@ func APIGetCheckout(context api.InterfaceApplicationContext) (interface{}, error
 		"subtotal":   nil,
 		"grandtotal": nil,
 		"info":       nil,
+
+		"violations": checkout.ValidateCheckout(currentCheckout),
 	}
 
 	if billingAddress := currentCheckout.GetBillingAddress(); billingAddress != nil {
@@ -98,6 +114,10 @@ func APIGetCheckout(context api.InterfaceApplicationContext) (interface{}, error
 		result["shipping_rate"] = shippingRate
 	}
 
+	if shippingGroups := currentCheckout.GetShippingGroups(); len(shippingGroups) > 0 {
+		result["shipping_groups"] = shippingGroups
+	}
+
 	result["grandtotal"] = currentCheckout.GetGrandTotal()
 	result["subtotal"] = currentCheckout.GetSubtotal()
 
@@ -109,6 +129,22 @@ func APIGetCheckout(context api.InterfaceApplicationContext) (interface{}, error
 	result["discount_amount"] = currentCheckout.GetDiscountAmount()
 	result["discounts"] = currentCheckout.GetDiscounts()
 
+	// totals presented in currency order is going to be charged in
+	currencyCode := currency.GetSessionCurrency(currentCheckout.GetSession())
+	if rate, err := currency.GetRate(currencyCode); err == nil {
+		result["currency"] = currencyCode
+		result["currency_rate"] = rate
+		result["display"] = map[string]interface{}{
+			"subtotal":        utils.RoundPrice(currentCheckout.GetSubtotal() * rate),
+			"shipping_amount": utils.RoundPrice(currentCheckout.GetShippingAmount() * rate),
+			"tax_amount":      utils.RoundPrice(currentCheckout.GetTaxAmount() * rate),
+			"discount_amount": utils.RoundPrice(currentCheckout.GetDiscountAmount() * rate),
+			"grandtotal":      utils.RoundPrice(currentCheckout.GetGrandTotal() * rate),
+		}
+	} else {
+		_ = env.ErrorDispatch(err)
+	}
+
 	// The info map is only returned for logged out users
 	infoMap := make(map[string]interface{})
 
@@ -418,6 +454,133 @@ func APISetShippingMethod(context api.InterfaceApplicationContext) (interface{},
 	return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c589e0f9-4e4a-4691-8f43-a045aaff48c2", "shipping method and/or rate were not found")
 }
 
+// APIGetShippingGroups returns groups checkout items are split into by destination along with shipping methods and
+// rates available for each group
+func APIGetShippingGroups(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	currentCheckout, err := checkout.GetCurrentCheckout(context, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	type ResultMethod struct {
+		Name  string
+		Code  string
+		Rates []checkout.StructShippingRate
+	}
+	var result []map[string]interface{}
+
+	for groupIdx, shippingGroup := range currentCheckout.GetShippingGroups() {
+		groupCheckout := currentCheckout.GetShippingGroupCheckout(groupIdx)
+
+		shippingMethods := make([]ResultMethod, 0)
+		if groupCheckout.GetShippingAddress() != nil {
+			for _, shippingMethod := range checkout.GetRegisteredShippingMethods() {
+				if shippingMethod.IsAllowed(groupCheckout) {
+					shippingMethods = append(shippingMethods, ResultMethod{Name: shippingMethod.GetName(), Code: shippingMethod.GetCode(), Rates: shippingMethod.GetRates(groupCheckout)})
+				}
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"items":                shippingGroup.Items,
+			"address":              shippingGroup.Address,
+			"shipping_method_code": shippingGroup.ShippingMethodCode,
+			"shipping_rate":        shippingGroup.ShippingRate,
+			"shipping_methods":     shippingMethods,
+		})
+	}
+
+	return result, nil
+}
+
+// APISetShippingGroups splits current checkout items over several destinations
+//   - "groups" should be specified in content as a list of "items" (cart item indexes), "address" (address data or
+//     visitor address "id") and optional "shipping_method" and "shipping_rate" codes
+//   - blank list returns checkout to one destination
+func APISetShippingGroups(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	currentCheckout, err := checkout.GetCurrentCheckout(context, true)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	requestData, err := api.GetRequestContentAsMap(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	currentVisitorID := utils.InterfaceToString(context.GetSession().Get(visitor.ConstSessionKeyVisitorID))
+
+	cartItems := make(map[int]bool)
+	for _, cartItem := range currentCheckout.GetItems() {
+		cartItems[cartItem.GetIdx()] = false
+	}
+
+	var shippingGroups []checkout.StructShippingGroup
+	for _, groupValue := range utils.InterfaceToArray(requestData["groups"]) {
+		groupData := utils.InterfaceToMap(groupValue)
+
+		var shippingGroup checkout.StructShippingGroup
+		for _, itemValue := range utils.InterfaceToArray(groupData["items"]) {
+			itemIdx := utils.InterfaceToInt(itemValue)
+			if shipped, present := cartItems[itemIdx]; !present || shipped {
+				context.SetResponseStatusBadRequest()
+				return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "9d4c1b7e-2f58-4a63-b0e9-6a3f8c5d2e17", "cart item "+utils.InterfaceToString(itemIdx)+" is not in cart or is included in several groups")
+			}
+			cartItems[itemIdx] = true
+			shippingGroup.Items = append(shippingGroup.Items, itemIdx)
+		}
+
+		address, err := checkoutObtainAddress(utils.InterfaceToMap(groupData["address"]))
+		if err != nil {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorDispatch(err)
+		}
+		if address.GetVisitorID() != "" && address.GetVisitorID() != currentVisitorID {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4a7e0d92-c6b1-4f38-85d3-e1f9b2c7a604", "address id is not related to current visitor")
+		}
+		shippingGroup.Address = address.ToHashMap()
+
+		shippingGroups = append(shippingGroups, shippingGroup)
+	}
+
+	if err := currentCheckout.SetShippingGroups(shippingGroups); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// shipping method and rate are checked with group items and address, so they are set after groups
+	for groupIdx, groupValue := range utils.InterfaceToArray(requestData["groups"]) {
+		groupData := utils.InterfaceToMap(groupValue)
+
+		methodCode := utils.InterfaceToString(groupData["shipping_method"])
+		if methodCode == "" {
+			continue
+		}
+
+		_, shippingRate, err := findShippingRate(currentCheckout.GetShippingGroupCheckout(groupIdx), methodCode, utils.InterfaceToString(groupData["shipping_rate"]))
+		if err != nil {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorDispatch(err)
+		}
+
+		shippingGroups[groupIdx].ShippingMethodCode = methodCode
+		shippingGroups[groupIdx].ShippingRate = shippingRate
+	}
+
+	if err := currentCheckout.SetShippingGroups(shippingGroups); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// updating session
+	if err := checkout.SetCurrentCheckout(context, currentCheckout); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "0c5b8e3f-7a14-4d92-b6e8-f2a9d1c4e735", err.Error())
+	}
+
+	return "ok", nil
+}
+
 // checkoutObtainToken is an internal usage function used to create or load credit card for visitor
 func checkoutObtainToken(currentCheckout checkout.InterfaceCheckout, creditCardInfo map[string]interface{}) (visitor.InterfaceVisitorCard, error) {
 
@@ -695,5 +858,66 @@ func APISubmitCheckout(context api.InterfaceApplicationContext) (interface{}, er
 		}
 	}
 
-	return currentCheckout.Submit()
+	result, err := currentCheckout.Submit()
+
+	// restriction rules violations are returned per cart item, so they could be shown next to items
+	if violationsCheckout, ok := currentCheckout.(interface {
+		GetViolations() []checkout.StructViolation
+	}); ok && err != nil {
+		if violations := violationsCheckout.GetViolations(); len(violations) > 0 {
+			context.SetResponseStatusBadRequest()
+			return map[string]interface{}{"violations": violations}, err
+		}
+	}
+
+	return result, err
+}
+
+// APIGetCalculationTrace returns calculation steps of current checkout: price adjustments applied by priority, changes
+// of item grand totals and cart totals after each step
+func APIGetCalculationTrace(context api.InterfaceApplicationContext) (interface{}, error) {
+	currentCheckout, err := checkout.GetCurrentCheckout(context, false)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	trace := currentCheckout.GetCalculationTrace()
+
+	return map[string]interface{}{
+		"grandtotal": currentCheckout.GetGrandTotal(),
+		"trace":      trace,
+	}, nil
+}
+
+// APIGetOrderCalculationTrace returns calculation trace stored on order along with its replay made with current
+// rounding settings
+//   - trace is stored on orders placed while calculation trace setting is enabled
+func APIGetOrderCalculationTrace(context api.InterfaceApplicationContext) (interface{}, error) {
+	orderModel, err := order.LoadOrderByID(context.GetRequestArgument("orderID"))
+	if err != nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	storedTrace := utils.InterfaceToMap(orderModel.Get("custom_info"))[checkout.ConstOrderInfoCalculationTrace]
+	if storedTrace == nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c4e9a2f7-1b58-4d36-8e0c-6f3a9d2b7e15", "order have no calculation trace, it is stored while calculation trace setting is enabled")
+	}
+
+	steps, err := decodeCalculationTrace(storedTrace)
+	if err != nil {
+		context.SetResponseStatusInternalServerError()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	replay := replayCalculationTrace(steps)
+
+	return map[string]interface{}{
+		"order_id":           orderModel.GetID(),
+		"grand_total":        orderModel.GetGrandTotal(),
+		"trace":              steps,
+		"replay_grand_total": replay.calculateAmount.Float64(),
+		"replay":             replay.calculationTrace,
+	}, nil
 }
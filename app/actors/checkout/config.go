@@ -331,5 +331,60 @@ Total: ${{.Order.grand_total}}<br />`,
 		return env.ErrorDispatch(err)
 	}
 
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        checkout.ConstConfigPathRoundingMode,
+		Value:       utils.ConstRoundingHalfUp,
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "select",
+		Options:     map[string]string{utils.ConstRoundingHalfUp: "Half up", utils.ConstRoundingHalfEven: "Half even (banker's)"},
+		Label:       "Rounding mode",
+		Description: "the way amounts are rounded to cents",
+		Image:       "",
+	}, func(value interface{}) (interface{}, error) {
+		if mode := utils.InterfaceToString(value); !utils.IsRoundingMode(mode) {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4e8b2d6f-a391-4c57-b0e8-7d1f3a9c5e26", "unknown rounding mode '"+mode+"'")
+		}
+		return value, nil
+	})
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        checkout.ConstConfigPathRoundingLevel,
+		Value:       checkout.ConstRoundingLevelLine,
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "select",
+		Options:     map[string]string{checkout.ConstRoundingLevelLine: "Per line", checkout.ConstRoundingLevelTotal: "Per total"},
+		Label:       "Rounding level",
+		Description: "per line - item amounts are rounded and summed up, per total - totals are rounded and split over items",
+		Image:       "",
+	}, func(value interface{}) (interface{}, error) {
+		if level := utils.InterfaceToString(value); level != checkout.ConstRoundingLevelLine && level != checkout.ConstRoundingLevelTotal {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b7d3f9a1-5c24-4e86-9a0d-2e6c8b4f1a73", "unknown rounding level '"+level+"'")
+		}
+		return value, nil
+	})
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        checkout.ConstConfigPathCalculationTrace,
+		Value:       false,
+		Type:        env.ConstConfigTypeBoolean,
+		Editor:      "boolean",
+		Options:     nil,
+		Label:       "Calculation trace",
+		Description: "record price adjustments applied within checkout calculation and store them on orders",
+		Image:       "",
+	}, func(value interface{}) (interface{}, error) { return utils.InterfaceToBool(value), nil })
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
 	return nil
 }
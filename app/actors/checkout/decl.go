@@ -3,9 +3,11 @@
 package checkout
 
 import (
+	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app/models/cart"
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
 )
 
 // Package global constants
@@ -14,6 +16,12 @@ const (
 	ConstErrorLevel  = env.ConstErrorLevelActor
 )
 
+// Package global variables
+var (
+	// cart level amounts of these labels are split over items, so item totals sum up to cart totals
+	splitLabels = []string{checkout.ConstLabelDiscount, checkout.ConstLabelTax}
+)
+
 // DefaultCheckout is a default implementer of InterfaceCheckout
 type DefaultCheckout struct {
 	CartID    string
@@ -22,6 +30,9 @@ type DefaultCheckout struct {
 
 	SessionID string
 
+	// session used instead of stored one for transient checkouts (estimates)
+	session api.InterfaceSession
+
 	ShippingAddress map[string]interface{}
 	BillingAddress  map[string]interface{}
 
@@ -30,17 +41,41 @@ type DefaultCheckout struct {
 
 	ShippingRate checkout.StructShippingRate
 
+	// items split over several destinations, each group has own address, shipping method and rate
+	ShippingGroups []checkout.StructShippingGroup
+
 	priceAdjustments []checkout.StructPriceAdjustment
 
 	// should store details about applied adjustments for specific keys
 	// 0 - cart, 1,2,3, .. n - index of cart item
-	calculationDetailTotals map[int]map[string]float64
+	calculationDetailTotals map[int]map[string]utils.Money
 	cart                    cart.InterfaceCart
 
 	Info map[string]interface{}
 
-	calculateAmount float64
+	calculateAmount utils.Money
+
+	// calculation steps recorded if tracing is enabled by settings or forced with traceFlag
+	calculationTrace []checkout.StructCalculationStep
+	traceFlag        bool
 
 	// flags enables and disables during calculation to prevent recursion
 	calculateFlag bool
+
+	// restriction rules violations found on last submit
+	violations []checkout.StructViolation
+}
+
+// shippingGroupCheckout is a checkout limited to items, address, shipping method and rate of a shipping group
+type shippingGroupCheckout struct {
+	*DefaultCheckout
+
+	group checkout.StructShippingGroup
+}
+
+// shippingGroupCart is a checkout cart limited to items of a shipping group
+type shippingGroupCart struct {
+	cart.InterfaceCart
+
+	groupCheckout *shippingGroupCheckout
 }
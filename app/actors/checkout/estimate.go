@@ -0,0 +1,263 @@
+package checkout
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+
+	"github.com/ottemo/foundation/app/actors/discount/coupon"
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
+	"github.com/ottemo/foundation/app/models/visitor"
+)
+
+// transientSession is an in-memory session for checkouts which are not going to be stored, values set to it
+// are lost with the checkout
+type transientSession struct {
+	values map[string]interface{}
+}
+
+// GetID returns blank value as transient session is not stored
+func (it *transientSession) GetID() string {
+	return ""
+}
+
+// Get returns session value by a given key or nil - if not set
+func (it *transientSession) Get(key string) interface{} {
+	return it.values[key]
+}
+
+// Set assigns value to session key
+func (it *transientSession) Set(key string, value interface{}) {
+	it.values[key] = value
+}
+
+// IsEmpty checks if session contains data
+func (it *transientSession) IsEmpty() bool {
+	return len(it.values) == 0
+}
+
+// Touch does nothing as transient session does not expire
+func (it *transientSession) Touch() error {
+	return nil
+}
+
+// Close does nothing as transient session is not stored
+func (it *transientSession) Close() error {
+	return nil
+}
+
+// newEstimateCheckout makes a checkout which is not bound to a session and not stored, so calculations made on it
+// do not affect visitor's current checkout
+//   - visitor and currency are taken from a request session to get the same prices visitor would get
+func newEstimateCheckout(requestSession api.InterfaceSession) (*DefaultCheckout, error) {
+	checkoutModel, err := checkout.GetCheckoutModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	estimateCheckout, ok := checkoutModel.(*DefaultCheckout)
+	if !ok {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6e0b2d41-93c7-4f8a-b5d2-1a7c84e0f96b", "unexpected checkout model implementation")
+	}
+
+	session := &transientSession{values: make(map[string]interface{})}
+	for _, key := range []string{currency.ConstSessionKeyCurrency, visitor.ConstSessionKeyVisitorID} {
+		if value := requestSession.Get(key); value != nil {
+			session.Set(key, value)
+		}
+	}
+	estimateCheckout.session = session
+	estimateCheckout.VisitorID = utils.InterfaceToString(session.Get(visitor.ConstSessionKeyVisitorID))
+
+	estimateCart, err := cart.GetCartModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := estimateCheckout.SetCart(estimateCart); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return estimateCheckout, nil
+}
+
+// APIEstimateCheckout returns price breakdown for given items without a need of cart and checkout
+//   - "items" should be specified in content as a list of "pid", "qty" and optional "options"
+//   - "destination" is an optional shipping address, it could be partial (country, state, zip code)
+//   - "coupons" is an optional list of coupon codes, codes which could not be redeemed are ignored
+//   - "shipping_method" and "shipping_rate" are optional codes of shipping method and rate to include in totals
+func APIEstimateCheckout(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	requestData, err := api.GetRequestContentAsMap(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	requestItems := utils.InterfaceToArray(requestData["items"])
+	if len(requestItems) == 0 {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b3d9f7a2-5c10-4e68-8a4f-d2e61c09b7a5", "items were not specified")
+	}
+
+	requestSession := context.GetSession()
+
+	estimateCheckout, err := newEstimateCheckout(requestSession)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	estimateCart := estimateCheckout.GetCart()
+	for _, requestItem := range requestItems {
+		itemData := utils.InterfaceToMap(requestItem)
+
+		pid := utils.InterfaceToString(itemData["pid"])
+		if pid == "" {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "0f4a8c63-d21e-47b9-96c5-e83b7d15a2f0", "item product id was not specified")
+		}
+
+		qty := 1
+		if value, present := itemData["qty"]; present {
+			qty = utils.InterfaceToInt(value)
+		}
+
+		if _, err := estimateCart.AddItem(pid, qty, utils.InterfaceToMap(itemData["options"])); err != nil {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	if destination, present := requestData["destination"]; present && destination != nil {
+		shippingAddress, err := visitor.GetVisitorAddressModel()
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		if err := shippingAddress.FromHashMap(utils.InterfaceToMap(destination)); err != nil {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorDispatch(err)
+		}
+		if err := estimateCheckout.SetShippingAddress(shippingAddress); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	redeemedCodes := utils.InterfaceToStringArray(requestSession.Get(coupon.ConstSessionKeyCurrentRedemptions))
+	couponCodes, err := coupon.FilterRedeemableCodes(utils.InterfaceToStringArray(requestData["coupons"]), redeemedCodes)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	estimateCheckout.GetSession().Set(coupon.ConstSessionKeyCurrentRedemptions, couponCodes)
+
+	// all rates are collected before shipping method selection, as they should not depend on it
+	type ResultShippingMethod struct {
+		Name  string
+		Code  string
+		Rates []checkout.StructShippingRate
+	}
+	shippingMethods := make([]ResultShippingMethod, 0)
+
+	methodCode := utils.InterfaceToString(requestData["shipping_method"])
+	rateCode := utils.InterfaceToString(requestData["shipping_rate"])
+	var selectedMethod checkout.InterfaceShippingMethod
+	var selectedRate checkout.StructShippingRate
+
+	for _, shippingMethod := range checkout.GetRegisteredShippingMethods() {
+		if !shippingMethod.IsAllowed(estimateCheckout) {
+			continue
+		}
+
+		rates := shippingMethod.GetRates(estimateCheckout)
+		shippingMethods = append(shippingMethods, ResultShippingMethod{Name: shippingMethod.GetName(), Code: shippingMethod.GetCode(), Rates: rates})
+
+		if methodCode != "" && shippingMethod.GetCode() == methodCode {
+			for _, shippingRate := range rates {
+				if shippingRate.Code == rateCode {
+					selectedMethod = shippingMethod
+					selectedRate = shippingRate
+				}
+			}
+		}
+	}
+
+	if methodCode != "" {
+		if selectedMethod == nil {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "a87c1e5f-2b49-4d06-9f3e-65d0b4a8c2e7", "shipping method and/or rate were not found")
+		}
+
+		if err := estimateCheckout.SetShippingMethod(selectedMethod); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		if err := estimateCheckout.SetShippingRate(selectedRate); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	grandTotal := estimateCheckout.GetGrandTotal()
+
+	var resultItems []map[string]interface{}
+	for _, cartItem := range estimateCheckout.GetItems() {
+		idx := cartItem.GetIdx()
+
+		resultItem := map[string]interface{}{
+			"idx":      idx,
+			"pid":      cartItem.GetProductID(),
+			"qty":      cartItem.GetQty(),
+			"options":  cartItem.GetOptions(),
+			"subtotal": estimateCheckout.GetItemSpecificTotal(idx, checkout.ConstLabelSubtotal),
+			"discount": estimateCheckout.GetItemSpecificTotal(idx, checkout.ConstLabelDiscount),
+			"tax":      estimateCheckout.GetItemSpecificTotal(idx, checkout.ConstLabelTax),
+		}
+
+		if itemProduct := cartItem.GetProduct(); itemProduct != nil {
+			resultItem["name"] = itemProduct.GetName()
+			resultItem["sku"] = itemProduct.GetSku()
+			resultItem["price"] = itemProduct.GetPrice()
+		}
+
+		resultItems = append(resultItems, resultItem)
+	}
+
+	result := map[string]interface{}{
+		"items":    resultItems,
+		"subtotal": estimateCheckout.GetSubtotal(),
+
+		"coupons":         estimateCheckout.GetSession().Get(coupon.ConstSessionKeyCurrentRedemptions),
+		"discounts":       estimateCheckout.GetDiscounts(),
+		"discount_amount": estimateCheckout.GetDiscountAmount(),
+
+		"taxes":      estimateCheckout.GetTaxes(),
+		"tax_amount": estimateCheckout.GetTaxAmount(),
+
+		"shipping_methods":     shippingMethods,
+		"shipping_method_code": nil,
+		"shipping_rate":        nil,
+		"shipping_amount":      estimateCheckout.GetShippingAmount(),
+
+		"grandtotal": grandTotal,
+	}
+
+	if selectedMethod != nil {
+		result["shipping_method_code"] = selectedMethod.GetCode()
+		result["shipping_rate"] = selectedRate
+	}
+
+	// totals presented in currency order is going to be charged in
+	currencyCode := currency.GetSessionCurrency(estimateCheckout.GetSession())
+	if rate, err := currency.GetRate(currencyCode); err == nil {
+		result["currency"] = currencyCode
+		result["currency_rate"] = rate
+		result["display"] = map[string]interface{}{
+			"subtotal":        currency.ConvertByRate(estimateCheckout.GetSubtotal(), rate),
+			"shipping_amount": currency.ConvertByRate(estimateCheckout.GetShippingAmount(), rate),
+			"tax_amount":      currency.ConvertByRate(estimateCheckout.GetTaxAmount(), rate),
+			"discount_amount": currency.ConvertByRate(estimateCheckout.GetDiscountAmount(), rate),
+			"grandtotal":      currency.ConvertByRate(grandTotal, rate),
+		}
+	} else {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return result, nil
+}
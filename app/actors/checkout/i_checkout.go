@@ -2,6 +2,7 @@ package checkout
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/ottemo/foundation/app/models/cart"
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/subscription"
 	"github.com/ottemo/foundation/app/models/visitor"
@@ -192,6 +194,10 @@ func (it *DefaultCheckout) SetSession(checkoutSession api.InterfaceSession) erro
 
 // GetSession return checkout session
 func (it *DefaultCheckout) GetSession() api.InterfaceSession {
+	if it.session != nil {
+		return it.session
+	}
+
 	sessionInstance, _ := api.GetSessionByID(it.SessionID, true)
 	return sessionInstance
 }
@@ -291,9 +297,13 @@ func (it *DefaultCheckout) calculateSubtotal() checkout.StructPriceAdjustment {
 		PerItem:   map[string]float64{},
 	}
 
+	// explicit product prices within checkout currency are taken into account
+	currencyCode := currency.GetSessionCurrency(it.GetSession())
+
 	for _, cartItem := range items {
 		if cartProduct := cartItem.GetProduct(); cartProduct != nil {
-			result.PerItem[utils.InterfaceToString(cartItem.GetIdx())] = utils.RoundPrice(cartProduct.GetPrice() * float64(cartItem.GetQty()))
+			price := currency.GetBaseEquivalentPrice(cartProduct, currencyCode)
+			result.PerItem[utils.InterfaceToString(cartItem.GetIdx())] = utils.MoneyFromFloat(price).Mul(cartItem.GetQty()).Float64()
 		}
 	}
 
@@ -301,10 +311,21 @@ func (it *DefaultCheckout) calculateSubtotal() checkout.StructPriceAdjustment {
 }
 
 // calculateShipping it's an element of calculation that provides shipping amounts
-func (it *DefaultCheckout) calculateShipping() checkout.StructPriceAdjustment {
+//   - each shipping group provides own shipping amount if items are split over several destinations
+func (it *DefaultCheckout) calculateShipping() []checkout.StructPriceAdjustment {
+
+	var shippingRates []checkout.StructShippingRate
+	if len(it.ShippingGroups) > 0 {
+		for _, shippingGroup := range it.ShippingGroups {
+			shippingRates = append(shippingRates, shippingGroup.ShippingRate)
+		}
+	} else if shippingRate := it.GetShippingRate(); shippingRate != nil {
+		shippingRates = append(shippingRates, *shippingRate)
+	}
 
-	if shippingRate := it.GetShippingRate(); shippingRate != nil {
-		return checkout.StructPriceAdjustment{
+	var result []checkout.StructPriceAdjustment
+	for _, shippingRate := range shippingRates {
+		result = append(result, checkout.StructPriceAdjustment{
 			Code:      shippingRate.Code,
 			Name:      shippingRate.Name,
 			Amount:    shippingRate.Price,
@@ -312,14 +333,14 @@ func (it *DefaultCheckout) calculateShipping() checkout.StructPriceAdjustment {
 			Priority:  checkout.ConstCalculateTargetShipping,
 			Labels:    []string{checkout.ConstLabelShipping},
 			PerItem:   nil,
-		}
+		})
 	}
 
-	return checkout.StructPriceAdjustment{}
+	return result
 }
 
 // GetItemTotals return details about totals per item (0 is a cart)
-func (it *DefaultCheckout) getItemTotals(idx interface{}) map[string]float64 {
+func (it *DefaultCheckout) getItemTotals(idx interface{}) map[string]utils.Money {
 	index := utils.InterfaceToInt(idx)
 
 	// in this case we don't started calculation process so it will be executed first
@@ -330,7 +351,7 @@ func (it *DefaultCheckout) getItemTotals(idx interface{}) map[string]float64 {
 	itemTotals, present := it.calculationDetailTotals[index]
 	if !present {
 
-		itemTotals = make(map[string]float64)
+		itemTotals = make(map[string]utils.Money)
 		it.calculationDetailTotals[index] = itemTotals
 	}
 
@@ -339,50 +360,96 @@ func (it *DefaultCheckout) getItemTotals(idx interface{}) map[string]float64 {
 
 // GetItemSpecificTotal return current amount value for given item index and label (0 is a cart)
 func (it *DefaultCheckout) GetItemSpecificTotal(idx interface{}, label string) float64 {
-	if value, present := it.getItemTotals(idx)[label]; present {
-		return value
-	}
-
-	return 0
+	return it.getItemTotals(idx)[label].Float64()
 }
 
 // applyAmount applies amounts to checkout detail calculation map
-func (it *DefaultCheckout) applyAmount(idx interface{}, label string, amount float64) {
+func (it *DefaultCheckout) applyAmount(idx interface{}, label string, amount utils.Money) {
 	index := utils.InterfaceToInt(idx)
 	if index != 0 {
-		it.getItemTotals(index)[label] = utils.RoundPrice(amount + it.GetItemSpecificTotal(index, label))
+		it.getItemTotals(index)[label] += amount
 	}
 
-	it.getItemTotals(0)[label] = utils.RoundPrice(amount + it.GetItemSpecificTotal(0, label))
+	it.getItemTotals(0)[label] += amount
 
 	if label == checkout.ConstLabelGrandTotal {
-		it.calculateAmount = utils.RoundPrice(amount + it.calculateAmount)
+		it.calculateAmount += amount
+	}
+}
+
+// getItemIndexes returns indexes of items having calculation details in ascending order
+func (it *DefaultCheckout) getItemIndexes() []int {
+	var result []int
+	for index := range it.calculationDetailTotals {
+		if index != 0 {
+			result = append(result, index)
+		}
+	}
+	sort.Ints(result)
+
+	return result
+}
+
+// splitAmount splits cart level amount over items proportionally to their grand totals, so item details sum up
+// to cart ones, amount is supposed to be already applied to cart
+//   - negative amount is limited by items grand totals sum, the rest stays cart level only
+func (it *DefaultCheckout) splitAmount(amount utils.Money, labels []string) {
+	indexes := it.getItemIndexes()
+
+	var totalWeight utils.Money
+	weights := make([]utils.Money, len(indexes))
+	for idx, index := range indexes {
+		if itemTotal := it.getItemTotals(index)[checkout.ConstLabelGrandTotal]; itemTotal > 0 {
+			weights[idx] = itemTotal
+			totalWeight += itemTotal
+		}
+	}
+
+	if totalWeight == 0 {
+		return
+	}
+	if amount < -totalWeight {
+		amount = -totalWeight
+	}
+
+	for idx, part := range amount.Allocate(weights, checkout.GetRoundingMode()) {
+		itemTotals := it.getItemTotals(indexes[idx])
+		itemTotals[checkout.ConstLabelGrandTotal] += part
+		for _, label := range labels {
+			if label != checkout.ConstLabelGrandTotal {
+				itemTotals[label] += part
+			}
+		}
 	}
 }
 
 // applyPriceAdjustment used to handle calculation of changes from price adjustment
 // and storing to all points with details
+//   - amounts are rounded per item or per adjustment total depending on rounding level setting
 func (it *DefaultCheckout) applyPriceAdjustment(priceAdjustment checkout.StructPriceAdjustment) {
 	if priceAdjustment.Code == "" {
 		return
 	}
-	var totalPriceAdjustmentAmount float64
+
+	roundingMode := checkout.GetRoundingMode()
+
+	var totalPriceAdjustmentAmount utils.Money
 	// main part is per items apply (we will handle Amount only if there was no per item value)
 	if priceAdjustment.PerItem == nil || len(priceAdjustment.PerItem) == 0 {
-		amount := priceAdjustment.Amount
+		amount := utils.MoneyFromFloat(priceAdjustment.Amount)
 		if priceAdjustment.IsPercent {
 			// current grand total will be changed on some percentage
-			amount = it.GetItemSpecificTotal(0, checkout.ConstLabelGrandTotal) * amount / 100.0
+			amount = it.getItemTotals(0)[checkout.ConstLabelGrandTotal].Percent(priceAdjustment.Amount, roundingMode)
 		}
 
+		// affecting grand total of a cart
+		amount = amount.Round(roundingMode)
+
 		// prevent negative values of grand total
-		if amount+it.calculateAmount < 0.0 {
-			amount = 0.0 - it.calculateAmount
+		if amount+it.calculateAmount < 0 {
+			amount = -it.calculateAmount
 		}
 
-		// affecting grand total of a cart
-		amount = utils.RoundPrice(amount)
-
 		it.applyAmount(0, checkout.ConstLabelGrandTotal, amount)
 		totalPriceAdjustmentAmount += amount
 
@@ -393,25 +460,52 @@ func (it *DefaultCheckout) applyPriceAdjustment(priceAdjustment checkout.StructP
 			}
 		}
 
+		for _, label := range priceAdjustment.Labels {
+			if utils.IsInListStr(label, splitLabels) {
+				it.splitAmount(amount, priceAdjustment.Labels)
+				break
+			}
+		}
+
 	} else {
-		for index, amount := range priceAdjustment.PerItem {
-			currentItemTotal := it.GetItemSpecificTotal(index, checkout.ConstLabelGrandTotal)
+		// items are processed in index order to have same results for same checkout
+		var indexes []int
+		for index := range priceAdjustment.PerItem {
+			indexes = append(indexes, utils.InterfaceToInt(index))
+		}
+		sort.Ints(indexes)
+
+		amounts := make([]utils.Money, len(indexes))
+		cartTotal := it.calculateAmount
+		for idx, index := range indexes {
+			value := priceAdjustment.PerItem[utils.InterfaceToString(index)]
+
+			currentItemTotal := it.getItemTotals(index)[checkout.ConstLabelGrandTotal]
+			amount := utils.MoneyFromFloat(value)
 			if priceAdjustment.IsPercent {
-				amount = currentItemTotal * amount / 100.0
+				amount = currentItemTotal.Percent(value, roundingMode)
 			}
 
 			// prevent negative values of grand total per cart
-			if amount+it.calculateAmount < 0.0 {
-				amount = 0.0 - it.calculateAmount
+			if amount+cartTotal < 0 {
+				amount = -cartTotal
 			}
 
 			// prevent negative values of grand total per item
-			if amount+currentItemTotal < 0.0 {
-				amount = 0.0 - currentItemTotal
+			if index != 0 && amount+currentItemTotal < 0 {
+				amount = -currentItemTotal
 			}
 
-			// adding amount to grand total of current item and full cart
-			amount = utils.RoundPrice(amount)
+			amounts[idx] = amount
+			cartTotal += amount
+		}
+
+		it.roundAmounts(amounts, roundingMode)
+
+		// adding amount to grand total of current item and full cart
+		for idx, index := range indexes {
+			amount := amounts[idx]
+
 			it.applyAmount(index, checkout.ConstLabelGrandTotal, amount)
 			totalPriceAdjustmentAmount += amount
 
@@ -421,13 +515,41 @@ func (it *DefaultCheckout) applyPriceAdjustment(priceAdjustment checkout.StructP
 				}
 			}
 		}
-
 	}
 
-	priceAdjustment.Amount = totalPriceAdjustmentAmount
+	priceAdjustment.Amount = totalPriceAdjustmentAmount.Float64()
 	it.priceAdjustments = append(it.priceAdjustments, priceAdjustment)
 }
 
+// roundAmounts rounds per item amounts of price adjustment to money precision
+//   - per line level rounds each amount
+//   - per total level rounds amounts sum and allocates it over items, so rounding error is not accumulated, amounts
+//     of different signs are rounded per line
+func (it *DefaultCheckout) roundAmounts(amounts []utils.Money, roundingMode string) {
+	var total utils.Money
+	positive, negative := false, false
+	weights := make([]utils.Money, len(amounts))
+	for idx, amount := range amounts {
+		total += amount
+		weights[idx] = amount
+		if amount < 0 {
+			weights[idx] = -amount
+			negative = true
+		} else if amount > 0 {
+			positive = true
+		}
+	}
+
+	if checkout.IsPerLineRounding() || (positive && negative) {
+		for idx, amount := range amounts {
+			amounts[idx] = amount.Round(roundingMode)
+		}
+		return
+	}
+
+	copy(amounts, total.Allocate(weights, roundingMode))
+}
+
 // CalculateAmount do a calculation of all amounts for checkout
 // TODO: make function use calculateTarget as a limit for priority to where it need to be calculated
 func (it *DefaultCheckout) CalculateAmount(calculateTarget float64) float64 {
@@ -436,21 +558,27 @@ func (it *DefaultCheckout) CalculateAmount(calculateTarget float64) float64 {
 		it.calculateFlag = true
 		it.calculateAmount = 0
 		it.priceAdjustments = make([]checkout.StructPriceAdjustment, 0)
-		it.calculationDetailTotals = make(map[int]map[string]float64)
+		it.calculationDetailTotals = make(map[int]map[string]utils.Money)
+		it.calculationTrace = nil
+
+		tracing := it.traceFlag || utils.InterfaceToBool(env.ConfigGetValue(checkout.ConstConfigPathCalculationTrace))
 
 		var priceAdjustments []checkout.StructPriceAdjustment
+		var priceAdjustmentSources []string
 		priceAdjustmentCalls := make(map[float64]func(checkout.InterfaceCheckout, float64) []checkout.StructPriceAdjustment)
+		priceAdjustmentCallSources := make(map[float64]string)
 		for _, priceAdjustment := range checkout.GetRegisteredPriceAdjustments() {
 			for _, priorityValue := range priceAdjustment.GetPriority() {
 				priceAdjustmentCalls[priorityValue] = priceAdjustment.Calculate
+				priceAdjustmentCallSources[priorityValue] = priceAdjustment.GetCode()
 			}
 		}
 
-		basePoints := map[float64]func() checkout.StructPriceAdjustment{
-			checkout.ConstCalculateTargetSubtotal: func() checkout.StructPriceAdjustment {
-				return it.calculateSubtotal()
+		basePoints := map[float64]func() []checkout.StructPriceAdjustment{
+			checkout.ConstCalculateTargetSubtotal: func() []checkout.StructPriceAdjustment {
+				return []checkout.StructPriceAdjustment{it.calculateSubtotal()}
 			},
-			checkout.ConstCalculateTargetShipping: func() checkout.StructPriceAdjustment {
+			checkout.ConstCalculateTargetShipping: func() []checkout.StructPriceAdjustment {
 				return it.calculateShipping()
 			},
 		}
@@ -485,7 +613,9 @@ func (it *DefaultCheckout) CalculateAmount(calculateTarget float64) float64 {
 					}
 				} else {
 					if priority == maxPriority {
-						it.applyPriceAdjustment(value())
+						for _, priceAdjustment := range value() {
+							it.applyTracedPriceAdjustment(priceAdjustment, checkout.ConstTraceSourceCheckout, tracing)
+						}
 					}
 				}
 			}
@@ -502,13 +632,14 @@ func (it *DefaultCheckout) CalculateAmount(calculateTarget float64) float64 {
 					if priority == maxPriority {
 						for _, priceAdjustment := range priceAdjustmentCall(it, priority) {
 							priceAdjustments = append(priceAdjustments, priceAdjustment)
+							priceAdjustmentSources = append(priceAdjustmentSources, priceAdjustmentCallSources[priority])
 						}
 					}
 				}
 			}
 
 			// priceAdjustment lookup
-			for _, priceAdjustment := range priceAdjustments {
+			for idx, priceAdjustment := range priceAdjustments {
 
 				if searchMode {
 					priority := priceAdjustment.Priority
@@ -518,7 +649,7 @@ func (it *DefaultCheckout) CalculateAmount(calculateTarget float64) float64 {
 					}
 				} else {
 					if priceAdjustment.Priority == maxPriority {
-						it.applyPriceAdjustment(priceAdjustment)
+						it.applyTracedPriceAdjustment(priceAdjustment, priceAdjustmentSources[idx], tracing)
 					}
 				}
 			}
@@ -533,7 +664,11 @@ func (it *DefaultCheckout) CalculateAmount(calculateTarget float64) float64 {
 
 		infoDetails := map[string]interface{}{}
 		for index, details := range it.calculationDetailTotals {
-			infoDetails[utils.InterfaceToString(index)] = details
+			amounts := make(map[string]float64)
+			for label, amount := range details {
+				amounts[label] = amount.Float64()
+			}
+			infoDetails[utils.InterfaceToString(index)] = amounts
 		}
 
 		if err := it.SetInfo("calculation", infoDetails); err != nil {
@@ -546,7 +681,7 @@ func (it *DefaultCheckout) CalculateAmount(calculateTarget float64) float64 {
 		it.calculateFlag = false
 	}
 
-	return it.calculateAmount
+	return it.calculateAmount.Float64()
 }
 
 // GetGrandTotal returns grand total for current checkout
@@ -603,14 +738,29 @@ func (it *DefaultCheckout) IsSubscription() bool {
 	return subscription.ContainsSubscriptionItems(it)
 }
 
+// GetViolations returns restriction rules violations checkout was rejected for on last submit
+func (it *DefaultCheckout) GetViolations() []checkout.StructViolation {
+	return it.violations
+}
+
 // Submit creates the order with provided information
 func (it *DefaultCheckout) Submit() (interface{}, error) {
+	it.violations = nil
 
 	if it.GetBillingAddress() == nil {
 		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "080db3c0-dbb5-4398-b1f1-4c3fefef79b4", "Billing address is not set")
 	}
 
-	if it.GetShippingAddress() == nil {
+	// items split over several destinations are shipped to the first group address and method by default
+	shippingCheckout := checkout.InterfaceCheckout(it)
+	if len(it.ShippingGroups) > 0 {
+		if err := it.validateShippingGroups(); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		shippingCheckout = it.GetShippingGroupCheckout(0)
+	}
+
+	if shippingCheckout.GetShippingAddress() == nil {
 		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1c069d54-2847-46cb-bccd-76fc13d229ea", "Shipping address is not set")
 	}
 
@@ -618,7 +768,7 @@ func (it *DefaultCheckout) Submit() (interface{}, error) {
 		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c0628038-3e06-47e9-9252-480351d903c0", "Payment method is not set")
 	}
 
-	if it.GetShippingMethod() == nil {
+	if shippingCheckout.GetShippingMethod() == nil {
 		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e22877fe-248d-4b5e-ad2f-10843cb9890c", "Shipping method is not set")
 	}
 
@@ -641,6 +791,16 @@ func (it *DefaultCheckout) Submit() (interface{}, error) {
 		return nil, env.ErrorDispatch(err)
 	}
 
+	it.violations = checkout.ValidateCheckout(it)
+	if err := checkout.ViolationsError(it.violations); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// cart items stay reserved while payment is processed
+	if err := reserveCartItems(currentCart); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
 	// making new order if needed
 	//---------------------------
 	currentTime := time.Now()
@@ -705,20 +865,20 @@ func (it *DefaultCheckout) Submit() (interface{}, error) {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c7f3cae2-ed76-4002-8d7c-5031cf55c6c6", err.Error())
 	}
 
-	shippingAddress := it.GetShippingAddress().ToHashMap()
+	shippingAddress := shippingCheckout.GetShippingAddress().ToHashMap()
 	if err := checkoutOrder.Set("shipping_address", shippingAddress); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c005d525-4c06-4d65-933b-6a928dd93bac", err.Error())
 	}
 
 	shippingInfo := utils.InterfaceToMap(checkoutOrder.Get("shipping_info"))
-	shippingInfo["shipping_method_name"] = it.GetShippingMethod().GetName() + "/" + it.GetShippingRate().Name
+	shippingInfo["shipping_method_name"] = shippingCheckout.GetShippingMethod().GetName() + "/" + shippingCheckout.GetShippingRate().Name
 	if notes := utils.InterfaceToString(it.GetInfo("notes")); notes != "" {
 		shippingInfo["notes"] = notes
 	}
 	if err := checkoutOrder.Set("shipping_info", shippingInfo); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e6de55fb-4a18-4aee-b474-1f0ecf742789", err.Error())
 	}
-	if err := checkoutOrder.Set("shipping_method", it.GetShippingMethod().GetCode()+"/"+it.GetShippingRate().Code); err != nil {
+	if err := checkoutOrder.Set("shipping_method", shippingCheckout.GetShippingMethod().GetCode()+"/"+shippingCheckout.GetShippingRate().Code); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "78ef6507-709c-4aa9-a3ac-d13364128b42", err.Error())
 	}
 
@@ -726,6 +886,28 @@ func (it *DefaultCheckout) Submit() (interface{}, error) {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "792a8468-4e49-43f6-9670-8f1c2fa122c0", err.Error())
 	}
 
+	// order belongs to store cart was made in, current store is used for carts made before stores were introduced
+	storeID := currentCart.GetStoreID()
+	if storeID == "" {
+		storeID = api.GetCurrentStoreID()
+	}
+	if err := checkoutOrder.Set("store_id", storeID); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5d1f8a3c-e627-4b94-a0c5-9e3b7d2f6a18", err.Error())
+	}
+
+	// order is charged in currency visitor selected, rate is fixed at order placement
+	currencyCode := currency.GetSessionCurrency(it.GetSession())
+	currencyRate, rateErr := currency.GetRate(currencyCode)
+	if rateErr != nil {
+		return nil, env.ErrorDispatch(rateErr)
+	}
+	if err := checkoutOrder.Set(currency.ConstOrderAttributeCurrency, currencyCode); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7b3e9f1a-c526-4d84-8e0b-4a1d6c9f2e35", err.Error())
+	}
+	if err := checkoutOrder.Set(currency.ConstOrderAttributeRate, currencyRate); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e2c6a9d4-8f13-4b57-a0e1-9d5b3f7c1a68", err.Error())
+	}
+
 	paymentMethod := it.GetPaymentMethod()
 
 	// call for recalculating of all amounts including taxes and discounts
@@ -734,6 +916,11 @@ func (it *DefaultCheckout) Submit() (interface{}, error) {
 
 	customInfo := utils.InterfaceToMap(checkoutOrder.Get("custom_info"))
 	customInfo["calculation"] = it.Info["calculation"]
+	if it.calculationTrace != nil {
+		customInfo[checkout.ConstOrderInfoCalculationTrace] = it.calculationTrace
+	} else {
+		delete(customInfo, checkout.ConstOrderInfoCalculationTrace)
+	}
 	if err := checkoutOrder.Set("custom_info", customInfo); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "937c4411-c18d-4cf2-a0de-17c0ccb9d42b", err.Error())
 	}
@@ -779,6 +966,9 @@ func (it *DefaultCheckout) Submit() (interface{}, error) {
 	if err := checkoutOrder.Set("shipping_amount", it.GetShippingAmount()); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c592435a-71fc-45cb-bd7a-18790fe616a6", err.Error())
 	}
+	if err := checkoutOrder.Set("shipments", it.getOrderShipments()); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b8e2f5a4-1d73-4c09-a6b5-3e9c7d0f2a81", err.Error())
+	}
 
 	// remove order items, and add new from current cart with new description
 	err := checkoutOrder.RemoveAllItems()
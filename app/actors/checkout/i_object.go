@@ -30,6 +30,8 @@ func (it *DefaultCheckout) Get(attribute string) interface{} {
 		return it.ShippingMethodCode
 	case "ShippingRate":
 		return it.ShippingRate
+	case "ShippingGroups":
+		return it.ShippingGroups
 	case "Info":
 		return it.Info
 	}
@@ -89,6 +91,34 @@ func (it *DefaultCheckout) Set(attribute string, value interface{}) error {
 			it.ShippingRate.Price = utils.InterfaceToFloat64(mapValue["Price"])
 		}
 
+	case "ShippingGroups":
+		it.ShippingGroups = make([]checkout.StructShippingGroup, 0)
+
+		switch typedValue := value.(type) {
+		case []checkout.StructShippingGroup:
+			it.ShippingGroups = append(it.ShippingGroups, typedValue...)
+		default:
+			for _, arrayItem := range utils.InterfaceToArray(value) {
+				mapValue := utils.InterfaceToMap(arrayItem)
+				rateValue := utils.InterfaceToMap(mapValue["ShippingRate"])
+
+				shippingGroup := checkout.StructShippingGroup{
+					Address:            utils.InterfaceToMap(mapValue["Address"]),
+					ShippingMethodCode: utils.InterfaceToString(mapValue["ShippingMethodCode"]),
+					ShippingRate: checkout.StructShippingRate{
+						Name:  utils.InterfaceToString(rateValue["Name"]),
+						Code:  utils.InterfaceToString(rateValue["Code"]),
+						Price: utils.InterfaceToFloat64(rateValue["Price"]),
+					},
+				}
+				for _, itemIdx := range utils.InterfaceToArray(mapValue["Items"]) {
+					shippingGroup.Items = append(shippingGroup.Items, utils.InterfaceToInt(itemIdx))
+				}
+
+				it.ShippingGroups = append(it.ShippingGroups, shippingGroup)
+			}
+		}
+
 		// leave this on it's one place to prevent some checkout from drop
 	case "Taxes":
 
@@ -132,6 +162,7 @@ func (it *DefaultCheckout) ToHashMap() map[string]interface{} {
 	result["PaymentMethodCode"] = it.PaymentMethodCode
 	result["ShippingMethodCode"] = it.ShippingMethodCode
 	result["ShippingRate"] = it.ShippingRate
+	result["ShippingGroups"] = it.ShippingGroups
 	result["Info"] = it.Info
 
 	return result
@@ -245,6 +276,19 @@ func (it *DefaultCheckout) GetAttributesInfo() []models.StructAttributeInfo {
 			Options:    "",
 			Default:    "",
 		},
+		models.StructAttributeInfo{
+			Model:      checkout.ConstCheckoutModelName,
+			Collection: "",
+			Attribute:  "ShippingGroups",
+			Type:       db.ConstTypeJSON,
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Shipping Groups",
+			Group:      "General",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
 		models.StructAttributeInfo{
 			Model:      checkout.ConstCheckoutModelName,
 			Collection: "",
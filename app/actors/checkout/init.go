@@ -3,6 +3,7 @@ package checkout
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/checkout"
@@ -18,4 +19,14 @@ func init() {
 
 	api.RegisterOnRestServiceStart(setupAPI)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "checkout",
+		Description:  "Checkout process",
+		Dependencies: []string{"cart", "order"},
+		Core:         true,
+		ConfigGroup:  checkout.ConstConfigPathGroup,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
@@ -3,6 +3,7 @@ package checkout
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/actors/discount/coupon"
 	"github.com/ottemo/foundation/app/actors/discount/giftcard"
@@ -10,6 +11,7 @@ import (
 	"github.com/ottemo/foundation/app/models/cart"
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/app/models/order"
+	"github.com/ottemo/foundation/app/models/product"
 )
 
 // SendOrderConfirmationEmail sends an order confirmation email
@@ -77,3 +79,20 @@ func (it *DefaultCheckout) CheckoutSuccess(checkoutOrder order.InterfaceOrder, s
 
 	return nil
 }
+
+// reserveCartItems reserves stock qty of cart items for the time checkout is in progress, reservations made before
+// are renewed
+func reserveCartItems(currentCart cart.InterfaceCart) error {
+	stockManager := product.GetRegisteredStock()
+	if stockManager == nil || utils.InterfaceToBool(env.ConfigGetValue(checkout.ConstConfigPathOversell)) {
+		return nil
+	}
+
+	for _, cartItem := range currentCart.GetItems() {
+		if err := stockManager.ReserveProductQty(currentCart.GetID(), cartItem.GetProductID(), cartItem.GetOptions(), cartItem.GetQty()); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
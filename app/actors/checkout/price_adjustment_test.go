@@ -98,7 +98,7 @@ func TestPriceAdjustments(t *testing.T) {
 	currentCheckout := new(DefaultCheckout)
 
 	// prevent from executing of calculate function
-	currentCheckout.calculationDetailTotals = make(map[int]map[string]float64)
+	currentCheckout.calculationDetailTotals = make(map[int]map[string]utils.Money)
 	currentCheckout.calculateFlag = true
 
 	for index, priceAdjustment := range priceAdjustments {
@@ -136,6 +136,124 @@ func TestPriceAdjustments(t *testing.T) {
 	}
 }
 
+func TestPriceAdjustmentsSplit(t *testing.T) {
+	priceAdjustments := []checkout.StructPriceAdjustment{
+		checkout.StructPriceAdjustment{
+			Code:     checkout.ConstLabelSubtotal,
+			Name:     checkout.ConstLabelSubtotal,
+			Priority: checkout.ConstCalculateTargetSubtotal,
+			Labels:   []string{checkout.ConstLabelSubtotal},
+			PerItem: map[string]float64{
+				"1": 3.33,
+				"2": 3.33,
+				"3": 3.34,
+			},
+		},
+
+		// cart discount is split over items
+		checkout.StructPriceAdjustment{
+			Code:      "coupon",
+			Name:      "15% off",
+			Amount:    -15,
+			IsPercent: true,
+			Priority:  2.1,
+			Labels:    []string{checkout.ConstLabelDiscount},
+		},
+
+		checkout.StructPriceAdjustment{
+			Code:      "Country-State",
+			Name:      "Tax",
+			Amount:    8.875,
+			IsPercent: true,
+			Priority:  2.5,
+			Labels:    []string{checkout.ConstLabelTax},
+		},
+	}
+
+	currentCheckout := new(DefaultCheckout)
+	currentCheckout.calculationDetailTotals = make(map[int]map[string]utils.Money)
+	currentCheckout.calculateFlag = true
+
+	for _, priceAdjustment := range priceAdjustments {
+		currentCheckout.applyPriceAdjustment(priceAdjustment)
+	}
+
+	// 10.00 - 1.50 = 8.50, 8.50 * 8.875% = 0.754375
+	if x := currentCheckout.GetGrandTotal(); x != 9.25 {
+		t.Error("incorrect grand total:", x)
+	}
+
+	for _, label := range []string{checkout.ConstLabelDiscount, checkout.ConstLabelTax, checkout.ConstLabelGrandTotal} {
+		var itemsTotal utils.Money
+		for _, index := range currentCheckout.getItemIndexes() {
+			itemsTotal += currentCheckout.calculationDetailTotals[index][label]
+		}
+		if cartTotal := currentCheckout.calculationDetailTotals[0][label]; itemsTotal != cartTotal {
+			t.Error("items", label, "total", itemsTotal, "differs from cart one", cartTotal)
+		}
+	}
+}
+
+func TestCalculationTrace(t *testing.T) {
+	priceAdjustments := []checkout.StructPriceAdjustment{
+		checkout.StructPriceAdjustment{
+			Code:     checkout.ConstLabelSubtotal,
+			Name:     checkout.ConstLabelSubtotal,
+			Priority: checkout.ConstCalculateTargetSubtotal,
+			Labels:   []string{checkout.ConstLabelSubtotal},
+			PerItem:  map[string]float64{"1": 20, "2": 30},
+		},
+		checkout.StructPriceAdjustment{
+			Code:     "SAVE5",
+			Name:     "5 off",
+			Priority: 2.1,
+			Labels:   []string{checkout.ConstLabelDiscount},
+			PerItem:  map[string]float64{"2": -5},
+		},
+		checkout.StructPriceAdjustment{
+			Code:      "Country-State",
+			Name:      "Tax",
+			Amount:    10,
+			IsPercent: true,
+			Priority:  2.5,
+			Labels:    []string{checkout.ConstLabelTax},
+		},
+	}
+
+	currentCheckout := new(DefaultCheckout)
+	currentCheckout.calculationDetailTotals = make(map[int]map[string]utils.Money)
+	currentCheckout.calculateFlag = true
+
+	for _, priceAdjustment := range priceAdjustments {
+		currentCheckout.applyTracedPriceAdjustment(priceAdjustment, "test", true)
+	}
+
+	trace := currentCheckout.calculationTrace
+	if len(trace) != len(priceAdjustments) {
+		t.Fatal("unexpected trace length:", len(trace))
+	}
+	if x := trace[1].Deltas["2"]; x != -5 {
+		t.Error("incorrect discount delta:", x)
+	}
+	if x := trace[2].Totals[checkout.ConstLabelGrandTotal]; x != 49.5 {
+		t.Error("incorrect grand total after tax:", x)
+	}
+
+	// trace stored on order is decoded and replayed to same totals
+	storedTrace, err := utils.DecodeJSONToInterface(utils.EncodeToJSONString(trace))
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps, err := decodeCalculationTrace(storedTrace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := replayCalculationTrace(steps)
+	if replay.calculateAmount != currentCheckout.calculateAmount {
+		t.Error("replay grand total", replay.calculateAmount, "differs from calculated one", currentCheckout.calculateAmount)
+	}
+}
+
 /*
 This output is generated with
 const DEBUG = true
@@ -0,0 +1,170 @@
+package rules
+
+import (
+	"encoding/json"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupConfig setups package configuration values for a system
+func setupConfig() error {
+	config := env.GetConfig()
+	if config == nil {
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "d7b3f1a2-49c6-4e08-8d5a-6f2c90e1b374", "can't obtain config")
+		return env.ErrorDispatch(err)
+	}
+
+	err := config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathGroup,
+		Value:       nil,
+		Type:        env.ConstConfigTypeGroup,
+		Editor:      "",
+		Options:     nil,
+		Label:       "Checkout Rules",
+		Description: "restrictions checked on cart changes and checkout submit",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:    ConstConfigPathMinSubtotal,
+		Value:   `{}`,
+		Type:    env.ConstConfigTypeJSON,
+		Editor:  "multiline_text",
+		Options: "",
+		Label:   "Minimum Order Subtotal",
+		Description: `minimum order subtotal by shipping country code, pattern:
+{"US": 25, "CA": 50, "*": 10}
+"*" is used for countries not listed, make it "{}" to disable the rule`,
+		Image: "",
+	}, env.FuncConfigValueValidator(validateMinSubtotal))
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:    ConstConfigPathMaxQty,
+		Value:   `{}`,
+		Type:    env.ConstConfigTypeJSON,
+		Editor:  "multiline_text",
+		Options: "",
+		Label:   "Maximum Quantity per Customer",
+		Description: `maximum qty of product customer could purchase by product SKU, pattern:
+{"sku-01": 2, "sku-02": 10}
+qty of previous not cancelled customer orders is taken into account, make it "{}" to disable the rule`,
+		Image: "",
+	}, env.FuncConfigValueValidator(validateMaxQty))
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:    ConstConfigPathBannedStates,
+		Value:   `{}`,
+		Type:    env.ConstConfigTypeJSON,
+		Editor:  "multiline_text",
+		Options: "",
+		Label:   "Shipping Restricted Products",
+		Description: `states product could not be shipped to by product SKU, pattern:
+{"sku-01": "CA, NY", "sku-02": "HI, AK"}
+make it "{}" to disable the rule`,
+		Image: "",
+	}, env.FuncConfigValueValidator(validateBannedStates))
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:    ConstConfigPathAgeRestricted,
+		Value:   `{}`,
+		Type:    env.ConstConfigTypeJSON,
+		Editor:  "multiline_text",
+		Options: "",
+		Label:   "Age Restricted Products",
+		Description: `minimum age of customer by product SKU, pattern:
+{"sku-01": 21, "sku-02": 18}
+visitor confirms age by "` + ConstInfoAgeConfirmed + `" checkout info value, make it "{}" to disable the rule`,
+		Image: "",
+	}, env.FuncConfigValueValidator(validateAgeRestricted))
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// validateMinSubtotal checks minimum subtotals to be non negative amounts
+func validateMinSubtotal(value interface{}) (interface{}, error) {
+	var amounts map[string]float64
+	if err := decodeConfigValue(value, &amounts); err != nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1e8b4c27-f5a0-4d93-b6c1-3a7d09e2f865", "minimum subtotals should be object of amounts by country: "+err.Error())
+	}
+
+	for country, amount := range amounts {
+		if amount < 0 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8f2d60b3-a947-4c1e-9e05-d4b17c3a82f6", "minimum subtotal of "+country+" should not be negative")
+		}
+	}
+
+	return value, nil
+}
+
+// validateMaxQty checks maximum quantities to be positive
+func validateMaxQty(value interface{}) (interface{}, error) {
+	var quantities map[string]int
+	if err := decodeConfigValue(value, &quantities); err != nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b5c91e07-3d2a-4f68-a1b4-e60f8d2c7a93", "maximum quantities should be object of qty by SKU: "+err.Error())
+	}
+
+	for sku, qty := range quantities {
+		if qty <= 0 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4a07d3e8-6c15-4b92-8f3e-29b5c1a0d6e4", "maximum qty of "+sku+" should be positive")
+		}
+	}
+
+	return value, nil
+}
+
+// validateBannedStates checks banned states to be a comma separated lists
+func validateBannedStates(value interface{}) (interface{}, error) {
+	var states map[string]string
+	if err := decodeConfigValue(value, &states); err != nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e3a6f924-8b07-4d5c-9a21-c7f04b8e1d35", "banned states should be object of comma separated states by SKU: "+err.Error())
+	}
+
+	return value, nil
+}
+
+// validateAgeRestricted checks minimum ages to be positive
+func validateAgeRestricted(value interface{}) (interface{}, error) {
+	var ages map[string]int
+	if err := decodeConfigValue(value, &ages); err != nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7c1f5b08-e2d4-4a96-b370-9d8e6a4f21c5", "minimum ages should be object of age by SKU: "+err.Error())
+	}
+
+	for sku, age := range ages {
+		if age <= 0 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "2b9e07d4-5f3c-4e81-a6d2-0c4b7f93e158", "minimum age of "+sku+" should be positive")
+		}
+	}
+
+	return value, nil
+}
+
+// decodeConfigValue decodes JSON object config value into given map, blank value is an empty object
+func decodeConfigValue(value interface{}, target interface{}) error {
+	stringValue := utils.InterfaceToString(value)
+	if stringValue == "" {
+		stringValue = "{}"
+	}
+
+	return json.Unmarshal([]byte(stringValue), target)
+}
@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"testing"
+)
+
+// TestConfigValidators tests rule config values validation
+func TestConfigValidators(t *testing.T) {
+	var tests = []struct {
+		validator   func(value interface{}) (interface{}, error)
+		value       interface{}
+		expectedErr bool
+	}{
+		{validateMinSubtotal, "", false},
+		{validateMinSubtotal, `{"US": 25, "*": 10}`, false},
+		{validateMinSubtotal, `{"US": -1}`, true},
+		{validateMinSubtotal, `[25]`, true},
+		{validateMaxQty, `{"sku-01": 2}`, false},
+		{validateMaxQty, `{"sku-01": 0}`, true},
+		{validateBannedStates, `{"sku-01": "CA, NY"}`, false},
+		{validateBannedStates, `{"sku-01": ["CA"]}`, true},
+		{validateAgeRestricted, `{"sku-01": 21}`, false},
+		{validateAgeRestricted, `{"sku-01": "adult"}`, true},
+	}
+
+	for idx, test := range tests {
+		if _, err := test.validator(test.value); (err != nil) != test.expectedErr {
+			t.Errorf("test %d: value %v, expected error %v, got %v", idx, test.value, test.expectedErr, err)
+		}
+	}
+}
+
+// TestIsStateInList tests banned states list matching
+func TestIsStateInList(t *testing.T) {
+	if !isStateInList("ny", "CA, NY") {
+		t.Error("state should be found in list regardless of case and spaces")
+	}
+	if isStateInList("", "CA, NY") {
+		t.Error("blank state should not be found in list")
+	}
+	if isStateInList("NV", "CA, NY") {
+		t.Error("state should not be found in list")
+	}
+}
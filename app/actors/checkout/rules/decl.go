@@ -0,0 +1,35 @@
+// Package rules implements checkout restriction rules: minimum order subtotal per country, maximum quantity of product
+// per customer, products banned from shipping to states and age restricted products
+package rules
+
+import (
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstConfigPathGroup         = "general.checkout.rules"
+	ConstConfigPathMinSubtotal   = "general.checkout.rules.min_subtotal"
+	ConstConfigPathMaxQty        = "general.checkout.rules.max_qty"
+	ConstConfigPathBannedStates  = "general.checkout.rules.banned_states"
+	ConstConfigPathAgeRestricted = "general.checkout.rules.age_restricted"
+
+	ConstAnyCountry = "*" // minimum subtotal key used for countries not listed
+
+	ConstInfoAgeConfirmed = "age_confirmed" // checkout info key visitor confirms their age with
+
+	ConstErrorModule = "checkout/rules"
+	ConstErrorLevel  = env.ConstErrorLevelActor
+)
+
+// MinSubtotalRule is a implementer of InterfaceValidationRule for a minimum order subtotal per shipping country
+type MinSubtotalRule struct{}
+
+// MaxQtyRule is a implementer of InterfaceValidationRule for a maximum qty of product customer could purchase
+type MaxQtyRule struct{}
+
+// BannedStatesRule is a implementer of InterfaceValidationRule for products which could not be shipped to some states
+type BannedStatesRule struct{}
+
+// AgeRestrictedRule is a implementer of InterfaceValidationRule for products which require age confirmation
+type AgeRestrictedRule struct{}
@@ -0,0 +1,186 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+	"github.com/ottemo/foundation/utils"
+
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/order"
+)
+
+// getRuleConfig decodes rule config value into given map, returns false if rule is not configured or checkout rules
+// extension is disabled, so rule is not evaluated
+func getRuleConfig(path string, target interface{}) bool {
+	if !extension.IsEnabled("checkout_rules") {
+		return false
+	}
+
+	value := utils.InterfaceToString(env.ConfigGetValue(path))
+	if value == "" {
+		return false
+	}
+
+	if err := decodeConfigValue(value, target); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5e0c7a2f-b81d-4c38-97e6-f8a3d2b5c140", err.Error())
+		return false
+	}
+
+	return true
+}
+
+// getItemSku returns SKU of cart item product or blank value if product is not available
+func getItemSku(cartItem cart.InterfaceCartItem) string {
+	if itemProduct := cartItem.GetProduct(); itemProduct != nil {
+		return itemProduct.GetSku()
+	}
+	return ""
+}
+
+// getItemName returns name of cart item product to refer to it in violation message
+func getItemName(cartItem cart.InterfaceCartItem) string {
+	if itemProduct := cartItem.GetProduct(); itemProduct != nil {
+		return itemProduct.GetName()
+	}
+	return cartItem.GetProductID()
+}
+
+// getItemStates returns states cart items are shipped to by item index, items without shipping address are skipped
+func getItemStates(checkoutInstance checkout.InterfaceCheckout) map[int]string {
+	result := make(map[int]string)
+
+	shippingGroups := checkoutInstance.GetShippingGroups()
+	if len(shippingGroups) == 0 {
+		if shippingAddress := checkoutInstance.GetShippingAddress(); shippingAddress != nil {
+			for _, cartItem := range checkoutInstance.GetItems() {
+				result[cartItem.GetIdx()] = shippingAddress.GetState()
+			}
+		}
+		return result
+	}
+
+	for groupIdx, shippingGroup := range shippingGroups {
+		groupCheckout := checkoutInstance.GetShippingGroupCheckout(groupIdx)
+		if groupCheckout == nil {
+			continue
+		}
+
+		if shippingAddress := groupCheckout.GetShippingAddress(); shippingAddress != nil {
+			for _, itemIdx := range shippingGroup.Items {
+				result[itemIdx] = shippingAddress.GetState()
+			}
+		}
+	}
+
+	return result
+}
+
+// isStateInList checks state to be in comma separated list of states, comparison is case insensitive
+func isStateInList(state string, statesList string) bool {
+	state = strings.TrimSpace(state)
+	if state == "" {
+		return false
+	}
+
+	for _, listState := range strings.Split(statesList, ",") {
+		if strings.EqualFold(strings.TrimSpace(listState), state) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPurchaseOrder checks order record to be a paid order other than the one checkout is placing, so products of order
+// which payment was declined are not counted when visitor retries checkout
+func isPurchaseOrder(orderRecord map[string]interface{}, checkoutOrderID string) bool {
+	if checkoutOrderID != "" && utils.InterfaceToString(orderRecord["_id"]) == checkoutOrderID {
+		return false
+	}
+
+	status := utils.InterfaceToString(orderRecord["status"])
+	return status == order.ConstOrderStatusProcessed || status == order.ConstOrderStatusCompleted
+}
+
+// getPurchasedQty returns qty of products customer have purchased in paid (processed and completed) orders by SKU
+//   - customer is identified by visitor for registered visitors and by e-mail for guests
+//   - order checkout is placing is not taken into account
+func getPurchasedQty(checkoutInstance checkout.InterfaceCheckout, skus []string) (map[string]int, error) {
+	result := make(map[string]int)
+
+	customerAttribute := "visitor_id"
+	customerValue := ""
+	if currentVisitor := checkoutInstance.GetVisitor(); currentVisitor != nil && currentVisitor.GetID() != "" {
+		customerValue = currentVisitor.GetID()
+	} else {
+		customerAttribute = "customer_email"
+		customerValue = utils.InterfaceToString(checkoutInstance.GetInfo("customer_email"))
+	}
+
+	if customerValue == "" || len(skus) == 0 {
+		return result, nil
+	}
+
+	checkoutOrderID := ""
+	if checkoutOrder := checkoutInstance.GetOrder(); checkoutOrder != nil {
+		checkoutOrderID = checkoutOrder.GetID()
+	}
+
+	orderCollectionModel, err := order.GetOrderCollectionModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	orderDBCollection := orderCollectionModel.GetDBCollection()
+	if err := orderDBCollection.AddFilter(customerAttribute, "=", customerValue); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := orderDBCollection.AddFilter("status", "in", []string{order.ConstOrderStatusProcessed, order.ConstOrderStatusCompleted}); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := orderDBCollection.SetResultColumns("_id", "status"); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	orderRecords, err := orderDBCollection.Load()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var orderIDs []string
+	for _, orderRecord := range orderRecords {
+		if isPurchaseOrder(orderRecord, checkoutOrderID) {
+			orderIDs = append(orderIDs, utils.InterfaceToString(orderRecord["_id"]))
+		}
+	}
+	if len(orderIDs) == 0 {
+		return result, nil
+	}
+
+	orderItemCollectionModel, err := order.GetOrderItemCollectionModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	orderItemDBCollection := orderItemCollectionModel.GetDBCollection()
+	if err := orderItemDBCollection.AddFilter("order_id", "in", orderIDs); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := orderItemDBCollection.AddFilter("sku", "in", skus); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	orderItems, err := orderItemDBCollection.Load()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	for _, orderItem := range orderItems {
+		result[utils.InterfaceToString(orderItem["sku"])] += utils.InterfaceToInt(orderItem["qty"])
+	}
+
+	return result, nil
+}
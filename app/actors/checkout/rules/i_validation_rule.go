@@ -0,0 +1,198 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+
+	"github.com/ottemo/foundation/app/models/checkout"
+)
+
+// GetName returns name of checkout restriction rule
+func (it *MinSubtotalRule) GetName() string {
+	return "Minimum Order Subtotal"
+}
+
+// GetCode returns code of checkout restriction rule
+func (it *MinSubtotalRule) GetCode() string {
+	return "min_subtotal"
+}
+
+// Validate checks checkout subtotal to reach minimum of shipping country
+//   - minimum of "*" key is used for countries not listed and for checkout without shipping address
+func (it *MinSubtotalRule) Validate(checkoutInstance checkout.InterfaceCheckout) []checkout.StructViolation {
+	var amounts map[string]float64
+	if !getRuleConfig(ConstConfigPathMinSubtotal, &amounts) || len(amounts) == 0 {
+		return nil
+	}
+
+	minSubtotal, present := amounts[ConstAnyCountry]
+	if shippingAddress := checkoutInstance.GetShippingAddress(); shippingAddress != nil {
+		for country, amount := range amounts {
+			if strings.EqualFold(country, shippingAddress.GetCountry()) {
+				minSubtotal, present = amount, true
+			}
+		}
+	}
+
+	if !present || minSubtotal <= 0 || len(checkoutInstance.GetItems()) == 0 {
+		return nil
+	}
+
+	if checkoutInstance.GetSubtotal() < minSubtotal {
+		return []checkout.StructViolation{{
+			Rule:    it.GetCode(),
+			ItemIdx: 0,
+			Message: "minimum order subtotal is " + utils.InterfaceToString(utils.RoundPrice(minSubtotal)),
+		}}
+	}
+
+	return nil
+}
+
+// GetName returns name of checkout restriction rule
+func (it *MaxQtyRule) GetName() string {
+	return "Maximum Quantity per Customer"
+}
+
+// GetCode returns code of checkout restriction rule
+func (it *MaxQtyRule) GetCode() string {
+	return "max_qty"
+}
+
+// Validate checks qty of products in cart along with qty customer have already purchased to not exceed maximum
+//   - each cart item of exceeding product is reported
+func (it *MaxQtyRule) Validate(checkoutInstance checkout.InterfaceCheckout) []checkout.StructViolation {
+	var quantities map[string]int
+	if !getRuleConfig(ConstConfigPathMaxQty, &quantities) || len(quantities) == 0 {
+		return nil
+	}
+
+	cartItems := checkoutInstance.GetItems()
+
+	var skus []string
+	cartQty := make(map[string]int)
+	for _, cartItem := range cartItems {
+		sku := getItemSku(cartItem)
+		if _, present := quantities[sku]; !present {
+			continue
+		}
+
+		if _, present := cartQty[sku]; !present {
+			skus = append(skus, sku)
+		}
+		cartQty[sku] += cartItem.GetQty()
+	}
+
+	if len(skus) == 0 {
+		return nil
+	}
+
+	purchasedQty, err := getPurchasedQty(checkoutInstance, skus)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		purchasedQty = make(map[string]int)
+	}
+
+	var result []checkout.StructViolation
+	for _, cartItem := range cartItems {
+		sku := getItemSku(cartItem)
+		maxQty, present := quantities[sku]
+		if !present || cartQty[sku]+purchasedQty[sku] <= maxQty {
+			continue
+		}
+
+		message := "maximum qty of " + getItemName(cartItem) + " per customer is " + utils.InterfaceToString(maxQty)
+		if purchasedQty[sku] > 0 {
+			availableQty := maxQty - purchasedQty[sku]
+			if availableQty < 0 {
+				availableQty = 0
+			}
+			message += ", " + utils.InterfaceToString(availableQty) + " more could be purchased"
+		}
+
+		result = append(result, checkout.StructViolation{
+			Rule:    it.GetCode(),
+			ItemIdx: cartItem.GetIdx(),
+			Message: message,
+		})
+	}
+
+	return result
+}
+
+// GetName returns name of checkout restriction rule
+func (it *BannedStatesRule) GetName() string {
+	return "Shipping Restricted Products"
+}
+
+// GetCode returns code of checkout restriction rule
+func (it *BannedStatesRule) GetCode() string {
+	return "banned_states"
+}
+
+// Validate checks cart items are not shipped to states they are banned from
+//   - items are checked against address of their shipping group if checkout is split to several addresses
+func (it *BannedStatesRule) Validate(checkoutInstance checkout.InterfaceCheckout) []checkout.StructViolation {
+	var bannedStates map[string]string
+	if !getRuleConfig(ConstConfigPathBannedStates, &bannedStates) || len(bannedStates) == 0 {
+		return nil
+	}
+
+	itemStates := getItemStates(checkoutInstance)
+
+	var result []checkout.StructViolation
+	for _, cartItem := range checkoutInstance.GetItems() {
+		statesList, present := bannedStates[getItemSku(cartItem)]
+		if !present {
+			continue
+		}
+
+		if state := itemStates[cartItem.GetIdx()]; isStateInList(state, statesList) {
+			result = append(result, checkout.StructViolation{
+				Rule:    it.GetCode(),
+				ItemIdx: cartItem.GetIdx(),
+				Message: getItemName(cartItem) + " could not be shipped to " + state,
+			})
+		}
+	}
+
+	return result
+}
+
+// GetName returns name of checkout restriction rule
+func (it *AgeRestrictedRule) GetName() string {
+	return "Age Restricted Products"
+}
+
+// GetCode returns code of checkout restriction rule
+func (it *AgeRestrictedRule) GetCode() string {
+	return "age_restricted"
+}
+
+// Validate checks visitor confirmed age if cart contains age restricted products
+//   - recurring subscription orders are not checked as age was confirmed on subscription purchase
+func (it *AgeRestrictedRule) Validate(checkoutInstance checkout.InterfaceCheckout) []checkout.StructViolation {
+	var ages map[string]int
+	if !getRuleConfig(ConstConfigPathAgeRestricted, &ages) || len(ages) == 0 {
+		return nil
+	}
+
+	if utils.InterfaceToBool(checkoutInstance.GetInfo(ConstInfoAgeConfirmed)) || checkoutInstance.GetInfo("subscription_id") != nil {
+		return nil
+	}
+
+	var result []checkout.StructViolation
+	for _, cartItem := range checkoutInstance.GetItems() {
+		if age, present := ages[getItemSku(cartItem)]; present {
+			result = append(result, checkout.StructViolation{
+				Rule:    it.GetCode(),
+				ItemIdx: cartItem.GetIdx(),
+				Message: getItemName(cartItem) + " requires confirmation of age " + utils.InterfaceToString(age) + " or older",
+			})
+		}
+	}
+
+	return result
+}
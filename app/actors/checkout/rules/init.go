@@ -0,0 +1,35 @@
+package rules
+
+import (
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+
+	"github.com/ottemo/foundation/app/models/checkout"
+)
+
+// init makes package self-initialization routine
+func init() {
+	env.RegisterOnConfigStart(setupConfig)
+
+	validationRules := []checkout.InterfaceValidationRule{
+		new(MinSubtotalRule),
+		new(MaxQtyRule),
+		new(BannedStatesRule),
+		new(AgeRestrictedRule),
+	}
+
+	for _, validationRule := range validationRules {
+		if err := checkout.RegisterValidationRule(validationRule); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "0c5e8a91-7d24-4b63-a9f0-e2b6d18c4f57", err.Error())
+		}
+	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "checkout_rules",
+		Description:  "Checkout restriction rules",
+		Dependencies: []string{"checkout"},
+		ConfigGroup:  ConstConfigPathGroup,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
@@ -0,0 +1,197 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/order"
+	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/app/models/visitor"
+)
+
+// testConfig is a config made of values map only
+type testConfig struct {
+	env.InterfaceConfig
+
+	values map[string]interface{}
+}
+
+func (it *testConfig) GetValue(path string) interface{} { return it.values[path] }
+
+// testProduct is a product with SKU and name only
+type testProduct struct {
+	product.InterfaceProduct
+
+	sku string
+}
+
+func (it *testProduct) GetSku() string  { return it.sku }
+func (it *testProduct) GetName() string { return it.sku }
+
+// testCartItem is a cart item made of index, qty and product
+type testCartItem struct {
+	cart.InterfaceCartItem
+
+	idx     int
+	qty     int
+	product product.InterfaceProduct
+}
+
+func (it *testCartItem) GetIdx() int                          { return it.idx }
+func (it *testCartItem) GetQty() int                          { return it.qty }
+func (it *testCartItem) GetProductID() string                 { return it.product.GetSku() }
+func (it *testCartItem) GetProduct() product.InterfaceProduct { return it.product }
+
+// testAddress is a shipping address with country and state only
+type testAddress struct {
+	visitor.InterfaceVisitorAddress
+
+	country string
+	state   string
+}
+
+func (it *testAddress) GetCountry() string { return it.country }
+func (it *testAddress) GetState() string   { return it.state }
+
+// testCheckout is a guest checkout made of items, subtotal, shipping address and info
+type testCheckout struct {
+	checkout.InterfaceCheckout
+
+	items    []cart.InterfaceCartItem
+	subtotal float64
+	address  visitor.InterfaceVisitorAddress
+	info     map[string]interface{}
+}
+
+func (it *testCheckout) GetItems() []cart.InterfaceCartItem                  { return it.items }
+func (it *testCheckout) GetSubtotal() float64                                { return it.subtotal }
+func (it *testCheckout) GetShippingAddress() visitor.InterfaceVisitorAddress { return it.address }
+func (it *testCheckout) GetShippingGroups() []checkout.StructShippingGroup   { return nil }
+func (it *testCheckout) GetVisitor() visitor.InterfaceVisitor                { return nil }
+func (it *testCheckout) GetInfo(key string) interface{}                      { return it.info[key] }
+
+var testConfigInstance = &testConfig{values: make(map[string]interface{})}
+
+func TestMain(m *testing.M) {
+	if err := env.RegisterConfig(testConfigInstance); err != nil {
+		fmt.Println("Unable to register test config:", err)
+	}
+	if err := extension.Register(extension.StructManifest{Name: "checkout", Core: true}); err != nil {
+		fmt.Println("Unable to register checkout extension:", err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// newTestCheckout makes checkout of given items shipped to given country and state
+func newTestCheckout(subtotal float64, country string, state string, items ...cart.InterfaceCartItem) *testCheckout {
+	result := &testCheckout{items: items, subtotal: subtotal, info: make(map[string]interface{})}
+	if country != "" {
+		result.address = &testAddress{country: country, state: state}
+	}
+	return result
+}
+
+// newTestItem makes cart item of given product SKU
+func newTestItem(idx int, sku string, qty int) cart.InterfaceCartItem {
+	return &testCartItem{idx: idx, qty: qty, product: &testProduct{sku: sku}}
+}
+
+// TestRulesValidate tests restriction rules violations of checkout
+func TestRulesValidate(t *testing.T) {
+	testConfigInstance.values = map[string]interface{}{
+		ConstConfigPathMinSubtotal:   `{"US": 25, "*": 10}`,
+		ConstConfigPathMaxQty:        `{"sku-01": 2}`,
+		ConstConfigPathBannedStates:  `{"sku-01": "CA, NY"}`,
+		ConstConfigPathAgeRestricted: `{"sku-02": 21}`,
+	}
+
+	ageConfirmed := newTestCheckout(20, "US", "TX", newTestItem(1, "sku-02", 1))
+	ageConfirmed.info[ConstInfoAgeConfirmed] = true
+
+	var tests = []struct {
+		rule            checkout.InterfaceValidationRule
+		checkout        checkout.InterfaceCheckout
+		expectedItemIdx []int
+	}{
+		{new(MinSubtotalRule), newTestCheckout(20, "US", "TX", newTestItem(1, "sku-03", 1)), []int{0}},
+		{new(MinSubtotalRule), newTestCheckout(20, "CA", "ON", newTestItem(1, "sku-03", 1)), nil},
+		{new(MinSubtotalRule), newTestCheckout(5, "", "", newTestItem(1, "sku-03", 1)), []int{0}},
+		{new(MinSubtotalRule), newTestCheckout(0, "US", "TX"), nil},
+		{new(MaxQtyRule), newTestCheckout(20, "US", "TX", newTestItem(1, "sku-01", 2), newTestItem(2, "sku-03", 5)), nil},
+		{new(MaxQtyRule), newTestCheckout(20, "US", "TX", newTestItem(1, "sku-01", 2), newTestItem(2, "sku-01", 1)), []int{1, 2}},
+		{new(BannedStatesRule), newTestCheckout(20, "US", "ny", newTestItem(1, "sku-01", 1), newTestItem(2, "sku-03", 1)), []int{1}},
+		{new(BannedStatesRule), newTestCheckout(20, "US", "TX", newTestItem(1, "sku-01", 1)), nil},
+		{new(BannedStatesRule), newTestCheckout(20, "", "", newTestItem(1, "sku-01", 1)), nil},
+		{new(AgeRestrictedRule), newTestCheckout(20, "US", "TX", newTestItem(1, "sku-02", 1), newTestItem(2, "sku-03", 1)), []int{1}},
+		{new(AgeRestrictedRule), ageConfirmed, nil},
+	}
+
+	for idx, test := range tests {
+		violations := test.rule.Validate(test.checkout)
+		if len(violations) != len(test.expectedItemIdx) {
+			t.Errorf("test %d: %s expected %d violations, got %v", idx, test.rule.GetCode(), len(test.expectedItemIdx), violations)
+			continue
+		}
+
+		for violationIdx, violation := range violations {
+			if violation.Rule != test.rule.GetCode() || violation.ItemIdx != test.expectedItemIdx[violationIdx] {
+				t.Errorf("test %d: unexpected violation %v", idx, violation)
+			}
+		}
+	}
+}
+
+// TestRulesDisabled tests restriction rules not to be evaluated if checkout rules extension is disabled
+func TestRulesDisabled(t *testing.T) {
+	testConfigInstance.values = map[string]interface{}{
+		ConstConfigPathMinSubtotal:                              `{"*": 10}`,
+		ConstConfigPathAgeRestricted:                            `{"sku-02": 21}`,
+		extension.ConstConfigPathExtensions + ".checkout_rules": false,
+	}
+
+	checkoutInstance := newTestCheckout(5, "US", "TX", newTestItem(1, "sku-02", 1))
+	for _, rule := range []checkout.InterfaceValidationRule{new(MinSubtotalRule), new(AgeRestrictedRule)} {
+		if violations := rule.Validate(checkoutInstance); len(violations) != 0 {
+			t.Errorf("%s should not be evaluated, got %v", rule.GetCode(), violations)
+		}
+	}
+
+	testConfigInstance.values[extension.ConstConfigPathExtensions+".checkout_rules"] = true
+	if violations := checkout.ValidateCheckout(checkoutInstance); len(violations) != 2 {
+		t.Errorf("registered rules should be evaluated once extension is enabled, got %v", violations)
+	}
+}
+
+// TestIsPurchaseOrder tests only paid orders other than the one checkout is placing to be counted as purchases, so
+// visitor retrying checkout after declined card is not limited by products of the order being placed
+func TestIsPurchaseOrder(t *testing.T) {
+	var tests = []struct {
+		orderID  string
+		status   string
+		expected bool
+	}{
+		{"paid-order", order.ConstOrderStatusProcessed, true},
+		{"completed-order", order.ConstOrderStatusCompleted, true},
+		{"new-order", order.ConstOrderStatusNew, false},
+		{"pending-order", order.ConstOrderStatusPending, false},
+		{"declined-order", order.ConstOrderStatusDeclined, false},
+		{"cancelled-order", order.ConstOrderStatusCancelled, false},
+		{"checkout-order", order.ConstOrderStatusDeclined, false},
+		{"checkout-order", order.ConstOrderStatusPending, false},
+		{"checkout-order", order.ConstOrderStatusProcessed, false},
+	}
+
+	for idx, test := range tests {
+		orderRecord := map[string]interface{}{"_id": test.orderID, "status": test.status}
+		if result := isPurchaseOrder(orderRecord, "checkout-order"); result != test.expected {
+			t.Errorf("test %d: order %s %s expected to be purchase %v, got %v", idx, test.orderID, test.status, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package checkout
+
+import (
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/order"
+	"github.com/ottemo/foundation/app/models/visitor"
+)
+
+// SetShippingGroups splits checkout items over several destinations, blank value returns checkout to one destination
+func (it *DefaultCheckout) SetShippingGroups(groups []checkout.StructShippingGroup) error {
+	it.ShippingGroups = make([]checkout.StructShippingGroup, 0, len(groups))
+	it.ShippingGroups = append(it.ShippingGroups, groups...)
+	return nil
+}
+
+// GetShippingGroups returns groups checkout items are split into by destination
+func (it *DefaultCheckout) GetShippingGroups() []checkout.StructShippingGroup {
+	return it.ShippingGroups
+}
+
+// GetShippingGroupCheckout returns checkout limited to a shipping group or nil if there is no such group
+func (it *DefaultCheckout) GetShippingGroupCheckout(groupIdx int) checkout.InterfaceCheckout {
+	if groupIdx < 0 || groupIdx >= len(it.ShippingGroups) {
+		return nil
+	}
+
+	return &shippingGroupCheckout{DefaultCheckout: it, group: it.ShippingGroups[groupIdx]}
+}
+
+// hasItem checks cart item with given index is shipped by the group
+func (it *shippingGroupCheckout) hasItem(itemIdx int) bool {
+	for _, groupItemIdx := range it.group.Items {
+		if groupItemIdx == itemIdx {
+			return true
+		}
+	}
+	return false
+}
+
+// GetItems returns cart items of the group
+func (it *shippingGroupCheckout) GetItems() []cart.InterfaceCartItem {
+	var result []cart.InterfaceCartItem
+	for _, item := range it.DefaultCheckout.GetItems() {
+		if it.hasItem(item.GetIdx()) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// GetCart returns checkout cart limited to items of the group, so shipping methods quote the group only
+func (it *shippingGroupCheckout) GetCart() cart.InterfaceCart {
+	checkoutCart := it.DefaultCheckout.GetCart()
+	if checkoutCart == nil {
+		return nil
+	}
+
+	return &shippingGroupCart{InterfaceCart: checkoutCart, groupCheckout: it}
+}
+
+// GetItems returns cart items of the group
+func (it *shippingGroupCart) GetItems() []cart.InterfaceCartItem {
+	return it.groupCheckout.GetItems()
+}
+
+// GetSubtotal returns cart subtotal of the group items
+func (it *shippingGroupCart) GetSubtotal() float64 {
+	roundingMode := checkout.GetRoundingMode()
+	perLineRounding := checkout.IsPerLineRounding()
+
+	var subtotal utils.Money
+	for _, cartItem := range it.GetItems() {
+		if cartProduct := cartItem.GetProduct(); cartProduct != nil {
+			itemAmount := utils.MoneyFromFloat(cartProduct.GetPrice()).Mul(cartItem.GetQty())
+			if perLineRounding {
+				itemAmount = itemAmount.Round(roundingMode)
+			}
+			subtotal += itemAmount
+		}
+	}
+
+	return subtotal.Round(roundingMode).Float64()
+}
+
+// GetDiscountableItems returns cart items of the group that can be discounted
+func (it *shippingGroupCheckout) GetDiscountableItems() []cart.InterfaceCartItem {
+	var result []cart.InterfaceCartItem
+	for _, item := range it.DefaultCheckout.GetDiscountableItems() {
+		if it.hasItem(item.GetIdx()) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// GetShippingAddress returns shipping address of the group
+func (it *shippingGroupCheckout) GetShippingAddress() visitor.InterfaceVisitorAddress {
+	if len(it.group.Address) == 0 {
+		return nil
+	}
+
+	shippingAddress, err := visitor.GetVisitorAddressModel()
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return nil
+	}
+
+	if err := shippingAddress.FromHashMap(it.group.Address); err != nil {
+		_ = env.ErrorDispatch(err)
+		return nil
+	}
+
+	return shippingAddress
+}
+
+// GetShippingMethod returns shipping method of the group
+func (it *shippingGroupCheckout) GetShippingMethod() checkout.InterfaceShippingMethod {
+	return checkout.GetShippingMethodByCode(it.group.ShippingMethodCode)
+}
+
+// GetShippingRate returns shipping rate of the group
+func (it *shippingGroupCheckout) GetShippingRate() *checkout.StructShippingRate {
+	return &it.group.ShippingRate
+}
+
+// GetShippingGroups returns nothing as the group is shipped to one destination
+func (it *shippingGroupCheckout) GetShippingGroups() []checkout.StructShippingGroup {
+	return nil
+}
+
+// GetSubtotal returns subtotal of the group items
+func (it *shippingGroupCheckout) GetSubtotal() float64 {
+	var result utils.Money
+	for _, itemIdx := range it.group.Items {
+		result += utils.MoneyFromFloat(it.GetItemSpecificTotal(itemIdx, checkout.ConstLabelSubtotal))
+	}
+	return result.Float64()
+}
+
+// GetShippingAmount returns shipping price of the group
+func (it *shippingGroupCheckout) GetShippingAmount() float64 {
+	return it.group.ShippingRate.Price
+}
+
+// findShippingRate looks for shipping method and rate allowed for given checkout
+func findShippingRate(checkoutInstance checkout.InterfaceCheckout, methodCode string, rateCode string) (checkout.InterfaceShippingMethod, checkout.StructShippingRate, error) {
+	shippingMethod := checkout.GetShippingMethodByCode(methodCode)
+	if shippingMethod == nil {
+		return nil, checkout.StructShippingRate{}, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3c9e5a17-80d4-4b2f-a6e1-f4b72d09c83a", "shipping method and/or rate were not found")
+	}
+
+	if !shippingMethod.IsAllowed(checkoutInstance) {
+		return nil, checkout.StructShippingRate{}, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "e8b1d6f0-2a73-4c59-9d14-7f05c3e9a2b6", "shipping method not allowed")
+	}
+
+	for _, shippingRate := range shippingMethod.GetRates(checkoutInstance) {
+		if shippingRate.Code == rateCode {
+			return shippingMethod, shippingRate, nil
+		}
+	}
+
+	return nil, checkout.StructShippingRate{}, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "5f2a09d3-b6c8-4e71-8a3d-c1e74b6f05d9", "shipping method and/or rate were not found")
+}
+
+// validateShippingGroups checks shipping groups are complete and each cart item is shipped by one of them
+func (it *DefaultCheckout) validateShippingGroups() error {
+	// subscriptions are made for one shipping address
+	if it.IsSubscription() {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "f6a3c1e8-0b92-4d75-8e4f-2c9d7b5a1e03", "subscription items could not be shipped to several addresses")
+	}
+
+	cartItems := make(map[int]bool)
+	for _, item := range it.GetItems() {
+		cartItems[item.GetIdx()] = false
+	}
+
+	for groupIdx, group := range it.ShippingGroups {
+		if len(group.Items) == 0 {
+			return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1b7f4e2c-9d05-4a38-b6c1-e3a8d92f0754", "shipping group "+utils.InterfaceToString(groupIdx+1)+" has no items")
+		}
+
+		for _, itemIdx := range group.Items {
+			shipped, present := cartItems[itemIdx]
+			if !present {
+				return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a6d30c8e-5f41-4b97-82e0-7c19b5e4f3a1", "shipping group "+utils.InterfaceToString(groupIdx+1)+" refers to item which is not in cart")
+			}
+			if shipped {
+				return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d04e7b95-3c1a-4f62-a8d7-19f6e2c5b830", "cart item is included in several shipping groups")
+			}
+			cartItems[itemIdx] = true
+		}
+
+		groupCheckout := it.GetShippingGroupCheckout(groupIdx)
+		if groupCheckout.GetShippingAddress() == nil {
+			return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7e95c2a0-4d18-4b3f-9e6a-52c0f8d1b7e4", "shipping address is not set for shipping group "+utils.InterfaceToString(groupIdx+1))
+		}
+
+		if _, _, err := findShippingRate(groupCheckout, group.ShippingMethodCode, group.ShippingRate.Code); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	for _, shipped := range cartItems {
+		if !shipped {
+			return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c2f8a7d1-60e9-4b54-93c2-8a1d5e7f4b06", "not all cart items are included in shipping groups")
+		}
+	}
+
+	return nil
+}
+
+// getOrderShipments makes order shipments of calculated checkout shipping groups
+func (it *DefaultCheckout) getOrderShipments() []order.StructShipment {
+	var result []order.StructShipment
+
+	for groupIdx, group := range it.ShippingGroups {
+		shipment := order.StructShipment{
+			Items:          group.Items,
+			Address:        group.Address,
+			ShippingMethod: group.ShippingMethodCode + "/" + group.ShippingRate.Code,
+			ShippingName:   group.ShippingRate.Name,
+			ShippingAmount: group.ShippingRate.Price,
+		}
+
+		if shippingMethod := it.GetShippingGroupCheckout(groupIdx).GetShippingMethod(); shippingMethod != nil {
+			shipment.ShippingName = shippingMethod.GetName() + "/" + group.ShippingRate.Name
+		}
+
+		// group shipping amount is applied to cart only, so it is added to item totals
+		var taxAmount utils.Money
+		grandTotal := utils.MoneyFromFloat(group.ShippingRate.Price)
+		for _, itemIdx := range group.Items {
+			itemTotals := it.getItemTotals(itemIdx)
+			taxAmount += itemTotals[checkout.ConstLabelTax]
+			grandTotal += itemTotals[checkout.ConstLabelGrandTotal]
+		}
+		shipment.TaxAmount = taxAmount.Float64()
+		shipment.GrandTotal = grandTotal.Float64()
+
+		result = append(result, shipment)
+	}
+
+	return result
+}
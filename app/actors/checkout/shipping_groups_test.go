@@ -0,0 +1,98 @@
+package checkout
+
+import (
+	"testing"
+
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/product"
+)
+
+// testProduct is a product with weight and price only
+type testProduct struct {
+	product.InterfaceProduct
+
+	weight float64
+	price  float64
+}
+
+func (it *testProduct) GetWeight() float64 { return it.weight }
+func (it *testProduct) GetPrice() float64  { return it.price }
+
+// testCartItem is a cart item made of index, qty and product
+type testCartItem struct {
+	cart.InterfaceCartItem
+
+	idx     int
+	qty     int
+	product product.InterfaceProduct
+}
+
+func (it *testCartItem) GetIdx() int                          { return it.idx }
+func (it *testCartItem) GetQty() int                          { return it.qty }
+func (it *testCartItem) GetProduct() product.InterfaceProduct { return it.product }
+
+// testCart is a cart made of items only
+type testCart struct {
+	cart.InterfaceCart
+
+	items []cart.InterfaceCartItem
+}
+
+func (it *testCart) GetID() string                      { return "test-cart" }
+func (it *testCart) GetItems() []cart.InterfaceCartItem { return it.items }
+
+// testWeightShippingMethod quotes rate by weight of checkout cart, the way carriers do
+type testWeightShippingMethod struct {
+	checkout.InterfaceShippingMethod
+}
+
+func (it *testWeightShippingMethod) GetRates(checkoutInstance checkout.InterfaceCheckout) []checkout.StructShippingRate {
+	var pounds float64
+	for _, cartItem := range checkoutInstance.GetCart().GetItems() {
+		pounds += cartItem.GetProduct().GetWeight() * float64(cartItem.GetQty())
+	}
+	return []checkout.StructShippingRate{{Code: "weight", Price: pounds}}
+}
+
+// TestShippingGroupQuote tests shipping group checkout to be quoted for the group items only
+func TestShippingGroupQuote(t *testing.T) {
+	checkoutInstance := &DefaultCheckout{cart: &testCart{items: []cart.InterfaceCartItem{
+		&testCartItem{idx: 1, qty: 2, product: &testProduct{weight: 1.5, price: 10}},
+		&testCartItem{idx: 2, qty: 1, product: &testProduct{weight: 4, price: 25}},
+		&testCartItem{idx: 3, qty: 3, product: &testProduct{weight: 0.5, price: 2}},
+	}}}
+
+	err := checkoutInstance.SetShippingGroups([]checkout.StructShippingGroup{
+		{Items: []int{1, 3}},
+		{Items: []int{2}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		groupIdx         int
+		expectedItems    int
+		expectedPrice    float64
+		expectedSubtotal float64
+	}{
+		{0, 2, 4.5, 26},
+		{1, 1, 4, 25},
+	}
+
+	shippingMethod := new(testWeightShippingMethod)
+	for _, test := range tests {
+		groupCheckout := checkoutInstance.GetShippingGroupCheckout(test.groupIdx)
+
+		if items := groupCheckout.GetCart().GetItems(); len(items) != test.expectedItems {
+			t.Errorf("group %d: expected %d cart items, got %d", test.groupIdx, test.expectedItems, len(items))
+		}
+		if subtotal := groupCheckout.GetCart().GetSubtotal(); subtotal != test.expectedSubtotal {
+			t.Errorf("group %d: expected cart subtotal %v, got %v", test.groupIdx, test.expectedSubtotal, subtotal)
+		}
+		if rates := shippingMethod.GetRates(groupCheckout); rates[0].Price != test.expectedPrice {
+			t.Errorf("group %d: expected rate %v, got %v", test.groupIdx, test.expectedPrice, rates[0].Price)
+		}
+	}
+}
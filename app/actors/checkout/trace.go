@@ -0,0 +1,90 @@
+package checkout
+
+import (
+	"encoding/json"
+
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// applyTracedPriceAdjustment applies price adjustment and records calculation step if tracing is enabled
+func (it *DefaultCheckout) applyTracedPriceAdjustment(priceAdjustment checkout.StructPriceAdjustment, source string, tracing bool) {
+	if !tracing {
+		it.applyPriceAdjustment(priceAdjustment)
+		return
+	}
+
+	grandTotalsBefore := make(map[int]utils.Money)
+	for index, totals := range it.calculationDetailTotals {
+		grandTotalsBefore[index] = totals[checkout.ConstLabelGrandTotal]
+	}
+
+	appliedCount := len(it.priceAdjustments)
+	it.applyPriceAdjustment(priceAdjustment)
+
+	// blank price adjustments are skipped by calculation
+	if len(it.priceAdjustments) == appliedCount {
+		return
+	}
+
+	step := checkout.StructCalculationStep{
+		Priority:   priceAdjustment.Priority,
+		Source:     source,
+		Adjustment: priceAdjustment,
+		Amount:     it.priceAdjustments[appliedCount].Amount,
+		Deltas:     make(map[string]float64),
+		Totals:     make(map[string]float64),
+	}
+
+	for index, totals := range it.calculationDetailTotals {
+		if index == 0 {
+			for label, amount := range totals {
+				step.Totals[label] = amount.Float64()
+			}
+			continue
+		}
+
+		if delta := totals[checkout.ConstLabelGrandTotal] - grandTotalsBefore[index]; delta != 0 {
+			step.Deltas[utils.InterfaceToString(index)] = delta.Float64()
+		}
+	}
+
+	it.calculationTrace = append(it.calculationTrace, step)
+}
+
+// GetCalculationTrace returns steps of checkout calculation, tracing is made regardless of settings
+func (it *DefaultCheckout) GetCalculationTrace() []checkout.StructCalculationStep {
+	it.traceFlag = true
+	it.CalculateAmount(0)
+	it.traceFlag = false
+
+	return it.calculationTrace
+}
+
+// decodeCalculationTrace converts calculation trace stored within order custom info to calculation steps
+func decodeCalculationTrace(value interface{}) ([]checkout.StructCalculationStep, error) {
+	var result []checkout.StructCalculationStep
+
+	if err := json.Unmarshal([]byte(utils.EncodeToJSONString(value)), &result); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return result, nil
+}
+
+// replayCalculationTrace applies price adjustments of calculation steps to blank checkout, so calculation could be
+// checked against current rounding settings without cart, coupons and other data it was made on
+func replayCalculationTrace(steps []checkout.StructCalculationStep) *DefaultCheckout {
+	result := new(DefaultCheckout)
+	result.calculationDetailTotals = make(map[int]map[string]utils.Money)
+
+	// prevents calculation from being started by checkout data
+	result.calculateFlag = true
+
+	for _, step := range steps {
+		result.applyTracedPriceAdjustment(step.Adjustment, step.Source, true)
+	}
+
+	return result
+}
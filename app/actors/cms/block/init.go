@@ -6,6 +6,7 @@ import (
 	"github.com/ottemo/foundation/app/models/cms"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -30,6 +31,13 @@ func init() {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "43876927-a8f4-4c5e-8ced-e8daa7faed7c", err.Error())
 	}
 
+	if err := extension.Register(extension.StructManifest{
+		Name:        "cms_block",
+		Description: "CMS blocks",
+		Core:        true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/media"
 	"github.com/ottemo/foundation/utils"
 )
@@ -16,6 +17,14 @@ func init() {
 	if err := utils.RegisterTemplateFunction("media", mediaTemplateDirective); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "706a59bb-cfdd-4e26-b8f1-42444daa3170", err.Error())
 	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "cms_media",
+		Description: "CMS media storage",
+		Core:        true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func onAppStart() error {
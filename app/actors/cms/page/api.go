@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/cms"
+	"github.com/ottemo/foundation/app/models/store"
 	"github.com/ottemo/foundation/env"
 )
 
@@ -55,6 +56,11 @@ func APIListCMSPages(context api.InterfaceApplicationContext) (interface{}, erro
 		}
 	}
 
+	// excluding pages not assigned to store
+	if err := store.ApplyVisibilityFilter(context, cmsPageCollectionModel.GetDBCollection(), store.ConstObjectTypeCMSPage); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8d3a6f1c-b275-4e49-9a0c-4f7e2b9d5c13", err.Error())
+	}
+
 	// checking for a "count" request
 	if context.GetRequestArgument(api.ConstRESTActionParameter) == "count" {
 		return cmsPageCollectionModel.GetDBCollection().Count()
@@ -97,6 +103,10 @@ func APIGetCMSPage(context api.InterfaceApplicationContext) (interface{}, error)
 		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "fa76f5ac-0cce-4670-9e62-197a600ec0b9", "cms page is not available")
 	}
 
+	if !store.IsVisible(context, store.ConstObjectTypeCMSPage, cmsPage.GetID()) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3f8e1b6d-c942-4a75-b0d7-6e2c9a4f1b58", "cms page is not available")
+	}
+
 	result := cmsPage.ToHashMap()
 	result["evaluated"] = cmsPage.EvaluateContent()
 
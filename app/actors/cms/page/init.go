@@ -6,6 +6,7 @@ import (
 	"github.com/ottemo/foundation/app/models/cms"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -29,6 +30,14 @@ func init() {
 	if err := utils.RegisterTemplateFunction("page", pageTemplateDirective); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "642eedcf-ea33-4bce-9149-7085cd9c4377", err.Error())
 	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "cms_page",
+		Description: "CMS pages",
+		Core:        true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -0,0 +1,133 @@
+package currency
+
+import (
+	"strings"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models/currency"
+	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("currencies", APIListCurrencies)
+	service.PUT("currency/:code", APISelectCurrency)
+
+	// Admin Only
+	service.GET("currency/rates", api.IsAdminHandler(APIGetRates))
+	service.POST("currency/rates/update", api.IsAdminHandler(APIUpdateRates))
+
+	service.GET("product/:productID/prices", api.IsAdminHandler(APIGetProductPrices))
+	service.PUT("product/:productID/prices", api.IsAdminHandler(APISetProductPrices))
+
+	return nil
+}
+
+// APIListCurrencies returns display currencies of current store along with their rates and currency request
+// session works with
+func APIListCurrencies(context api.InterfaceApplicationContext) (interface{}, error) {
+	var currencies []map[string]interface{}
+	for _, code := range serviceInstance.GetDisplayCurrencies() {
+		rate, err := serviceInstance.GetRate(code)
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			continue
+		}
+		currencies = append(currencies, map[string]interface{}{"code": code, "rate": rate})
+	}
+
+	return map[string]interface{}{
+		"base":       serviceInstance.GetBaseCurrency(),
+		"default":    serviceInstance.GetDefaultCurrency(),
+		"current":    currency.GetCurrentCurrency(context),
+		"currencies": currencies,
+	}, nil
+}
+
+// APISelectCurrency sets currency prices are presented and charged in for request session
+//   - currency should be a display currency of current store having exchange rate
+func APISelectCurrency(context api.InterfaceApplicationContext) (interface{}, error) {
+	code := strings.ToUpper(context.GetRequestArgument("code"))
+
+	if !currency.IsDisplayCurrency(code) {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "f3a8d1c6-9b27-4e54-8a0d-5c2e7b9f1a36", "currency '"+code+"' is not available")
+	}
+
+	if _, err := serviceInstance.GetRate(code); err != nil {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	context.GetSession().Set(currency.ConstSessionKeyCurrency, code)
+
+	return APIListCurrencies(context)
+}
+
+// APIGetRates returns exchange rates currently used
+func APIGetRates(context api.InterfaceApplicationContext) (interface{}, error) {
+	return map[string]interface{}{
+		"base":       serviceInstance.GetBaseCurrency(),
+		"provider":   utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathProvider)),
+		"updated_at": serviceInstance.getUpdatedAt(),
+		"rates":      serviceInstance.GetRates(),
+	}, nil
+}
+
+// APIUpdateRates obtains exchange rates from configured provider at once
+func APIUpdateRates(context api.InterfaceApplicationContext) (interface{}, error) {
+	if err := serviceInstance.UpdateRates(); err != nil {
+		context.SetResponseStatusInternalServerError()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return APIGetRates(context)
+}
+
+// APIGetProductPrices returns explicit product prices by currency
+func APIGetProductPrices(context api.InterfaceApplicationContext) (interface{}, error) {
+	productID := context.GetRequestArgument("productID")
+	if _, err := product.LoadProductByID(productID); err != nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return serviceInstance.GetPriceOverrides(productID), nil
+}
+
+// APISetProductPrices updates explicit product prices
+//   - request content is a currency code to price map, blank or null price removes explicit price
+func APISetProductPrices(context api.InterfaceApplicationContext) (interface{}, error) {
+	productID := context.GetRequestArgument("productID")
+	if _, err := product.LoadProductByID(productID); err != nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	requestData, err := api.GetRequestContentAsMap(context)
+	if err != nil {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	for code, value := range requestData {
+		if value == nil || utils.InterfaceToString(value) == "" {
+			if err := serviceInstance.RemovePriceOverride(productID, code); err != nil {
+				return nil, env.ErrorDispatch(err)
+			}
+			continue
+		}
+
+		if err := serviceInstance.SetPriceOverride(productID, code, utils.InterfaceToFloat64(value)); err != nil {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	return serviceInstance.GetPriceOverrides(productID), nil
+}
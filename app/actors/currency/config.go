@@ -0,0 +1,189 @@
+package currency
+
+import (
+	"strings"
+
+	"github.com/ottemo/foundation/app/models/currency"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupConfig setups package configuration values for a system
+func setupConfig() error {
+	config := env.GetConfig()
+	if config == nil {
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "7d3b1f9e-c652-4a48-8e0d-2a6f9c4b1e75", "can't obtain config")
+		return env.ErrorDispatch(err)
+	}
+
+	err := config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathCurrency,
+		Value:       nil,
+		Type:        env.ConstConfigTypeGroup,
+		Editor:      "",
+		Options:     nil,
+		Label:       "Currency",
+		Description: "currencies and exchange rates settings",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	// base currency change makes rates to be updated
+	baseValidator := func(newValue interface{}) (interface{}, error) {
+		code := strings.ToUpper(strings.TrimSpace(utils.InterfaceToString(newValue)))
+		if !currencyCodeRegexp.MatchString(code) {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "e4b8a2d6-3f17-4c95-9a0e-6d1c8f5b3a29", "three letter currency code expected")
+		}
+		serviceInstance.expireRates()
+
+		return code, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathBase,
+		Value:       currency.ConstDefaultBaseCurrency,
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "line_text",
+		Options:     nil,
+		Label:       "Base currency",
+		Description: "currency product prices, shipping rates and discounts are specified in (should not be changed when orders exist)",
+		Image:       "",
+	}, baseValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	currenciesValidator := func(newValue interface{}) (interface{}, error) {
+		value := utils.InterfaceToString(newValue)
+		if strings.TrimSpace(value) == "" {
+			return "", nil
+		}
+
+		codes := parseCurrencies(value)
+		if len(codes) != len(strings.Split(value, ",")) {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "5a9f3c7e-b284-4d16-8e0b-1c6d4f9a2e83", "comma separated three letter currency codes expected")
+		}
+
+		return strings.Join(codes, ", "), nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathDisplay,
+		Value:       currency.ConstDefaultBaseCurrency,
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "line_text",
+		Options:     nil,
+		Label:       "Display currencies",
+		Description: "comma separated currencies visitor could select prices to be presented and charged in, could be set per store",
+		Image:       "",
+	}, currenciesValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	defaultValidator := func(newValue interface{}) (interface{}, error) {
+		code := strings.ToUpper(strings.TrimSpace(utils.InterfaceToString(newValue)))
+		if code != "" && !currencyCodeRegexp.MatchString(code) {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3c8f1a6d-e947-4b25-9d0e-7a2c5f9b4e18", "three letter currency code expected")
+		}
+
+		return code, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathDefault,
+		Value:       "",
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "line_text",
+		Options:     nil,
+		Label:       "Default currency",
+		Description: "display currency used if visitor have not selected one, first display currency if blank, could be set per store",
+		Image:       "",
+	}, defaultValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	providers := make(map[string]string)
+	for code, provider := range currency.GetRateProviders() {
+		providers[code] = provider.GetName()
+	}
+
+	// rates source change makes rates to be updated
+	providerValidator := func(newValue interface{}) (interface{}, error) {
+		code := utils.InterfaceToString(newValue)
+		if currency.GetRateProvider(code) == nil {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b1e7d4a9-6c23-4f58-a0e2-9d5b3f8c1e46", "unknown rates provider '"+code+"'")
+		}
+		serviceInstance.expireRates()
+
+		return code, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathProvider,
+		Value:       ConstProviderManual,
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "select",
+		Options:     providers,
+		Label:       "Rates provider",
+		Description: "source of exchange rates, rates are updated hourly",
+		Image:       "",
+	}, providerValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	ratesValidator := func(newValue interface{}) (interface{}, error) {
+		if _, err := parseRates(utils.InterfaceToString(newValue)); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		serviceInstance.expireRates()
+
+		return newValue, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathRates,
+		Value:       "",
+		Type:        env.ConstConfigTypeText,
+		Editor:      "multiline_text",
+		Options:     nil,
+		Label:       "Manual rates",
+		Description: "amounts of currencies one unit of base currency is worth for manual provider, like 'CAD: 1.35, EUR: 0.92'",
+		Image:       "",
+	}, ratesValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	ratesFileValidator := func(newValue interface{}) (interface{}, error) {
+		serviceInstance.expireRates()
+		return newValue, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathRatesFile,
+		Value:       ConstDefaultRatesFile,
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "line_text",
+		Options:     nil,
+		Label:       "Rates file",
+		Description: "json file path for file provider, like {\"base\": \"USD\", \"rates\": {\"CAD\": 1.35}}",
+		Image:       "",
+	}, ratesFileValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Package currency is a default implementation of currency service declared in
+// "github.com/ottemo/foundation/app/models/currency" package
+package currency
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstCollectionNamePrice = "currency_price"
+
+	ConstConfigPathCurrency  = "general.currency"
+	ConstConfigPathBase      = "general.currency.base"
+	ConstConfigPathDisplay   = "general.currency.display"
+	ConstConfigPathDefault   = "general.currency.default"
+	ConstConfigPathProvider  = "general.currency.provider"
+	ConstConfigPathRates     = "general.currency.rates"
+	ConstConfigPathRatesFile = "general.currency.rates_file"
+
+	ConstProviderManual   = "manual"
+	ConstProviderFile     = "file"
+	ConstDefaultRatesFile = "currency_rates.json"
+
+	ConstRatesUpdateTaskName = "currencyRatesUpdate"
+
+	ConstErrorModule = "currency"
+	ConstErrorLevel  = env.ConstErrorLevelActor
+)
+
+// Package global variables
+var (
+	serviceInstance = new(DefaultCurrencyService)
+
+	currencyCodeRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// DefaultCurrencyService is a default implementer of InterfaceCurrencyService
+type DefaultCurrencyService struct {
+	rates        map[string]float64 // rates of currencies to base one
+	ratesBase    string             // base currency rates were obtained for
+	ratesExpired bool               // flag rates source settings were changed
+	updatedAt    time.Time
+	ratesMutex   sync.RWMutex
+
+	prices      map[string]map[string]float64 // explicit product prices by product id and currency
+	pricesMutex sync.RWMutex
+}
+
+// ManualRateProvider is a rates provider taking rates from configuration value
+type ManualRateProvider struct{}
+
+// FileRateProvider is a rates provider reading rates from json file, it is a stand-in for remote rates services
+//   - file format: {"base": "USD", "rates": {"CAD": 1.35, "EUR": 0.92}}
+type FileRateProvider struct{}
@@ -0,0 +1,83 @@
+package currency
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/app/models/currency"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+)
+
+// init makes package self-initialization routine
+func init() {
+	var _ currency.InterfaceCurrencyService = serviceInstance
+	if err := currency.RegisterCurrencyService(serviceInstance); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8b2e5f9a-d371-4c64-a0e8-3f6c1d9b7a25", err.Error())
+	}
+
+	if err := currency.RegisterRateProvider(new(ManualRateProvider)); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1d6a9c3e-f825-4b47-8e0c-7b4f2a8d5e91", err.Error())
+	}
+	if err := currency.RegisterRateProvider(new(FileRateProvider)); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e9c4b7f2-3a16-4d58-b0e9-5a1d8c6f3b72", err.Error())
+	}
+
+	env.RegisterOnConfigStart(setupConfig)
+	db.RegisterOnDatabaseStart(setupDB)
+	api.RegisterOnRestServiceStart(setupAPI)
+	app.OnAppStart(onAppStart)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "currency",
+		Description: "Multi-currency pricing and exchange rates",
+		Core:        true,
+		ConfigGroup: ConstConfigPathCurrency,
+		Routes:      []string{"currencies", "currency/*", "product/:productID/prices"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// setupDB prepares system database for package usage
+func setupDB() error {
+	collection, err := db.GetCollection(ConstCollectionNamePrice)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddColumn("product_id", db.ConstTypeID, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("currency", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("price", db.ConstTypeMoney, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// onAppStart obtains exchange rates and schedules their hourly update
+func onAppStart() error {
+	if err := serviceInstance.UpdateRates(); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if scheduler := env.GetScheduler(); scheduler != nil {
+		if err := extension.RegisterTask("currency", ConstRatesUpdateTaskName, updateRatesTask); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+		if _, err := scheduler.ScheduleRepeat("0 * * * *", ConstRatesUpdateTaskName, nil); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// updateRatesTask is a scheduler task updating exchange rates
+func updateRatesTask(params map[string]interface{}) error {
+	return serviceInstance.UpdateRates()
+}
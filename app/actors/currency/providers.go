@@ -0,0 +1,110 @@
+package currency
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// parseRates parses rates list in "CAD: 1.35, EUR: 0.92" format
+func parseRates(value string) (map[string]float64, error) {
+	result := make(map[string]float64)
+
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.FieldsFunc(item, func(r rune) bool { return r == ':' || r == '=' || r == ' ' })
+		if len(parts) != 2 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8a3f6d1c-2e95-4b74-a0c8-5d1e9b7f3a26", "invalid rate '"+item+"', 'CODE: rate' expected")
+		}
+
+		code := strings.ToUpper(parts[0])
+		if !currencyCodeRegexp.MatchString(code) {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d6e1b8a4-7c23-4f59-9e0b-3a8c5f2d1e97", "invalid currency code '"+parts[0]+"'")
+		}
+
+		rate := utils.InterfaceToFloat64(parts[1])
+		if rate <= 0 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1f9c4e7a-b352-4d86-8a0e-6c2b9d5f3e18", "rate of '"+code+"' should be positive")
+		}
+
+		result[code] = rate
+	}
+
+	return result, nil
+}
+
+// GetCode returns rates provider code
+func (it *ManualRateProvider) GetCode() string {
+	return ConstProviderManual
+}
+
+// GetName returns rates provider name
+func (it *ManualRateProvider) GetName() string {
+	return "Manual"
+}
+
+// GetRates returns rates specified within configuration, they are considered to be rates to base currency
+func (it *ManualRateProvider) GetRates(baseCurrency string) (map[string]float64, error) {
+	return parseRates(utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathRates)))
+}
+
+// GetCode returns rates provider code
+func (it *FileRateProvider) GetCode() string {
+	return ConstProviderFile
+}
+
+// GetName returns rates provider name
+func (it *FileRateProvider) GetName() string {
+	return "File"
+}
+
+// GetRates returns rates read from file, cross rates are calculated if file base currency differs from given one
+func (it *FileRateProvider) GetRates(baseCurrency string) (map[string]float64, error) {
+	filePath := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathRatesFile))
+	if filePath == "" {
+		filePath = ConstDefaultRatesFile
+	}
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var ratesFile struct {
+		Base  string             `json:"base"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.Unmarshal(content, &ratesFile); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	fileBase := strings.ToUpper(ratesFile.Base)
+	result := make(map[string]float64)
+	for code, rate := range ratesFile.Rates {
+		result[strings.ToUpper(code)] = rate
+	}
+
+	if fileBase == "" || fileBase == baseCurrency {
+		return result, nil
+	}
+
+	result[fileBase] = 1
+	baseRate, present := result[baseCurrency]
+	if !present || baseRate <= 0 {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4c8e2a6f-d917-4b35-8e1a-9f5d3b7c2e64", "rates file has no rate for base currency '"+baseCurrency+"'")
+	}
+
+	for code, rate := range result {
+		result[code] = rate / baseRate
+	}
+	delete(result, baseCurrency)
+
+	return result, nil
+}
@@ -0,0 +1,29 @@
+package currency
+
+import (
+	"testing"
+)
+
+// TestParseRates validates manual rates parsing
+func TestParseRates(t *testing.T) {
+	rates, err := parseRates("CAD: 1.35, eur=0.92\nGBP 0.79;")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]float64{"CAD": 1.35, "EUR": 0.92, "GBP": 0.79}
+	if len(rates) != len(expected) {
+		t.Fatalf("unexpected rates %v", rates)
+	}
+	for code, rate := range expected {
+		if rates[code] != rate {
+			t.Errorf("rate of %s is %v, %v expected", code, rates[code], rate)
+		}
+	}
+
+	for _, value := range []string{"CAD", "CAD: -1", "CADX: 1.35", "CAD: 1.35: 2"} {
+		if _, err := parseRates(value); err == nil {
+			t.Errorf("error expected for '%s'", value)
+		}
+	}
+}
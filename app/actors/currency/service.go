@@ -0,0 +1,291 @@
+package currency
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/app/models/currency"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// parseCurrencies parses comma separated currency codes list, invalid codes are skipped
+func parseCurrencies(value string) []string {
+	var result []string
+	for _, code := range strings.Split(value, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if currencyCodeRegexp.MatchString(code) {
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+// GetBaseCurrency returns code of currency all amounts are calculated and stored in
+func (it *DefaultCurrencyService) GetBaseCurrency() string {
+	if code := strings.ToUpper(utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathBase))); code != "" {
+		return code
+	}
+	return currency.ConstDefaultBaseCurrency
+}
+
+// GetDisplayCurrencies returns currencies prices could be presented in within current store, base currency is used if
+// there are no display currencies specified
+func (it *DefaultCurrencyService) GetDisplayCurrencies() []string {
+	result := parseCurrencies(utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathDisplay)))
+	if len(result) == 0 {
+		result = []string{it.GetBaseCurrency()}
+	}
+	return result
+}
+
+// GetDefaultCurrency returns currency prices are presented in within current store if visitor have not selected one
+//   - first display currency is used if default one is not specified or it is not a display currency
+func (it *DefaultCurrencyService) GetDefaultCurrency() string {
+	displayCurrencies := it.GetDisplayCurrencies()
+
+	defaultCurrency := strings.ToUpper(utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathDefault)))
+	for _, code := range displayCurrencies {
+		if code == defaultCurrency {
+			return code
+		}
+	}
+
+	return displayCurrencies[0]
+}
+
+// GetRate returns amount of given currency one unit of base currency is worth
+//   - rates are updated if base currency or rates source settings were changed since last update
+func (it *DefaultCurrencyService) GetRate(currencyCode string) (float64, error) {
+	baseCurrency := it.GetBaseCurrency()
+
+	currencyCode = strings.ToUpper(currencyCode)
+	if currencyCode == baseCurrency {
+		return 1, nil
+	}
+
+	it.ratesMutex.RLock()
+	expired := it.ratesExpired || it.ratesBase != baseCurrency
+	it.ratesMutex.RUnlock()
+
+	if expired {
+		if err := it.UpdateRates(); err != nil {
+			return 0, env.ErrorDispatch(err)
+		}
+	}
+
+	it.ratesMutex.RLock()
+	rate, present := it.rates[currencyCode]
+	it.ratesMutex.RUnlock()
+
+	if !present || rate <= 0 {
+		return 0, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "2b7d9e4f-a163-4c58-8e0a-5f3c1d9b7e26", "there is no exchange rate for currency '"+currencyCode+"'")
+	}
+
+	return rate, nil
+}
+
+// GetRates returns currently known rates of currencies to base one
+func (it *DefaultCurrencyService) GetRates() map[string]float64 {
+	it.ratesMutex.RLock()
+	defer it.ratesMutex.RUnlock()
+
+	result := make(map[string]float64)
+	for code, rate := range it.rates {
+		result[code] = rate
+	}
+	return result
+}
+
+// UpdateRates obtains rates from configured provider, previous rates are kept if provider fails
+func (it *DefaultCurrencyService) UpdateRates() error {
+	providerCode := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathProvider))
+	provider := currency.GetRateProvider(providerCode)
+	if provider == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9e3a6c1f-4d78-4b25-a0e9-7c2f5b8d1a63", "unknown rates provider '"+providerCode+"'")
+	}
+
+	baseCurrency := it.GetBaseCurrency()
+	rates, err := provider.GetRates(baseCurrency)
+
+	it.ratesMutex.Lock()
+	defer it.ratesMutex.Unlock()
+
+	it.ratesExpired = false
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	it.rates = rates
+	it.ratesBase = baseCurrency
+	it.updatedAt = time.Now()
+
+	return nil
+}
+
+// expireRates marks rates to be updated on next use
+func (it *DefaultCurrencyService) expireRates() {
+	it.ratesMutex.Lock()
+	it.ratesExpired = true
+	it.ratesMutex.Unlock()
+}
+
+// getUpdatedAt returns time rates were obtained
+func (it *DefaultCurrencyService) getUpdatedAt() time.Time {
+	it.ratesMutex.RLock()
+	defer it.ratesMutex.RUnlock()
+
+	return it.updatedAt
+}
+
+// getPrices returns explicit product prices map, map is loaded on first access
+func (it *DefaultCurrencyService) getPrices() map[string]map[string]float64 {
+	it.pricesMutex.RLock()
+	prices := it.prices
+	it.pricesMutex.RUnlock()
+
+	if prices != nil {
+		return prices
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNamePrice)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return nil
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return nil
+	}
+
+	prices = make(map[string]map[string]float64)
+	for _, record := range records {
+		productID := utils.InterfaceToString(record["product_id"])
+		if _, present := prices[productID]; !present {
+			prices[productID] = make(map[string]float64)
+		}
+		prices[productID][utils.InterfaceToString(record["currency"])] = utils.InterfaceToFloat64(record["price"])
+	}
+
+	it.pricesMutex.Lock()
+	it.prices = prices
+	it.pricesMutex.Unlock()
+
+	return prices
+}
+
+// GetPriceOverride returns explicit product price within given currency if there is one
+func (it *DefaultCurrencyService) GetPriceOverride(productID string, currencyCode string) (float64, bool) {
+	prices := it.getPrices()
+
+	it.pricesMutex.RLock()
+	defer it.pricesMutex.RUnlock()
+
+	price, present := prices[productID][strings.ToUpper(currencyCode)]
+	return price, present
+}
+
+// GetPriceOverrides returns explicit product prices by currency
+func (it *DefaultCurrencyService) GetPriceOverrides(productID string) map[string]float64 {
+	prices := it.getPrices()
+
+	it.pricesMutex.RLock()
+	defer it.pricesMutex.RUnlock()
+
+	result := make(map[string]float64)
+	for code, price := range prices[productID] {
+		result[code] = price
+	}
+	return result
+}
+
+// SetPriceOverride sets explicit product price within given currency, it is used instead of converted base price
+func (it *DefaultCurrencyService) SetPriceOverride(productID string, currencyCode string, price float64) error {
+	currencyCode = strings.ToUpper(currencyCode)
+	if !currencyCodeRegexp.MatchString(currencyCode) {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c5a1e8d3-7f42-4b96-9e0c-2d6b8f4a1c57", "invalid currency code '"+currencyCode+"'")
+	}
+	if currencyCode == it.GetBaseCurrency() {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6f2d9b4e-1a83-4c75-b0e6-8c3a5d7f2e19", "product price within base currency is a product price itself")
+	}
+	if price < 0 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a8e4c2f6-5b19-4d37-8e0a-1f7c9d3b5e62", "price should not be negative")
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNamePrice)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("product_id", "=", productID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddFilter("currency", "=", currencyCode); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	record := map[string]interface{}{"product_id": productID, "currency": currencyCode}
+	if len(records) > 0 {
+		record = records[0]
+	}
+	record["price"] = utils.RoundPrice(price)
+
+	if _, err := collection.Save(record); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if prices := it.getPrices(); prices != nil {
+		it.pricesMutex.Lock()
+		if _, present := prices[productID]; !present {
+			prices[productID] = make(map[string]float64)
+		}
+		prices[productID][currencyCode] = utils.RoundPrice(price)
+		it.pricesMutex.Unlock()
+	}
+
+	return nil
+}
+
+// RemovePriceOverride removes explicit product price within given currency, blank currency removes all of them
+func (it *DefaultCurrencyService) RemovePriceOverride(productID string, currencyCode string) error {
+	currencyCode = strings.ToUpper(currencyCode)
+
+	collection, err := db.GetCollection(ConstCollectionNamePrice)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("product_id", "=", productID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if currencyCode != "" {
+		if err := collection.AddFilter("currency", "=", currencyCode); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	if _, err := collection.Delete(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	prices := it.getPrices()
+
+	it.pricesMutex.Lock()
+	if currencyCode != "" {
+		delete(prices[productID], currencyCode)
+	}
+	if currencyCode == "" || len(prices[productID]) == 0 {
+		delete(prices, productID)
+	}
+	it.pricesMutex.Unlock()
+
+	return nil
+}
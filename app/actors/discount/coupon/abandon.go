@@ -0,0 +1,84 @@
+package coupon
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// abandonCouponListener makes single use coupon for abandoned cart campaign email and puts its code to event data
+//   - coupon is valid for given number of days, zero days makes coupon valid until it is used
+func abandonCouponListener(eventName string, data map[string]interface{}) bool {
+	amount := utils.InterfaceToFloat64(data["amount"])
+	percent := utils.InterfaceToFloat64(data["percent"])
+	if amount <= 0 && percent <= 0 {
+		return true
+	}
+
+	codeBytes := make([]byte, 5)
+	if _, err := rand.Read(codeBytes); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5c1e7a94-2d3b-4f86-b0a9-e8f4d63c1257", err.Error())
+		return true
+	}
+	couponCode := "CART-" + strings.ToUpper(hex.EncodeToString(codeBytes))
+
+	currentTime := time.Now()
+	newRecord := map[string]interface{}{
+		"code":    couponCode,
+		"name":    "Abandoned cart " + utils.InterfaceToString(data["email"]),
+		"amount":  amount,
+		"percent": percent,
+		"times":   1,
+		"since":   currentTime,
+		"limits":  make(map[string]interface{}),
+		"target":  checkout.ConstDiscountObjectCart,
+	}
+	if days := utils.InterfaceToInt(data["days"]); days > 0 {
+		newRecord["until"] = currentTime.AddDate(0, 0, days)
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameCouponDiscounts)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return true
+	}
+
+	if _, err := collection.Save(newRecord); err != nil {
+		_ = env.ErrorDispatch(err)
+		return true
+	}
+
+	data["code"] = couponCode
+
+	return true
+}
+
+// abandonCouponRevokeListener removes coupon made for abandoned cart email which was not sent
+func abandonCouponRevokeListener(eventName string, data map[string]interface{}) bool {
+	couponCode := utils.InterfaceToString(data["code"])
+	if couponCode == "" {
+		return true
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameCouponDiscounts)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return true
+	}
+
+	if err := collection.AddFilter("code", "=", couponCode); err != nil {
+		_ = env.ErrorDispatch(err)
+		return true
+	}
+	if _, err := collection.Delete(); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return true
+}
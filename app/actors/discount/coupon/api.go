@@ -282,34 +282,10 @@ func Remove(context api.InterfaceApplicationContext) (interface{}, error) {
 		}
 		context.GetSession().Set(ConstSessionKeyCurrentRedemptions, newAppliedCoupons)
 
-		// times used increase
-		collection, err := db.GetCollection(ConstCollectionNameCouponDiscounts)
-		if err != nil {
-			context.SetResponseStatusInternalServerError()
-			return nil, env.ErrorDispatch(err)
-		}
-		err = collection.AddFilter("code", "=", couponCode)
-		if err != nil {
-			context.SetResponseStatusInternalServerError()
-			return nil, env.ErrorDispatch(err)
-		}
-		records, err := collection.Load()
-		if err != nil {
+		if err := releaseCoupon(couponCode); err != nil {
 			context.SetResponseStatusInternalServerError()
 			return nil, env.ErrorDispatch(err)
 		}
-		if len(records) > 0 {
-			applyTimes := utils.InterfaceToInt(records[0]["times"])
-			if applyTimes >= 0 {
-				records[0]["times"] = applyTimes + 1
-
-				_, err := collection.Save(records[0])
-				if err != nil {
-					context.SetResponseStatusInternalServerError()
-					return nil, env.ErrorDispatch(err)
-				}
-			}
-		}
 	}
 
 	return "Removed successful", nil
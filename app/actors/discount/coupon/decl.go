@@ -4,6 +4,7 @@ package coupon
 
 import (
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
 )
 
 // Package global constants
@@ -27,8 +28,8 @@ var usedCoupons map[string][]string
 type discount struct {
 	Code     string
 	Name     string
-	Total    float64
-	Amount   float64
+	Total    utils.Money
+	Amount   utils.Money
 	Percents float64
 	Qty      int
 }
@@ -0,0 +1,55 @@
+package coupon
+
+import (
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// FilterRedeemableCodes returns codes which could be redeemed, so they are usable for a price estimate
+//   - codes already redeemed by a visitor are kept as their usage was counted on apply
+//   - other codes should exist and have usages left, validity dates are checked on calculation
+//   - order of codes is kept as it is an order coupons are applied in
+func FilterRedeemableCodes(codes []string, redeemedCodes []string) ([]string, error) {
+	usableCodes := make(map[string]bool)
+
+	var checkCodes []string
+	for _, code := range codes {
+		if utils.IsInArray(code, redeemedCodes) {
+			usableCodes[code] = true
+		} else if code != "" {
+			checkCodes = append(checkCodes, code)
+		}
+	}
+
+	if len(checkCodes) > 0 {
+		collection, err := db.GetCollection(ConstCollectionNameCouponDiscounts)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		if err := collection.AddFilter("code", "in", checkCodes); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+
+		records, err := collection.Load()
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+
+		for _, record := range records {
+			applyTimes := utils.InterfaceToInt(record["times"])
+			if applyTimes == -1 || applyTimes > 0 {
+				usableCodes[utils.InterfaceToString(record["code"])] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(usableCodes))
+	for _, code := range codes {
+		if usableCodes[code] && !utils.IsInListStr(code, result) {
+			result = append(result, code)
+		}
+	}
+
+	return result, nil
+}
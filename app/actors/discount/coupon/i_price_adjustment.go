@@ -7,6 +7,7 @@ import (
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -32,6 +33,10 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 
 	var result []checkout.StructPriceAdjustment
 
+	if !extension.IsEnabled("coupon") {
+		return result
+	}
+
 	// check session for applied coupon codes
 	if currentSession := checkoutInstance.GetSession(); currentSession != nil {
 
@@ -119,7 +124,7 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 				applicableDiscount := discount{
 					Code:     utils.InterfaceToString(discountCoupon["code"]),
 					Name:     utils.InterfaceToString(discountCoupon["name"]),
-					Amount:   utils.InterfaceToFloat64(discountCoupon["amount"]),
+					Amount:   utils.InterfaceToMoney(discountCoupon["amount"]),
 					Percents: utils.InterfaceToFloat64(discountCoupon["percent"]),
 					Qty:      utils.InterfaceToInt(discountCoupon["usage_qty"]),
 				}
@@ -144,7 +149,7 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 			// handle cart coupon discounts to find one that is biggest and append it to result
 			// as price adjustments in % and in $ amount
 			if !productCouponsCalculation {
-				currentCartAmount := checkoutInstance.GetItemSpecificTotal(0, checkout.ConstLabelGrandTotal)
+				currentCartAmount := utils.MoneyFromFloat(checkoutInstance.GetItemSpecificTotal(0, checkout.ConstLabelGrandTotal))
 				if len(applicableCartDiscounts) > 0 && currentCartAmount > 0 {
 					applicableDiscount, _ := findBiggestDiscount(applicableCartDiscounts, currentCartAmount)
 
@@ -165,7 +170,7 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 					}
 
 					if applicableDiscount.Amount > 0 {
-						currentPriceAdjustment.Amount = applicableDiscount.Amount * -1
+						currentPriceAdjustment.Amount = (-applicableDiscount.Amount).Float64()
 						currentPriceAdjustment.IsPercent = false
 						currentPriceAdjustment.Priority += float64(0.0001)
 
@@ -184,7 +189,7 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 				index := utils.InterfaceToString(cartItem.GetIdx())
 
 				if cartProduct := cartItem.GetProduct(); cartProduct != nil {
-					productPrice := cartProduct.GetPrice()
+					productPrice := utils.MoneyFromFloat(cartProduct.GetPrice())
 					productID := cartItem.GetProductID()
 					productQty := cartItem.GetQty()
 
@@ -218,11 +223,11 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 
 						// making from discount price adjustment
 						// calculating amount that will be discounted from item
-						amount := float64(discountUsed) * biggestAppliedDiscount.Total * -1
+						amount := -biggestAppliedDiscount.Total.Mul(discountUsed)
 
 						// add this amount to already existing PA (with the same coupon code) or creating new
 						if priceAdjustment, present := priceAdjustments[biggestAppliedDiscount.Code]; present {
-							priceAdjustment.PerItem[index] = utils.RoundPrice(priceAdjustment.PerItem[index] + amount)
+							priceAdjustment.PerItem[index] = (utils.MoneyFromFloat(priceAdjustment.PerItem[index]) + amount).Float64()
 							priceAdjustments[biggestAppliedDiscount.Code] = priceAdjustment
 						} else {
 							currentPriority += float64(0.000001)
@@ -234,7 +239,7 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 								Priority:  currentPriority,
 								Labels:    []string{checkout.ConstLabelDiscount},
 								PerItem: map[string]float64{
-									index: amount,
+									index: amount.Float64(),
 								},
 							}
 						}
@@ -253,15 +258,23 @@ func (it *Coupon) Calculate(checkoutInstance checkout.InterfaceCheckout, current
 }
 
 //finds biggest discount amount if applied more than one coupon
-func findBiggestDiscount(discounts []discount, total float64) (discount, int) {
+//   - percentage discount is rounded per unit for per line rounding level
+func findBiggestDiscount(discounts []discount, total utils.Money) (discount, int) {
 
 	var biggestAppliedDiscount discount
 	var biggestAppliedDiscountIndex int
 
+	roundingMode := checkout.GetRoundingMode()
+	perLineRounding := checkout.IsPerLineRounding()
+
 	// looking for biggest applicable discount for current item
 	for index, discount := range discounts {
 		if (discount.Qty) > 0 {
-			productDiscountableAmount := discount.Amount + total*discount.Percents/100
+			percentAmount := total.Percent(discount.Percents, roundingMode)
+			if perLineRounding {
+				percentAmount = percentAmount.Round(roundingMode)
+			}
+			productDiscountableAmount := discount.Amount + percentAmount
 
 			// if we have discount that is bigger then a price we will apply it
 			if productDiscountableAmount > total {
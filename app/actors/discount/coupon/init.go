@@ -3,10 +3,12 @@ package coupon
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/app/models/cart"
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -23,6 +25,16 @@ func init() {
 	api.RegisterOnRestServiceStart(setupAPI)
 
 	app.OnAppStart(initListeners)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "coupon",
+		Description:  "Coupon based discounts",
+		Dependencies: []string{"checkout"},
+		ConfigGroup:  ConstConfigPathDiscounts,
+		Routes:       []string{"coupons*", "cart/coupons*", "csv/coupons"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -67,6 +79,10 @@ func setupDB() error {
 // initListeners register event listeners
 func initListeners() error {
 
+	extension.EventRegisterListener("coupon", cart.ConstEventMerged, cartMergedListener)
+	extension.EventRegisterListener("coupon", cart.ConstEventAbandonCoupon, abandonCouponListener)
+	extension.EventRegisterListener("coupon", cart.ConstEventAbandonCouponRevoke, abandonCouponRevokeListener)
+
 	return nil
 }
 
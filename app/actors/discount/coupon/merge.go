@@ -0,0 +1,202 @@
+package coupon
+
+import (
+	"strings"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// releaseCoupon returns usage of coupon back, so limited coupon could be applied one more time
+func releaseCoupon(couponCode string) error {
+	collection, err := db.GetCollection(ConstCollectionNameCouponDiscounts)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddFilter("code", "=", couponCode); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if len(records) > 0 {
+		applyTimes := utils.InterfaceToInt(records[0]["times"])
+		if applyTimes >= 0 {
+			records[0]["times"] = applyTimes + 1
+
+			if _, err := collection.Save(records[0]); err != nil {
+				return env.ErrorDispatch(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// estimateCouponsDiscount returns approximate discount given coupons make for cart items
+//   - the biggest cart coupon is taken from cart subtotal and the biggest product coupon from each product line, so
+//     result could differ from checkout calculation which takes other price adjustments into account
+func estimateCouponsDiscount(couponCodes []string, items []cart.InterfaceCartItem) (utils.Money, error) {
+	if len(couponCodes) == 0 {
+		return 0, nil
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameCouponDiscounts)
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+	if err := collection.AddFilter("code", "in", couponCodes); err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	var subtotal utils.Money
+	productsInCart := make(map[string]int)
+	productPrices := make(map[string]utils.Money)
+	for _, item := range items {
+		if itemProduct := item.GetProduct(); itemProduct != nil {
+			price := utils.MoneyFromFloat(itemProduct.GetPrice())
+			productsInCart[item.GetProductID()] += item.GetQty()
+			productPrices[item.GetProductID()] = price
+			subtotal += price.Mul(item.GetQty())
+		}
+	}
+
+	var cartDiscounts []discount
+	productDiscounts := make(map[string][]discount)
+	for _, record := range records {
+		applyQty := getCouponApplyQty(productsInCart, record)
+		if applyQty <= 0 || !isValidStart(record["since"]) || !isValidEnd(record["until"]) {
+			continue
+		}
+
+		couponDiscount := discount{
+			Code:     utils.InterfaceToString(record["code"]),
+			Name:     utils.InterfaceToString(record["name"]),
+			Amount:   utils.InterfaceToMoney(record["amount"]),
+			Percents: utils.InterfaceToFloat64(record["percent"]),
+			Qty:      applyQty,
+		}
+
+		discountTarget := utils.InterfaceToString(record["target"])
+		if strings.Contains(discountTarget, checkout.ConstDiscountObjectCart) || discountTarget == "" {
+			cartDiscounts = append(cartDiscounts, couponDiscount)
+			continue
+		}
+
+		for _, productID := range utils.InterfaceToStringArray(discountTarget) {
+			if _, present := productsInCart[productID]; present {
+				productDiscounts[productID] = append(productDiscounts[productID], couponDiscount)
+			}
+		}
+	}
+
+	var result utils.Money
+	if len(cartDiscounts) > 0 && subtotal > 0 {
+		biggestDiscount, _ := findBiggestDiscount(cartDiscounts, subtotal)
+		result += biggestDiscount.Total
+	}
+
+	for productID, discounts := range productDiscounts {
+		biggestDiscount, _ := findBiggestDiscount(discounts, productPrices[productID])
+
+		qty := productsInCart[productID]
+		if biggestDiscount.Qty < qty {
+			qty = biggestDiscount.Qty
+		}
+		result += biggestDiscount.Total.Mul(qty)
+	}
+
+	return result, nil
+}
+
+// cartMergedListener keeps the better of coupons applied in guest session and in session of visitor cart after
+// guest cart was merged into visitor cart, coupons which are not kept are released
+func cartMergedListener(eventName string, data map[string]interface{}) bool {
+	currentSession, ok := data["session"].(api.InterfaceSession)
+	if !ok || currentSession == nil {
+		return true
+	}
+	mergedCart, ok := data["cart"].(cart.InterfaceCart)
+	if !ok || mergedCart == nil {
+		return true
+	}
+
+	guestCodes := utils.InterfaceToStringArray(currentSession.Get(ConstSessionKeyCurrentRedemptions))
+
+	var visitorCodes []string
+	var previousSession api.InterfaceSession
+	if previousSessionID := utils.InterfaceToString(data["previous_session_id"]); previousSessionID != "" && previousSessionID != currentSession.GetID() {
+		if sessionInstance, err := api.GetSessionByID(previousSessionID, false); err == nil && sessionInstance != nil {
+			previousSession = sessionInstance
+			visitorCodes = utils.InterfaceToStringArray(previousSession.Get(ConstSessionKeyCurrentRedemptions))
+		}
+	}
+
+	if len(guestCodes) == 0 && len(visitorCodes) == 0 {
+		return true
+	}
+
+	keptCodes, droppedCodes := guestCodes, visitorCodes
+	if len(guestCodes) == 0 {
+		keptCodes, droppedCodes = visitorCodes, nil
+	} else if len(visitorCodes) > 0 {
+		guestDiscount, err := estimateCouponsDiscount(guestCodes, mergedCart.GetItems())
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			return true
+		}
+		visitorDiscount, err := estimateCouponsDiscount(visitorCodes, mergedCart.GetItems())
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			return true
+		}
+
+		if visitorDiscount > guestDiscount {
+			keptCodes, droppedCodes = visitorCodes, guestCodes
+		}
+	}
+
+	// codes applied in both sessions are kept, so their usage should not be released
+	var releasedCodes []string
+	for _, couponCode := range droppedCodes {
+		if !utils.IsInArray(couponCode, keptCodes) {
+			releasedCodes = append(releasedCodes, couponCode)
+		}
+	}
+
+	// coupons follow the cart, so they are not applied in its previous session anymore
+	currentSession.Set(ConstSessionKeyCurrentRedemptions, keptCodes)
+	if previousSession != nil {
+		previousSession.Set(ConstSessionKeyCurrentRedemptions, make([]string, 0))
+	}
+
+	for _, couponCode := range releasedCodes {
+		if err := releaseCoupon(couponCode); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	if summary, ok := data["summary"].(map[string]interface{}); ok {
+		if releasedCodes == nil {
+			releasedCodes = make([]string, 0)
+		}
+		summary["coupons"] = map[string]interface{}{
+			"kept":    keptCodes,
+			"dropped": releasedCodes,
+		}
+	}
+
+	return true
+}
@@ -89,12 +89,16 @@ func GetList(context api.InterfaceApplicationContext) (interface{}, error) {
 
 	for _, value := range dbRecords {
 
-		initialAmount := utils.InterfaceToFloat64(value["amount"])
+		initialAmount := utils.InterfaceToMoney(value["amount"])
 		for _, amount := range utils.InterfaceToMap(value["orders_used"]) {
-			initialAmount = initialAmount + math.Abs(utils.InterfaceToFloat64(amount))
+			if usedAmount := utils.InterfaceToMoney(amount); usedAmount < 0 {
+				initialAmount -= usedAmount
+			} else {
+				initialAmount += usedAmount
+			}
 		}
 
-		value["initial_amount"] = initialAmount
+		value["initial_amount"] = initialAmount.Float64()
 	}
 
 	return dbRecords, nil
@@ -65,7 +65,7 @@ func orderProceedHandler(event string, eventData map[string]interface{}) bool {
 				giftCard := records[0]
 
 				// calculate the amount that will be on cart after apply and add order used record with orderID and amount
-				giftCardAmountAfterApply := utils.InterfaceToFloat64(giftCard["amount"]) + orderAppliedDiscount.Amount
+				giftCardAmountAfterApply := (utils.InterfaceToMoney(giftCard["amount"]) + utils.MoneyFromFloat(orderAppliedDiscount.Amount)).Float64()
 
 				ordersGiftCardUsedMap := utils.InterfaceToMap(giftCard["orders_used"])
 				ordersGiftCardUsedMap[orderID] = orderAppliedDiscount.Amount
@@ -133,7 +133,7 @@ func orderRollbackHandler(event string, eventData map[string]interface{}) bool {
 
 		if refillAmount, present := ordersUsage[orderID]; present {
 
-			newAmount := utils.InterfaceToFloat64(record["amount"]) - utils.InterfaceToFloat64(refillAmount)
+			newAmount := (utils.InterfaceToMoney(record["amount"]) - utils.InterfaceToMoney(refillAmount)).Float64()
 
 			// refill gift card amount, change status and orders_used information
 			delete(ordersUsage, orderID)
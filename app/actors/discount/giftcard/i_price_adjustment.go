@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 	"strings"
 )
@@ -27,6 +28,10 @@ func (it *DefaultGiftcard) GetPriority() []float64 {
 // Calculate calculates and returns amount and set of applied gift card discounts to given checkout
 func (it *DefaultGiftcard) Calculate(checkoutInstance checkout.InterfaceCheckout, currentPriority float64) []checkout.StructPriceAdjustment {
 	var result []checkout.StructPriceAdjustment
+	if !extension.IsEnabled("giftcard") {
+		return result
+	}
+
 	giftCardSkuElement := checkout.GiftCardSkuElement
 
 	// discount gift cards on 100%, so they wouldn't be discounted or taxed
@@ -119,13 +124,13 @@ func (it *DefaultGiftcard) Calculate(checkoutInstance checkout.InterfaceCheckout
 					continue
 				}
 
-				giftCardAmount := utils.InterfaceToFloat64(giftCard["amount"])
+				giftCardAmount := utils.InterfaceToMoney(giftCard["amount"])
 
 				if giftCardAmount > 0 {
 					result = append(result, checkout.StructPriceAdjustment{
 						Code:      utils.InterfaceToString(giftCard["code"]),
 						Name:      utils.InterfaceToString(giftCard["name"]),
-						Amount:    giftCardAmount * -1,
+						Amount:    (-giftCardAmount).Float64(),
 						IsPercent: false,
 						Priority:  priorityValue,
 						Labels:    []string{checkout.ConstLabelGiftCard},
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -20,7 +21,7 @@ func (it *Shipping) GetCode() string {
 
 // IsAllowed checks for method applicability
 func (it *Shipping) IsAllowed(checkout checkout.InterfaceCheckout) bool {
-	return true
+	return extension.IsEnabled("giftcard")
 }
 
 // GetRates returns rates allowed by shipping method for a given checkout
@@ -6,6 +6,7 @@ import (
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init makes package self-initialization routine
@@ -28,6 +29,15 @@ func init() {
 	api.RegisterOnRestServiceStart(setupAPI)
 
 	app.OnAppStart(onAppStart)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "giftcard",
+		Description:  "Gift cards",
+		Dependencies: []string{"checkout"},
+		Routes:       []string{"giftcard*", "cart/giftcards*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // DB preparations for current model implementation
@@ -85,12 +95,12 @@ func setupDB() error {
 // onAppStart makes module initialization on application startup
 func onAppStart() error {
 
-	env.EventRegisterListener("checkout.success", checkoutSuccessHandler)
-	env.EventRegisterListener("order.proceed", orderProceedHandler)
-	env.EventRegisterListener("order.rollback", orderRollbackHandler)
+	extension.EventRegisterListener("giftcard", "checkout.success", checkoutSuccessHandler)
+	extension.EventRegisterListener("giftcard", "order.proceed", orderProceedHandler)
+	extension.EventRegisterListener("giftcard", "order.rollback", orderRollbackHandler)
 
 	if scheduler := env.GetScheduler(); scheduler != nil {
-		if err := scheduler.RegisterTask("sendGiftCards", SendTask); err != nil {
+		if err := extension.RegisterTask("giftcard", "sendGiftCards", SendTask); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a3b21acc-4925-4ecc-b8b1-f7ca2f779b1a", err.Error())
 		}
 		if _, err := scheduler.ScheduleRepeat("0 8 * * *", "sendGiftCards", nil); err != nil {
@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/checkout"
@@ -34,6 +35,17 @@ func init() {
 
 	salePriceDelegate = new(SalePriceDelegate)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "saleprice",
+		Description:       "Sale prices",
+		Dependencies:      []string{"product", "checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+		Routes:            []string{"saleprice*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
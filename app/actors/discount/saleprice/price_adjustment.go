@@ -79,19 +79,18 @@ func (it *DefaultSalePrice) Calculate(checkoutInstance checkout.InterfaceCheckou
 				return result
 			}
 
-			itemGrandTotal := checkoutInstance.GetItemSpecificTotal(item.GetIdx(), checkout.ConstLabelGrandTotal)
+			itemGrandTotal := utils.MoneyFromFloat(checkoutInstance.GetItemSpecificTotal(item.GetIdx(), checkout.ConstLabelGrandTotal))
 
 			for _, salePrice := range salePrices {
 				if salePrice["product_id"] == productItem.GetID() {
-					suggestedDiscount := utils.InterfaceToFloat64(item.GetQty()) *
-						(productItem.GetPrice() - utils.InterfaceToFloat64(salePrice["amount"]))
+					suggestedDiscount := (utils.MoneyFromFloat(productItem.GetPrice()) - utils.InterfaceToMoney(salePrice["amount"])).Mul(item.GetQty())
 
 					// do not use sale price if it greater than current item calculated total
 					if suggestedDiscount > itemGrandTotal {
 						continue
 					}
 
-					perItem[utils.InterfaceToString(item.GetIdx())] = -suggestedDiscount
+					perItem[utils.InterfaceToString(item.GetIdx())] = (-suggestedDiscount).Float64()
 
 					// Because of time ranges are not overlapped, first found sale price is
 					// acceptable
@@ -0,0 +1,77 @@
+package errorreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// sendAlert notifies about error which rate crossed the threshold by email and webhook set in config
+//   - delivery failures are written to log directly, as dispatching them to error bus could cause alerts loop
+func sendAlert(alert map[string]interface{}) {
+	if email := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathReportEmail)); email != "" {
+		if err := sendAlertEmail(email, alert); err != nil {
+			env.Log(ConstLogStorage, env.ConstLogPrefixError, "alert email was not sent: "+err.Error())
+		}
+	}
+
+	if webhook := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathReportWebhook)); webhook != "" {
+		if err := sendAlertWebhook(webhook, alert); err != nil {
+			env.Log(ConstLogStorage, env.ConstLogPrefixError, "alert webhook was not called: "+err.Error())
+		}
+	}
+}
+
+// sendAlertEmail sends alert information to given email address
+func sendAlertEmail(email string, alert map[string]interface{}) error {
+	subject := "Error alert: " + utils.InterfaceToString(alert["code"])
+	if name := utils.InterfaceToString(alert["name"]); name != "" {
+		subject += " (" + name + ")"
+	}
+
+	lines := []string{
+		"Error occurred " + utils.InterfaceToString(alert["count"]) + " times within last " + utils.InterfaceToString(alert["window"]) + " minutes.",
+		"",
+		"Module: " + utils.InterfaceToString(alert["module"]),
+		"Level: " + utils.InterfaceToString(alert["level"]),
+		"Message: " + utils.InterfaceToString(alert["message"]),
+		"Total occurrences: " + utils.InterfaceToString(alert["total"]),
+		"First seen: " + utils.InterfaceToTime(alert["first_seen"]).Format(time.RFC3339),
+		"",
+		utils.InterfaceToString(alert["call_stack"]),
+	}
+
+	for index, line := range lines {
+		lines[index] = html.EscapeString(line)
+	}
+
+	return app.SendMail(email, subject, strings.Join(lines, "<br />\n"))
+}
+
+// sendAlertWebhook POSTs alert information to given URL as JSON
+func sendAlertWebhook(webhook string, alert map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"event": "error.alert", "error": alert})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "c3f8a1d6-9e2b-4b70-8d45-6a1e0f7c2b98", "webhook responded with status "+response.Status)
+	}
+
+	return nil
+}
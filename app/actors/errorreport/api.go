@@ -0,0 +1,44 @@
+package errorreport
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("app/errors/stats", api.IsAdminHandler(restErrorStats))
+	service.DELETE("app/errors/stats", api.IsAdminHandler(restErrorStatsReset))
+
+	return nil
+}
+
+// WEB REST API used to get errors occurred the most within time window
+//   - "window" is an amount of minutes errors counted within, 60 by default, a day at most
+//   - "limit" is a maximum amount of errors to return, 10 by default, 0 means no limit
+func restErrorStats(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	window := ConstStatsWindowDefault
+	if value := context.GetRequestArgument("window"); value != "" {
+		window = utils.InterfaceToInt(value)
+	}
+	if window <= 0 || window > ConstStatsHistory {
+		window = ConstStatsHistory
+	}
+
+	limit := ConstStatsLimitDefault
+	if value := context.GetRequestArgument("limit"); value != "" {
+		limit = utils.InterfaceToInt(value)
+	}
+
+	return GetStats(window, limit), nil
+}
+
+// WEB REST API used to drop collected errors statistics
+func restErrorStatsReset(context api.InterfaceApplicationContext) (interface{}, error) {
+	ResetStats()
+	return "ok", nil
+}
@@ -0,0 +1,149 @@
+package errorreport
+
+import (
+	"net/url"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupConfig setups package configuration values for a system
+func setupConfig() error {
+	config := env.GetConfig()
+	if config == nil {
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "5d6f1e0b-8c3a-4f27-9b14-e2a7c9d3f680", "can't obtain config")
+		return env.ErrorDispatch(err)
+	}
+
+	err := config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathReport,
+		Value:       nil,
+		Type:        env.ConstConfigTypeGroup,
+		Editor:      "",
+		Options:     nil,
+		Label:       "Report",
+		Description: "errors aggregation and alerting settings",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	positiveValidator := func(newValue interface{}) (interface{}, error) {
+		if utils.InterfaceToInt(newValue) < 0 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b1e4a7c2-3d90-4f5e-8a61-0c7d2f9b4e53", "value should not be negative")
+		}
+		return utils.InterfaceToInt(newValue), nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathReportThreshold,
+		Value:       0,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Alert threshold",
+		Description: "amount of same errors within alert window to send alert, 0 disables alerts",
+		Image:       "",
+	}, positiveValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	windowValidator := func(newValue interface{}) (interface{}, error) {
+		value := utils.InterfaceToInt(newValue)
+		if value < 1 || value > ConstStatsHistory {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7f2c9e14-5a6b-4d83-b0e1-9c4a8d2f6b17", "alert window should be from 1 to 1440 minutes")
+		}
+		return value, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathReportWindow,
+		Value:       5,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Alert window",
+		Description: "time window in minutes errors are counted within to compare with alert threshold",
+		Image:       "",
+	}, windowValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathReportCooldown,
+		Value:       60,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Alert cooldown",
+		Description: "minutes to wait before next alert about the same error",
+		Image:       "",
+	}, positiveValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathReportEmail,
+		Value:       "",
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "line_text",
+		Options:     nil,
+		Label:       "Alert email",
+		Description: "email address alerts are sent to, blank value disables email alerts",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	webhookValidator := func(newValue interface{}) (interface{}, error) {
+		value := utils.InterfaceToString(newValue)
+		if value != "" {
+			if parsedURL, err := url.Parse(value); err != nil || parsedURL.Host == "" {
+				return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e8a3b6d0-1c47-4f92-a5e8-3b0d7c6f2a91", "alert webhook should be a valid URL")
+			}
+		}
+		return value, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathReportWebhook,
+		Value:       "",
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "line_text",
+		Options:     nil,
+		Label:       "Alert webhook",
+		Description: "URL alerts are POSTed to as JSON, blank value disables webhook alerts",
+		Image:       "",
+	}, webhookValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathReportMaxEntries,
+		Value:       1000,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Max tracked errors",
+		Description: "amount of distinct errors kept in memory, least recently seen ones are dropped first",
+		Image:       "",
+	}, positiveValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Package errorreport implements errors aggregation, statistics and alerting module
+package errorreport
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstConfigPathReport           = "general.error.report"
+	ConstConfigPathReportThreshold  = "general.error.report.threshold"
+	ConstConfigPathReportWindow     = "general.error.report.window"
+	ConstConfigPathReportCooldown   = "general.error.report.cooldown"
+	ConstConfigPathReportEmail      = "general.error.report.email"
+	ConstConfigPathReportWebhook    = "general.error.report.webhook"
+	ConstConfigPathReportMaxEntries = "general.error.report.max_entries"
+
+	ConstStatsWindowDefault = 60   // minutes stats endpoint counts errors within by default
+	ConstStatsLimitDefault  = 10   // amount of top errors stats endpoint returns by default
+	ConstStatsHistory       = 1440 // minutes occurrences are kept for, so the widest window is a day
+
+	ConstCallStackFrames = 5 // amount of call stack lines shown within stats and alerts
+
+	ConstLogStorage = "errorreport.log"
+
+	ConstErrorModule = "errorreport"
+	ConstErrorLevel  = env.ConstErrorLevelActor
+)
+
+// Package global variables
+var (
+	entries      = make(map[string]*StructErrorEntry)
+	entriesMutex sync.Mutex
+)
+
+// StructErrorEntry holds aggregated information about errors having same fingerprint
+//   - occurrences are counted within per minute buckets, keyed by unix time minute
+type StructErrorEntry struct {
+	Fingerprint string
+	Code        string
+	Module      string
+	Level       int
+	Message     string
+	CallStack   string
+
+	Total     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+	LastAlert time.Time
+
+	buckets map[int64]int
+}
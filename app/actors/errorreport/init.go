@@ -0,0 +1,28 @@
+package errorreport
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+)
+
+// init makes package self-initialization routine
+func init() {
+	env.RegisterOnConfigIniStart(setupListener)
+	env.RegisterOnConfigStart(setupConfig)
+	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "errorreport",
+		Description: "Errors aggregation and alerting",
+		Routes:      []string{"app/errors/stats"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// setupListener subscribes to error bus, as it is not yet registered at package init time
+func setupListener() error {
+	env.ErrorRegisterListener(errorListener)
+	return nil
+}
@@ -0,0 +1,194 @@
+package errorreport
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// makeFingerprint returns identifier of error kind, errors with same code raised at same place are the same kind
+func makeFingerprint(code string, callStack string) string {
+	hasher := md5.New()
+	hasher.Write([]byte(code + "\n" + callStack))
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// shortCallStack returns top lines of call stack, which are enough to locate error
+func shortCallStack(callStack string) string {
+	lines := strings.Split(strings.TrimSpace(callStack), "\n")
+	if len(lines) > ConstCallStackFrames {
+		lines = lines[:ConstCallStackFrames]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// timeMinute returns bucket key for given time
+func timeMinute(value time.Time) int64 {
+	return value.Unix() / 60
+}
+
+// add registers error occurrence at given time and drops occurrences outside of kept history
+func (it *StructErrorEntry) add(now time.Time) {
+	minute := timeMinute(now)
+
+	it.buckets[minute]++
+	it.Total++
+	it.LastSeen = now
+
+	for key := range it.buckets {
+		if key <= minute-ConstStatsHistory {
+			delete(it.buckets, key)
+		}
+	}
+}
+
+// count returns amount of occurrences within given amount of minutes before now
+func (it *StructErrorEntry) count(now time.Time, window int) int {
+	minute := timeMinute(now)
+
+	result := 0
+	for key, value := range it.buckets {
+		if key > minute-int64(window) {
+			result += value
+		}
+	}
+	return result
+}
+
+// errorListener is an error bus listener which aggregates errors and triggers alerts
+//   - listener never stops error propagation to other listeners
+func errorListener(err error) bool {
+	ottemoErr, ok := err.(env.InterfaceOttemoError)
+	if !ok {
+		return false
+	}
+
+	fingerprint := makeFingerprint(ottemoErr.ErrorCode(), ottemoErr.ErrorCallStack())
+	now := time.Now()
+
+	entriesMutex.Lock()
+
+	entry, present := entries[fingerprint]
+	if !present {
+		evictEntries()
+
+		entry = &StructErrorEntry{
+			Fingerprint: fingerprint,
+			Code:        ottemoErr.ErrorCode(),
+			Module:      ottemoErr.ErrorModule(),
+			Level:       ottemoErr.ErrorLevel(),
+			Message:     ottemoErr.ErrorMessage(),
+			CallStack:   shortCallStack(ottemoErr.ErrorCallStack()),
+			FirstSeen:   now,
+			buckets:     make(map[int64]int),
+		}
+
+		entries[fingerprint] = entry
+	}
+	entry.add(now)
+
+	var alert map[string]interface{}
+	if threshold := utils.InterfaceToInt(env.ConfigGetValue(ConstConfigPathReportThreshold)); threshold > 0 {
+		window := utils.InterfaceToInt(env.ConfigGetValue(ConstConfigPathReportWindow))
+		cooldown := time.Duration(utils.InterfaceToInt(env.ConfigGetValue(ConstConfigPathReportCooldown))) * time.Minute
+
+		if count := entry.count(now, window); count >= threshold && now.Sub(entry.LastAlert) >= cooldown {
+			entry.LastAlert = now
+			alert = entry.toMap(now, window)
+		}
+	}
+
+	entriesMutex.Unlock()
+
+	// alert delivery could take a while, so it should not hold the routine error happened in
+	if alert != nil {
+		go sendAlert(alert)
+	}
+
+	return false
+}
+
+// evictEntries drops least recently seen entries to make place for a new one
+//   - entries mutex should be locked by caller
+func evictEntries() {
+	maxEntries := utils.InterfaceToInt(env.ConfigGetValue(ConstConfigPathReportMaxEntries))
+	if maxEntries <= 0 {
+		return
+	}
+
+	for len(entries) >= maxEntries {
+		var oldest *StructErrorEntry
+		for _, entry := range entries {
+			if oldest == nil || entry.LastSeen.Before(oldest.LastSeen) {
+				oldest = entry
+			}
+		}
+		delete(entries, oldest.Fingerprint)
+	}
+}
+
+// toMap returns entry information along with amount of occurrences within given window
+//   - entries mutex should be locked by caller
+func (it *StructErrorEntry) toMap(now time.Time, window int) map[string]interface{} {
+	result := map[string]interface{}{
+		"fingerprint": it.Fingerprint,
+		"code":        it.Code,
+		"name":        "",
+		"module":      it.Module,
+		"level":       it.Level,
+		"message":     it.Message,
+		"call_stack":  it.CallStack,
+		"count":       it.count(now, window),
+		"window":      window,
+		"total":       it.Total,
+		"first_seen":  it.FirstSeen,
+		"last_seen":   it.LastSeen,
+	}
+
+	if catalogItem, present := env.ErrorGetCatalogItem(it.Code); present {
+		result["name"] = catalogItem.Name
+	}
+
+	return result
+}
+
+// GetStats returns errors occurred the most within given amount of minutes, limit 0 means no limit
+func GetStats(window int, limit int) []map[string]interface{} {
+	now := time.Now()
+	result := make([]map[string]interface{}, 0)
+
+	entriesMutex.Lock()
+	for _, entry := range entries {
+		if item := entry.toMap(now, window); utils.InterfaceToInt(item["count"]) > 0 {
+			result = append(result, item)
+		}
+	}
+	entriesMutex.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		countI := utils.InterfaceToInt(result[i]["count"])
+		countJ := utils.InterfaceToInt(result[j]["count"])
+		if countI == countJ {
+			return utils.InterfaceToTime(result[i]["last_seen"]).After(utils.InterfaceToTime(result[j]["last_seen"]))
+		}
+		return countI > countJ
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+// ResetStats drops all collected errors information
+func ResetStats() {
+	entriesMutex.Lock()
+	entries = make(map[string]*StructErrorEntry)
+	entriesMutex.Unlock()
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/order"
+	"github.com/ottemo/foundation/app/models/store"
 	"github.com/ottemo/foundation/app/models/visitor"
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
@@ -101,6 +102,11 @@ func APIListOrders(context api.InterfaceApplicationContext) (interface{}, error)
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "feb1e3b3-fb65-4e77-9524-d64f4cf574e8", err.Error())
 	}
 
+	// limiting orders to store admin works with
+	if err := store.ApplyStoreFilter(context, orderCollectionModel.GetDBCollection()); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a4c7e2d9-51b8-4f36-9e0a-7d3b6f1c8e52", err.Error())
+	}
+
 	// checking for a "count" request
 	if context.GetRequestArgument(api.ConstRESTActionParameter) == "count" {
 		return orderCollectionModel.GetDBCollection().Count()
@@ -333,6 +339,9 @@ func APIExportOrders(context api.InterfaceApplicationContext) (interface{}, erro
 	if err := dbOrderCollection.AddSort("created_at", false); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d237815b-a87b-4c2a-a4e0-fa28e1ed6459", err.Error())
 	}
+	if err := store.ApplyStoreFilter(context, dbOrderCollection); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e81b5d3f-2c96-4a07-b4e8-3f9d1a6c7b25", err.Error())
+	}
 
 	// load orders based on order IDs passed
 	orders = utils.InterfaceToStringArray(utils.InterfaceToArray(requestData["orders"]))
@@ -113,6 +113,8 @@ const (
 
 	ConstConfigPathLastIncrementID = "internal.order.increment_id"
 
+	ConstMigrationBatchSize = 100 // records loaded at once by money migration command
+
 	ConstErrorModule = "order"
 	ConstErrorLevel  = env.ConstErrorLevelActor
 )
@@ -149,6 +151,7 @@ type DefaultOrder struct {
 	SessionID string
 	VisitorID string
 	CartID    string
+	StoreID   string
 
 	Description  string
 	PaymentInfo  map[string]interface{}
@@ -170,11 +173,17 @@ type DefaultOrder struct {
 	ShippingAmount float64
 	GrandTotal     float64
 
+	Currency     string
+	CurrencyRate float64
+
 	Taxes     []order.StructTaxRate
 	Discounts []order.StructDiscount
 
 	Notes []string
 
+	// items shipped to different addresses, empty if order is shipped to one address
+	Shipments []order.StructShipment
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
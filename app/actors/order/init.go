@@ -4,9 +4,11 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/order"
+	"github.com/ottemo/foundation/cli"
 	"github.com/ottemo/foundation/db"
 
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init makes package self-initialization routine
@@ -34,6 +36,31 @@ func init() {
 	env.RegisterOnConfigStart(setupConfig)
 
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := cli.RegisterCommand(cli.StructCommand{
+		Name:        "order migrate-money",
+		Usage:       "[-test]",
+		Description: "rounds amounts stored in orders to money precision",
+		Handler:     commandMigrateMoney,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+	if err := cli.RegisterMigration(cli.StructCommand{
+		Name:        "order money",
+		Description: "rounds amounts stored in orders to money precision",
+		Handler:     commandMigrateMoney,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "order",
+		Description:  "Purchase orders",
+		Dependencies: []string{"visitor"},
+		Core:         true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -61,6 +88,9 @@ func setupDB() error {
 		if err := collection.AddColumn("cart_id", db.ConstTypeID, true); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5879e9f5-b367-4e52-9f3c-a73e4249f4e6", err.Error())
 		}
+		if err := collection.AddColumn("store_id", db.ConstTypeID, true); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e3a91f6c-4b27-4d58-8c0e-7f2d5a9b1c46", err.Error())
+		}
 
 		if err := collection.AddColumn("billing_address", db.ConstTypeJSON, true); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "cbd77fc8-e734-4739-86a1-e4c7a620a2de", err.Error())
@@ -98,6 +128,12 @@ func setupDB() error {
 		if err := collection.AddColumn("grand_total", db.ConstTypeMoney, false); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "628a5e5f-b8e4-4d1a-84a6-c4a1bda772e3", err.Error())
 		}
+		if err := collection.AddColumn("currency", db.ConstTypeVarchar, false); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3a7e1c9f-b642-4d85-9e0a-6c2f8d4b1e57", err.Error())
+		}
+		if err := collection.AddColumn("currency_rate", db.ConstTypeFloat, false); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c8f2a5d1-7e39-4b64-a0d7-2e9b6f1c4a83", err.Error())
+		}
 
 		if err := collection.AddColumn("discounts", db.TypeArrayOf(db.ConstTypeJSON), false); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "19937cab-5488-4eea-aed3-c64e19c481b8", err.Error())
@@ -130,6 +166,9 @@ func setupDB() error {
 		if err := collection.AddColumn("notes", db.TypeArrayOf(db.ConstTypeVarchar), false); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "f4484325-2b27-4551-bf8e-f58d6a0c6cd7", err.Error())
 		}
+		if err := collection.AddColumn("shipments", db.TypeArrayOf(db.ConstTypeJSON), false); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4d2b7e91-c3a8-4f05-9b6e-81f0a5d3c7e2", err.Error())
+		}
 
 		collection, err = dbEngine.GetCollection(ConstCollectionNameOrderItems)
 		if err != nil {
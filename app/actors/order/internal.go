@@ -3,6 +3,7 @@ package order
 import (
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
 	"strings"
@@ -140,3 +141,42 @@ func (it DefaultOrder) SendOrderConfirmationEmail() error {
 
 	return nil
 }
+
+// shipmentToHashMap represents order shipment as map[string]interface{} to store it
+func shipmentToHashMap(shipment order.StructShipment) map[string]interface{} {
+	return map[string]interface{}{
+		"items":           shipment.Items,
+		"address":         shipment.Address,
+		"shipping_method": shipment.ShippingMethod,
+		"shipping_name":   shipment.ShippingName,
+		"shipping_amount": shipment.ShippingAmount,
+		"tax_amount":      shipment.TaxAmount,
+		"grand_total":     shipment.GrandTotal,
+		"carrier":         shipment.Carrier,
+		"service":         shipment.Service,
+		"tracking_number": shipment.TrackingNumber,
+		"tracking_url":    shipment.TrackingURL,
+	}
+}
+
+// shipmentFromHashMap makes order shipment from stored map[string]interface{}
+func shipmentFromHashMap(input map[string]interface{}) order.StructShipment {
+	var items []int
+	for _, itemIdx := range utils.InterfaceToArray(input["items"]) {
+		items = append(items, utils.InterfaceToInt(itemIdx))
+	}
+
+	return order.StructShipment{
+		Items:          items,
+		Address:        utils.InterfaceToMap(input["address"]),
+		ShippingMethod: utils.InterfaceToString(input["shipping_method"]),
+		ShippingName:   utils.InterfaceToString(input["shipping_name"]),
+		ShippingAmount: utils.InterfaceToMoney(input["shipping_amount"]).Float64(),
+		TaxAmount:      utils.InterfaceToMoney(input["tax_amount"]).Float64(),
+		GrandTotal:     utils.InterfaceToMoney(input["grand_total"]).Float64(),
+		Carrier:        utils.InterfaceToString(input["carrier"]),
+		Service:        utils.InterfaceToString(input["service"]),
+		TrackingNumber: utils.InterfaceToString(input["tracking_number"]),
+		TrackingURL:    utils.InterfaceToString(input["tracking_url"]),
+	}
+}
@@ -91,7 +91,7 @@ func (it *DefaultOrderItem) Set(attribute string, value interface{}) error {
 		it.Options = utils.InterfaceToMap(value)
 
 	case "price":
-		it.Price = utils.InterfaceToFloat64(value)
+		it.Price = utils.InterfaceToMoney(value).Float64()
 
 	case "weight":
 		it.Weight = utils.InterfaceToFloat64(value)
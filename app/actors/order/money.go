@@ -0,0 +1,123 @@
+package order
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// commandMigrateMoney normalizes amounts stored in orders and order items to money precision, so records made with
+// float arithmetic have exact cent values
+//   - "-test" outputs number of records to be changed without saving them
+func commandMigrateMoney(args []string, output io.Writer) error {
+	flagSet := flag.NewFlagSet("order migrate-money", flag.ContinueOnError)
+	flagSet.SetOutput(os.Stderr)
+	testMode := flagSet.Bool("test", false, "output number of records to be changed without saving them")
+	if err := flagSet.Parse(args); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	roundingMode := checkout.GetRoundingMode()
+	for _, collectionName := range []string{ConstCollectionNameOrder, ConstCollectionNameOrderItems} {
+		changed, err := migrateMoneyColumns(collectionName, roundingMode, *testMode)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+		fmt.Fprintln(output, collectionName+":", changed, "records changed")
+	}
+
+	return nil
+}
+
+// migrateMoneyColumns rounds values of money type columns and checkout calculation details of collection records,
+// returns number of changed records
+func migrateMoneyColumns(collectionName string, roundingMode string, testMode bool) (int, error) {
+	collection, err := db.GetCollection(collectionName)
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	var moneyColumns []string
+	for column, columnType := range collection.ListColumns() {
+		if db.TypeParse(columnType).Name == db.ConstTypeMoney {
+			moneyColumns = append(moneyColumns, column)
+		}
+	}
+
+	if err := collection.AddSort("_id", false); err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	changed := 0
+	for offset := 0; ; offset += ConstMigrationBatchSize {
+		if err := collection.SetLimit(offset, ConstMigrationBatchSize); err != nil {
+			return changed, env.ErrorDispatch(err)
+		}
+
+		records, err := collection.Load()
+		if err != nil {
+			return changed, env.ErrorDispatch(err)
+		}
+		if len(records) == 0 {
+			break
+		}
+
+		for _, record := range records {
+			recordChanged := false
+			for _, column := range moneyColumns {
+				if value, present := record[column]; present && roundMoneyValue(record, column, value, roundingMode) {
+					recordChanged = true
+				}
+			}
+
+			// checkout calculation details are kept within order custom info
+			if customInfo, ok := record["custom_info"].(map[string]interface{}); ok {
+				calculation, _ := customInfo["calculation"].(map[string]interface{})
+				for _, details := range calculation {
+					if amounts, ok := details.(map[string]interface{}); ok {
+						for label, value := range amounts {
+							if roundMoneyValue(amounts, label, value, roundingMode) {
+								recordChanged = true
+							}
+						}
+					}
+				}
+			}
+
+			if !recordChanged {
+				continue
+			}
+			changed++
+
+			if !testMode {
+				saveCollection, err := db.GetCollection(collectionName)
+				if err != nil {
+					return changed, env.ErrorDispatch(err)
+				}
+				if _, err := saveCollection.Save(record); err != nil {
+					return changed, env.ErrorDispatch(err)
+				}
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// roundMoneyValue rounds map value to money precision, returns true if value was changed
+func roundMoneyValue(values map[string]interface{}, key string, value interface{}, roundingMode string) bool {
+	floatValue := utils.InterfaceToFloat64(value)
+	roundedValue := utils.MoneyFromFloat(floatValue).Round(roundingMode).Float64()
+	if roundedValue == floatValue {
+		return false
+	}
+
+	values[key] = roundedValue
+	return true
+}
@@ -32,6 +32,9 @@ func (it *DefaultOrder) Get(attribute string) interface{} {
 	case "cart_id":
 		return it.CartID
 
+	case "store_id":
+		return it.StoreID
+
 	case "shipping_address":
 		return it.ShippingAddress
 
@@ -65,6 +68,12 @@ func (it *DefaultOrder) Get(attribute string) interface{} {
 	case "grand_total":
 		return it.GrandTotal
 
+	case "currency":
+		return it.Currency
+
+	case "currency_rate":
+		return it.CurrencyRate
+
 	case "taxes":
 		return it.Taxes
 
@@ -92,6 +101,9 @@ func (it *DefaultOrder) Get(attribute string) interface{} {
 	case "notes":
 		return it.Notes
 
+	case "shipments":
+		return it.Shipments
+
 	}
 
 	return nil
@@ -140,6 +152,9 @@ func (it *DefaultOrder) Set(attribute string, value interface{}) error {
 	case "cart_id":
 		it.CartID = utils.InterfaceToString(value)
 
+	case "store_id":
+		it.StoreID = utils.InterfaceToString(value)
+
 	case "customer_email":
 		it.CustomerEmail = utils.InterfaceToString(value)
 
@@ -159,19 +174,25 @@ func (it *DefaultOrder) Set(attribute string, value interface{}) error {
 		it.ShippingMethod = utils.InterfaceToString(value)
 
 	case "subtotal":
-		it.Subtotal = utils.InterfaceToFloat64(value)
+		it.Subtotal = utils.InterfaceToMoney(value).Float64()
 
 	case "discount":
-		it.Discount = utils.InterfaceToFloat64(value)
+		it.Discount = utils.InterfaceToMoney(value).Float64()
 
 	case "tax_amount":
-		it.TaxAmount = utils.InterfaceToFloat64(value)
+		it.TaxAmount = utils.InterfaceToMoney(value).Float64()
 
 	case "shipping_amount":
-		it.ShippingAmount = utils.InterfaceToFloat64(value)
+		it.ShippingAmount = utils.InterfaceToMoney(value).Float64()
 
 	case "grand_total":
-		it.GrandTotal = utils.InterfaceToFloat64(value)
+		it.GrandTotal = utils.InterfaceToMoney(value).Float64()
+
+	case "currency":
+		it.Currency = utils.InterfaceToString(value)
+
+	case "currency_rate":
+		it.CurrencyRate = utils.InterfaceToFloat64(value)
 
 	case "taxes":
 		it.Taxes = make([]order.StructTaxRate, 0)
@@ -280,6 +301,18 @@ func (it *DefaultOrder) Set(attribute string, value interface{}) error {
 	case "notes":
 		it.Notes = utils.InterfaceToStringArray(value)
 
+	case "shipments":
+		it.Shipments = make([]order.StructShipment, 0)
+
+		switch typedValue := value.(type) {
+		case []order.StructShipment:
+			it.Shipments = append(it.Shipments, typedValue...)
+		default:
+			for _, arrayItem := range utils.InterfaceToArray(value) {
+				it.Shipments = append(it.Shipments, shipmentFromHashMap(utils.InterfaceToMap(arrayItem)))
+			}
+		}
+
 	case "note":
 		if stringValue := utils.InterfaceToString(value); value != "" {
 			if attributeIdx != "" {
@@ -324,6 +357,7 @@ func (it *DefaultOrder) ToHashMap() map[string]interface{} {
 	result["visitor_id"] = it.Get("visitor_id")
 	result["session_id"] = it.Get("session_id")
 	result["cart_id"] = it.Get("cart_id")
+	result["store_id"] = it.Get("store_id")
 
 	result["customer_email"] = it.Get("customer_email")
 	result["customer_name"] = it.Get("customer_name")
@@ -340,6 +374,9 @@ func (it *DefaultOrder) ToHashMap() map[string]interface{} {
 	result["shipping_amount"] = it.Get("shipping_amount")
 	result["grand_total"] = it.Get("grand_total")
 
+	result["currency"] = it.Get("currency")
+	result["currency_rate"] = it.Get("currency_rate")
+
 	result["taxes"] = it.Get("taxes")
 	result["discounts"] = it.Get("discounts")
 
@@ -353,6 +390,12 @@ func (it *DefaultOrder) ToHashMap() map[string]interface{} {
 
 	result["notes"] = it.Get("notes")
 
+	shipments := make([]map[string]interface{}, 0, len(it.Shipments))
+	for _, shipment := range it.Shipments {
+		shipments = append(shipments, shipmentToHashMap(shipment))
+	}
+	result["shipments"] = shipments
+
 	return result
 }
 
@@ -432,6 +475,19 @@ func (it *DefaultOrder) GetAttributesInfo() []models.StructAttributeInfo {
 			Options:    "",
 			Default:    "",
 		},
+		models.StructAttributeInfo{
+			Model:      order.ConstModelNameOrder,
+			Collection: ConstCollectionNameOrder,
+			Attribute:  "store_id",
+			Type:       db.ConstTypeID,
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Store",
+			Group:      "General",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
 		models.StructAttributeInfo{
 			Model:      order.ConstModelNameOrder,
 			Collection: ConstCollectionNameOrder,
@@ -581,6 +637,32 @@ func (it *DefaultOrder) GetAttributesInfo() []models.StructAttributeInfo {
 			Default:    "",
 			Validators: "numeric positive",
 		},
+		models.StructAttributeInfo{
+			Model:      order.ConstModelNameOrder,
+			Collection: ConstCollectionNameOrder,
+			Attribute:  "currency",
+			Type:       db.ConstTypeVarchar,
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Currency",
+			Group:      "Totals",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
+		models.StructAttributeInfo{
+			Model:      order.ConstModelNameOrder,
+			Collection: ConstCollectionNameOrder,
+			Attribute:  "currency_rate",
+			Type:       db.ConstTypeFloat,
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Currency Rate",
+			Group:      "Totals",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
 		models.StructAttributeInfo{
 			Model:      order.ConstModelNameOrder,
 			Collection: ConstCollectionNameOrder,
@@ -659,6 +741,19 @@ func (it *DefaultOrder) GetAttributesInfo() []models.StructAttributeInfo {
 			Options:    "",
 			Default:    "",
 		},
+		models.StructAttributeInfo{
+			Model:      order.ConstModelNameOrder,
+			Collection: ConstCollectionNameOrder,
+			Attribute:  "shipments",
+			Type:       db.TypeArrayOf(db.ConstTypeJSON),
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Shipments",
+			Group:      "General",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
 	}
 
 	return info
@@ -167,18 +167,31 @@ func (it *DefaultOrder) SetIncrementID(incrementID string) error {
 // CalculateTotals recalculates order Subtotal and GrandTotal
 func (it *DefaultOrder) CalculateTotals() error {
 
-	it.GrandTotal = utils.RoundPrice(it.GetSubtotal() + it.GetShippingAmount() + it.GetTaxAmount() + it.GetDiscountAmount())
+	grandTotal := utils.MoneyFromFloat(it.GetSubtotal()) +
+		utils.MoneyFromFloat(it.GetShippingAmount()) +
+		utils.MoneyFromFloat(it.GetTaxAmount()) +
+		utils.MoneyFromFloat(it.GetDiscountAmount())
+
+	it.GrandTotal = grandTotal.Round(checkout.GetRoundingMode()).Float64()
 
 	return nil
 }
 
 // GetSubtotal returns subtotal of order
+//   - item amounts are rounded before summing up for per line rounding level
 func (it *DefaultOrder) GetSubtotal() float64 {
-	var subtotal float64
+	roundingMode := checkout.GetRoundingMode()
+	perLineRounding := checkout.IsPerLineRounding()
+
+	var subtotal utils.Money
 	for _, orderItem := range it.Items {
-		subtotal += utils.RoundPrice(orderItem.GetPrice() * float64(orderItem.GetQty()))
+		itemAmount := utils.MoneyFromFloat(orderItem.GetPrice()).Mul(orderItem.GetQty())
+		if perLineRounding {
+			itemAmount = itemAmount.Round(roundingMode)
+		}
+		subtotal += itemAmount
 	}
-	it.Subtotal = utils.RoundPrice(subtotal)
+	it.Subtotal = subtotal.Round(roundingMode).Float64()
 
 	return it.Subtotal
 }
@@ -218,6 +231,11 @@ func (it *DefaultOrder) GetShippingMethod() string {
 	return it.ShippingMethod
 }
 
+// GetShipments returns groups of items shipped to different addresses
+func (it *DefaultOrder) GetShipments() []order.StructShipment {
+	return it.Shipments
+}
+
 // GetPaymentMethod returns payment method used for order
 func (it *DefaultOrder) GetPaymentMethod() string {
 	return it.PaymentMethod
@@ -280,7 +298,7 @@ func (it *DefaultOrder) SetStatus(newStatus string) error {
 	return env.ErrorDispatch(err)
 }
 
-// Proceed subtracts order items from stock, changes status to new if status was not set yet, saves order
+// Proceed subtracts order items from stock releasing qty reserved for order cart, changes status to new if status was not set yet, saves order
 func (it *DefaultOrder) Proceed() error {
 
 	if it.Status == "" {
@@ -308,6 +326,11 @@ func (it *DefaultOrder) Proceed() error {
 			}
 
 		}
+
+		// stock was decremented, so qty reserved by order cart is not needed anymore
+		if err := stockManager.ReleaseReservations(it.CartID); err != nil {
+			return env.ErrorDispatch(err)
+		}
 	}
 
 	// checking order's incrementID, if not set - assigning new one
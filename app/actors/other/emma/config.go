@@ -60,8 +60,8 @@ func setupConfig() error {
 	err = config.RegisterItem(env.StructConfigItem{
 		Path:        ConstConfigPathEmmaPrivateAPIKey,
 		Value:       "",
-		Type:        env.ConstConfigTypeVarchar,
-		Editor:      "line_text",
+		Type:        env.ConstConfigTypeSecret,
+		Editor:      "password",
 		Options:     nil,
 		Label:       "Emma Private API Key",
 		Description: "Enter your Emma Private API Key",
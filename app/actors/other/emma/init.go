@@ -4,16 +4,27 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 func init() {
 	app.OnAppStart(appStart)
 	env.RegisterOnConfigStart(setupConfig)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "emma",
+		Description:       "Emma integration",
+		ConfigGroup:       ConstConfigPathEmma,
+		EnabledConfigPath: ConstConfigPathEmmaEnabled,
+		Routes:            []string{"emma/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func appStart() error {
-	env.EventRegisterListener("checkout.success", checkoutSuccessHandler)
+	extension.EventRegisterListener("emma", "checkout.success", checkoutSuccessHandler)
 
 	emmaService = *newEmmaService()
 
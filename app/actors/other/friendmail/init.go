@@ -5,6 +5,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"time"
 )
 
@@ -39,6 +40,15 @@ func init() {
 			}
 		}()
 	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "friendmail",
+		Description: "Email a friend",
+		ConfigGroup: ConstConfigPathFriendMail,
+		Routes:      []string{"friend/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -3,6 +3,7 @@ package grouping
 import (
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -10,6 +11,14 @@ import (
 func init() {
 	app.OnAppStart(onAppStart)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "grouping",
+		Description:  "Products grouping rules",
+		Dependencies: []string{"cart"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // onAppStart makes module initialization on application startup
@@ -21,7 +30,7 @@ func onAppStart() error {
 	}
 	currentRules = rules
 
-	env.EventRegisterListener("api.cart.update", updateCartHandler)
+	extension.EventRegisterListener("grouping", "api.cart.update", updateCartHandler)
 
 	return nil
 }
@@ -45,8 +45,8 @@ func setupConfig() error {
 	err = config.RegisterItem(env.StructConfigItem{
 		Path:        ConstConfigPathMailchimpAPIKey,
 		Value:       "",
-		Type:        env.ConstConfigTypeVarchar,
-		Editor:      "line_text",
+		Type:        env.ConstConfigTypeSecret,
+		Editor:      "password",
 		Options:     nil,
 		Label:       "MailChimp API Key",
 		Description: "Enter your MailChimp API Key",
@@ -3,15 +3,25 @@ package mailchimp
 import (
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 func init() {
 	app.OnAppStart(appStart)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "mailchimp",
+		Description:       "MailChimp integration",
+		ConfigGroup:       ConstConfigPathMailchimp,
+		EnabledConfigPath: ConstConfigPathMailchimpEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func appStart() error {
-	env.EventRegisterListener("checkout.success", checkoutSuccessHandler)
+	extension.EventRegisterListener("mailchimp", "checkout.success", checkoutSuccessHandler)
 
 	return nil
 }
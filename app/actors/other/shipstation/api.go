@@ -11,6 +11,7 @@ import (
 
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/app/models/order"
+	"github.com/ottemo/foundation/app/models/visitor"
 )
 
 func setupAPI() error {
@@ -115,8 +116,18 @@ func listOrders(context api.InterfaceApplicationContext) (interface{}, error) {
 	// Assemble our response
 	response := &Orders{}
 	for _, orderResult := range oResults {
-		responseOrder := buildItem(orderResult, oiResults)
-		response.Orders = append(response.Orders, responseOrder)
+		shipments := orderResult.GetShipments()
+		if len(shipments) == 0 {
+			responseOrder := buildItem(orderResult, oiResults)
+			response.Orders = append(response.Orders, responseOrder)
+			continue
+		}
+
+		// order shipped to several addresses is exported as an order per shipment
+		for shipmentIdx, shipment := range shipments {
+			responseOrder := buildShipmentItem(orderResult, shipmentIdx, shipment, oiResults)
+			response.Orders = append(response.Orders, responseOrder)
+		}
 	}
 
 	return response, nil
@@ -124,23 +135,62 @@ func listOrders(context api.InterfaceApplicationContext) (interface{}, error) {
 
 // Convert an ottemo order and all possible orderitems into a shipstation order
 func buildItem(oItem order.InterfaceOrder, allOrderItems []map[string]interface{}) Order {
+	orderDetails := newOrderDetails(oItem, oItem.GetID())
+	orderDetails.TaxAmount = oItem.GetTaxAmount()
+	orderDetails.ShippingAmount = oItem.GetShippingAmount()
+	orderDetails.OrderTotal = oItem.GetGrandTotal()
+	orderDetails.Customer.ShippingAddress = buildShippingAddress(oItem.GetShippingAddress())
+
+	return addOrderItems(orderDetails, oItem, allOrderItems)
+}
+
+// buildShipmentItem converts a shipment of an ottemo order into a shipstation order
+//   - shipstation order number is made of order id and shipment number
+func buildShipmentItem(oItem order.InterfaceOrder, shipmentIdx int, shipment order.StructShipment, allOrderItems []map[string]interface{}) Order {
+	orderNumber := oItem.GetID() + ConstShipmentNumberSeparator + utils.InterfaceToString(shipmentIdx+1)
+
+	orderDetails := newOrderDetails(oItem, orderNumber)
+	orderDetails.TaxAmount = shipment.TaxAmount
+	orderDetails.ShippingAmount = shipment.ShippingAmount
+	orderDetails.OrderTotal = shipment.GrandTotal
+
+	if shippingAddress, err := visitor.GetVisitorAddressModel(); err == nil {
+		if err := shippingAddress.FromHashMap(shipment.Address); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "2e6b9f41-d873-4c05-a1e7-5c3d08f9b2a6", err.Error())
+		}
+		orderDetails.Customer.ShippingAddress = buildShippingAddress(shippingAddress)
+	} else {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b71c4d08-6e2a-4f93-8d05-e9a3f6b2c147", err.Error())
+	}
+
+	var shipmentItems []map[string]interface{}
+	for _, oiItem := range allOrderItems {
+		oiItemIdx := utils.InterfaceToInt(oiItem["idx"])
+		for _, itemIdx := range shipment.Items {
+			if itemIdx == oiItemIdx {
+				shipmentItems = append(shipmentItems, oiItem)
+				break
+			}
+		}
+	}
+
+	return addOrderItems(orderDetails, oItem, shipmentItems)
+}
+
+// newOrderDetails makes shipstation order with base details and customer of an ottemo order
+func newOrderDetails(oItem order.InterfaceOrder, orderNumber string) Order {
 	const outputDateFormat = "01/02/2006 15:04"
 
 	// Base Order Details
 	createdAt := utils.InterfaceToTime(oItem.Get("created_at"))
 	updatedAt := utils.InterfaceToTime(oItem.Get("updated_at"))
-	var customInfo = utils.InterfaceToMap(oItem.Get("custom_info"))
-	var calculation = utils.InterfaceToMap(customInfo["calculation"])
 
 	orderDetails := Order{
-		OrderId:        oItem.GetID(),
-		OrderNumber:    oItem.GetID(),
-		OrderDate:      createdAt.Format(outputDateFormat),
-		OrderStatus:    oItem.GetStatus(),
-		LastModified:   updatedAt.Format(outputDateFormat),
-		TaxAmount:      oItem.GetTaxAmount(),
-		ShippingAmount: oItem.GetShippingAmount(),
-		OrderTotal:     oItem.GetGrandTotal(),
+		OrderId:      orderNumber,
+		OrderNumber:  orderNumber,
+		OrderDate:    createdAt.Format(outputDateFormat),
+		OrderStatus:  oItem.GetStatus(),
+		LastModified: updatedAt.Format(outputDateFormat),
 	}
 
 	// Customer Details
@@ -151,8 +201,12 @@ func buildItem(oItem order.InterfaceOrder, allOrderItems []map[string]interface{
 		Name: oBillAddress.GetFirstName() + " " + oBillAddress.GetLastName(),
 	}
 
-	oShipAddress := oItem.GetShippingAddress()
-	orderDetails.Customer.ShippingAddress = ShippingAddress{
+	return orderDetails
+}
+
+// buildShippingAddress converts an ottemo address into a shipstation shipping address
+func buildShippingAddress(oShipAddress visitor.InterfaceVisitorAddress) ShippingAddress {
+	return ShippingAddress{
 		Name:       oShipAddress.GetFirstName() + " " + oShipAddress.GetLastName(),
 		Address1:   oShipAddress.GetAddressLine1(),
 		City:       oShipAddress.GetCity(),
@@ -160,6 +214,13 @@ func buildItem(oItem order.InterfaceOrder, allOrderItems []map[string]interface{
 		PostalCode: oShipAddress.GetZipCode(),
 		Country:    oShipAddress.GetCountry(),
 	}
+}
+
+// addOrderItems adds items of an ottemo order to shipstation order, differences of item and order totals are added
+// as adjustment items
+func addOrderItems(orderDetails Order, oItem order.InterfaceOrder, allOrderItems []map[string]interface{}) Order {
+	var customInfo = utils.InterfaceToMap(oItem.Get("custom_info"))
+	var calculation = utils.InterfaceToMap(customInfo["calculation"])
 
 	var calculatedDiscounts float64
 	var calculatedSubtotal float64
@@ -207,7 +268,7 @@ func buildItem(oItem order.InterfaceOrder, allOrderItems []map[string]interface{
 	// apply whole order discount
 	if calculation != nil {
 		var calculatedGrandTotal = calculatedSubtotal + calculatedDiscounts + orderDetails.ShippingAmount + orderDetails.TaxAmount
-		var orderDiscount = oItem.GetGrandTotal() - calculatedGrandTotal
+		var orderDiscount = orderDetails.OrderTotal - calculatedGrandTotal
 
 		if utils.RoundPrice(orderDiscount) != 0 {
 			orderItem := OrderItem{
@@ -228,7 +289,8 @@ func buildItem(oItem order.InterfaceOrder, allOrderItems []map[string]interface{
 // and then send off an email update
 //
 // - action :			The value will always be "shipnotify" when sending shipping notifications.
-// - order_number :		This is the order's unique identifier.
+// - order_number :		This is the order's unique identifier, order id followed by shipment number for orders
+// 						shipped to several addresses.
 // - carrier :			USPS, UPS, FedEx, DHL, Other, DHLGlobalMail, UPSMI, BrokersWorldWide, FedExInternationalMailService,
 // 						CanadaPost, FedExCanada, OnTrac, Newgistics, FirstMile, Globegistics, LoneStar, Asendia,
 // 						RoyalMail, APC, AccessWorldwide, AustraliaPost, DHLCanada, IMEX
@@ -248,10 +310,41 @@ func updateShipmentStatus(context api.InterfaceApplicationContext) (interface{},
 	service := context.GetRequestArgument("service")
 	trackingNumber := context.GetRequestArgument("tracking_number")
 
+	shipmentIdx := -1
 	orderModel, orderNotFound := order.LoadOrderByID(orderID)
 	if orderNotFound != nil {
-		context.SetResponseStatusBadRequest()
-		return nil, nil
+		separatorIdx := strings.LastIndex(orderID, ConstShipmentNumberSeparator)
+		if separatorIdx <= 0 {
+			context.SetResponseStatusBadRequest()
+			return nil, nil
+		}
+
+		shipmentIdx = utils.InterfaceToInt(orderID[separatorIdx+1:]) - 1
+		orderModel, orderNotFound = order.LoadOrderByID(orderID[:separatorIdx])
+		if orderNotFound != nil || shipmentIdx < 0 || shipmentIdx >= len(orderModel.GetShipments()) {
+			context.SetResponseStatusBadRequest()
+			return nil, nil
+		}
+	}
+
+	// order is completed when all its shipments are shipped
+	isShipped := true
+	if shipmentIdx >= 0 {
+		shipments := orderModel.GetShipments()
+		shipments[shipmentIdx].Carrier = carrier
+		shipments[shipmentIdx].Service = service
+		shipments[shipmentIdx].TrackingNumber = trackingNumber
+		shipments[shipmentIdx].TrackingURL = buildTrackingUrl(carrier, trackingNumber)
+
+		for _, shipment := range shipments {
+			if shipment.TrackingNumber == "" {
+				isShipped = false
+			}
+		}
+
+		if err := orderModel.Set("shipments", shipments); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5d8e2f71-0c4a-4b96-a3e7-f19b6c2d084e", err.Error())
+		}
 	}
 
 	shippingInfo := utils.InterfaceToMap(orderModel.Get("shipping_info"))
@@ -266,8 +359,10 @@ func updateShipmentStatus(context api.InterfaceApplicationContext) (interface{},
 	if err := orderModel.Set("updated_at", time.Now()); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "0467a420-f40d-499f-a215-d1ad922b5a89", err.Error())
 	}
-	if err := orderModel.SetStatus(order.ConstOrderStatusCompleted); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9e87ea89-754c-46b5-916c-9cbf20dfbfe0", err.Error())
+	if isShipped {
+		if err := orderModel.SetStatus(order.ConstOrderStatusCompleted); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9e87ea89-754c-46b5-916c-9cbf20dfbfe0", err.Error())
+		}
 	}
 	err := orderModel.Save()
 
@@ -5,6 +5,8 @@ import "github.com/ottemo/foundation/env"
 const (
 	ConstErrorModule = "shipstation"
 	ConstErrorLevel  = env.ConstErrorLevel
+
+	ConstShipmentNumberSeparator = "-" // separates order id and shipment number in order number of a shipment
 )
 
 // struct goes here
@@ -3,9 +3,19 @@ package shipstation
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 func init() {
 	env.RegisterOnConfigStart(setupConfig)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "shipstation",
+		Description:  "ShipStation integration",
+		Dependencies: []string{"order"},
+		Routes:       []string{"shipstation"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
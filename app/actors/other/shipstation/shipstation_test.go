@@ -5,6 +5,7 @@ import (
 
 	"github.com/ottemo/foundation/app/actors/order"
 	_ "github.com/ottemo/foundation/app/actors/visitor" // required to initialize Visitor Address Model
+	modelOrder "github.com/ottemo/foundation/app/models/order"
 )
 
 // TestBuildItemReturnsNoAdjustments tests buildItem function.
@@ -81,3 +82,45 @@ func TestBuildItemReturnsNoAdjustments(t *testing.T) {
 		}
 	}
 }
+
+// TestBuildShipmentItemTakesShipmentItemsAndTotals tests buildShipmentItem function.
+// Shipment order should have shipment number, items and totals of a shipment only.
+func TestBuildShipmentItemTakesShipmentItemsAndTotals(t *testing.T) {
+	const orderID = "5846cbbf7720ae713751e356"
+
+	var orderObj = &order.DefaultOrder{}
+	if err := orderObj.SetID(orderID); err != nil {
+		t.Error(err)
+	}
+	orderObj.GrandTotal = 70
+	orderObj.CustomInfo = map[string]interface{}{
+		"calculation": map[string]interface{}{
+			"1": map[string]interface{}{"GT": 20, "ST": 20},
+			"2": map[string]interface{}{"GT": 40, "ST": 40},
+		},
+	}
+
+	var allOrderItems = []map[string]interface{}{
+		{"idx": 1, "order_id": orderID, "price": 20, "qty": 1, "sku": "sku-01", "name": "name-01"},
+		{"idx": 2, "order_id": orderID, "price": 20, "qty": 2, "sku": "sku-02", "name": "name-02"},
+	}
+
+	var shipment = modelOrder.StructShipment{
+		Items:          []int{2},
+		Address:        map[string]interface{}{"first_name": "John", "last_name": "Doe", "city": "Dallas"},
+		ShippingAmount: 5,
+		GrandTotal:     45,
+	}
+
+	var builtOrder = buildShipmentItem(orderObj, 1, shipment, allOrderItems)
+
+	if builtOrder.OrderNumber != orderID+ConstShipmentNumberSeparator+"2" {
+		t.Error("Unexpected shipment order number:", builtOrder.OrderNumber)
+	}
+	if builtOrder.Customer.ShippingAddress.City != "Dallas" {
+		t.Error("Unexpected shipment city:", builtOrder.Customer.ShippingAddress.City)
+	}
+	if len(builtOrder.Items) != 1 || builtOrder.Items[0].Sku != "sku-02" {
+		t.Error("Shipment order should contain shipment items only:", builtOrder.Items)
+	}
+}
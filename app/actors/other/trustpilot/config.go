@@ -108,8 +108,8 @@ func setupConfig() error {
 	err = config.RegisterItem(env.StructConfigItem{
 		Path:        ConstConfigPathTrustPilotAPISecret,
 		Value:       "",
-		Type:        env.ConstConfigTypeVarchar,
-		Editor:      "line_text",
+		Type:        env.ConstConfigTypeSecret,
+		Editor:      "password",
 		Options:     "",
 		Label:       "API Secret",
 		Description: "",
@@ -7,6 +7,7 @@ import (
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -15,6 +16,16 @@ func init() {
 	app.OnAppStart(onAppStart)
 	env.RegisterOnConfigStart(setupConfig)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "trustpilot",
+		Description:       "TrustPilot integration",
+		ConfigGroup:       ConstConfigPathTrustPilot,
+		EnabledConfigPath: ConstConfigPathTrustPilotEnabled,
+		Routes:            []string{"trustpilot/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // Function for every day checking for email sent to customers who order is already two week
@@ -125,10 +136,10 @@ func schedulerFunc(params map[string]interface{}) error {
 // onAppStart makes module initialization on application startup
 func onAppStart() error {
 
-	env.EventRegisterListener("checkout.success", checkoutSuccessHandler)
+	extension.EventRegisterListener("trustpilot", "checkout.success", checkoutSuccessHandler)
 
 	if scheduler := env.GetScheduler(); scheduler != nil {
-		if err := scheduler.RegisterTask("trustPilotReview", schedulerFunc); err != nil {
+		if err := extension.RegisterTask("trustpilot", "trustPilotReview", schedulerFunc); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6bd3542b-7acf-4ee8-bd79-a15ddfe646f1", err.Error())
 		}
 		if _, err := scheduler.ScheduleRepeat("0 9 * * *", "trustPilotReview", nil); err != nil {
@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 func init() {
@@ -12,6 +13,15 @@ func init() {
 	db.RegisterOnDatabaseStart(onDatabaseStart)
 
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "vantagepoint",
+		Description:  "VantagePoint inventory uploads",
+		Dependencies: []string{"stock"},
+		ConfigGroup:  ConstConfigPathVantagePoint,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func onDatabaseStart() error {
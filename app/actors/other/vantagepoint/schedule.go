@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -34,7 +35,7 @@ func scheduleCheckNewUploads() error {
 		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "905f6a45-e733-42a9-8699-00a27762c044", "scheduler is not registered")
 	}
 
-	if err := scheduler.RegisterTask(ConstSchedulerTaskName, runCheckNewUploadsSchedule); err != nil {
+	if err := extension.RegisterTask("vantagepoint", ConstSchedulerTaskName, runCheckNewUploadsSchedule); err != nil {
 		return env.ErrorDispatch(err)
 	}
 
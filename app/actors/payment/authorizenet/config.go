@@ -99,8 +99,8 @@ func setupConfig() error {
 		err = config.RegisterItem(env.StructConfigItem{
 			Path:        ConstConfigPathDPMKey,
 			Value:       "",
-			Type:        env.ConstConfigTypeVarchar,
-			Editor:      "line_text",
+			Type:        env.ConstConfigTypeSecret,
+			Editor:      "password",
 			Options:     nil,
 			Label:       "Transaction Key",
 			Description: "account transaction key",
@@ -311,8 +311,8 @@ func setupConfig() error {
 		err = config.RegisterItem(env.StructConfigItem{
 			Path:        ConstConfigPathAuthorizeNetRestAPITransactionKey,
 			Value:       "",
-			Type:        env.ConstConfigTypeVarchar,
-			Editor:      "line_text",
+			Type:        env.ConstConfigTypeSecret,
+			Editor:      "password",
 			Options:     nil,
 			Label:       "Transaction Key",
 			Description: "",
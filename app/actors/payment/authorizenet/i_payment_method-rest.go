@@ -9,9 +9,11 @@ import (
 	"github.com/avator/authorizecim"
 
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 )
@@ -43,7 +45,9 @@ func (it *RestMethod) GetType() string {
 
 // IsAllowed checks for method applicability
 func (it *RestMethod) IsAllowed(checkoutInstance checkout.InterfaceCheckout) bool {
-	return utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathAuthorizeNetRestAPIEnabled))
+	// transactions are made in merchant account currency, which is considered to be a base one
+	return extension.IsEnabled("authorizenet") && utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathAuthorizeNetRestAPIEnabled)) &&
+		currency.GetSessionCurrency(checkoutInstance.GetSession()) == currency.GetBaseCurrency()
 }
 
 // Authorize makes payment method authorize operation
@@ -11,9 +11,11 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 )
@@ -45,7 +47,7 @@ func (it *DirectPostMethod) GetType() string {
 
 // IsAllowed checks for method applicability
 func (it *DirectPostMethod) IsAllowed(checkoutInstance checkout.InterfaceCheckout) bool {
-	return utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathDPMEnabled))
+	return extension.IsEnabled("authorizenet") && utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathDPMEnabled))
 }
 
 // Authorize makes payment method authorize operation
@@ -56,11 +58,12 @@ func (it *DirectPostMethod) Authorize(orderInstance order.InterfaceOrder, paymen
 	loginID := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathDPMLogin))
 	sequence := fmt.Sprintf("%d", rand.Intn(999)+1)
 	timeStamp := fmt.Sprintf("%d", time.Now().Unix())
-	amount := fmt.Sprintf("%.2f", orderInstance.GetGrandTotal())
+	currencyCode, grandTotal := currency.GetOrderAmount(orderInstance, orderInstance.GetGrandTotal())
+	amount := fmt.Sprintf("%.2f", grandTotal)
 	transactionKey := []byte(utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathDPMKey)))
 
 	hmacEncoder := hmac.New(md5.New, transactionKey)
-	if _, err := hmacEncoder.Write([]byte(loginID + "^" + sequence + "^" + timeStamp + "^" + amount + "^USD")); err != nil {
+	if _, err := hmacEncoder.Write([]byte(loginID + "^" + sequence + "^" + timeStamp + "^" + amount + "^" + currencyCode)); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1b24fa5b-aa72-4474-bc7c-dca378709ef8", err.Error())
 	}
 	fingerprint := hex.EncodeToString(hmacEncoder.Sum(nil))
@@ -85,7 +88,7 @@ func (it *DirectPostMethod) Authorize(orderInstance order.InterfaceOrder, paymen
 		"x_login":         loginID,
 		"x_type":          utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathDPMAction)),
 		"x_method":        "CC",
-		"x_currency_code": "USD",
+		"x_currency_code": currencyCode,
 
 		"x_first_name": billingAddress.GetFirstName(),
 		"x_last_name":  billingAddress.GetLastName(),
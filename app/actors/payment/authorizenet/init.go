@@ -3,6 +3,7 @@ package authorizenet
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -17,4 +18,13 @@ func init() {
 	}
 	api.RegisterOnRestServiceStart(setupAPI)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "authorizenet",
+		Description:  "Authorize.Net payment methods",
+		Dependencies: []string{"checkout"},
+		Routes:       []string{"authorizenet/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
@@ -70,8 +70,8 @@ func setupConfig() error {
 	err = config.RegisterItem(env.StructConfigItem{
 		Path:        ConstGeneralConfigPathPrivateKey,
 		Value:       "",
-		Type:        env.ConstConfigTypeVarchar,
-		Editor:      "line_text",
+		Type:        env.ConstConfigTypeSecret,
+		Editor:      "password",
 		Options:     nil,
 		Label:       "PRIVATE Key",
 		Description: "Environment PRIVATE key",
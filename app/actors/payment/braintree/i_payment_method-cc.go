@@ -7,6 +7,7 @@ import (
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 )
@@ -33,7 +34,9 @@ func (it *CreditCardMethod) GetType() string {
 
 // IsAllowed checks for payment method applicability
 func (it *CreditCardMethod) IsAllowed(checkoutInstance checkout.InterfaceCheckout) bool {
-	return utils.InterfaceToBool(env.ConfigGetValue(ConstGeneralConfigPathEnabled))
+	// transactions are made in merchant account currency, which is considered to be a base one
+	return utils.InterfaceToBool(env.ConfigGetValue(ConstGeneralConfigPathEnabled)) &&
+		currency.GetSessionCurrency(checkoutInstance.GetSession()) == currency.GetBaseCurrency()
 }
 
 // IsTokenable returns possibility to save token for this payment method
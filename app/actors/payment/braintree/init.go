@@ -2,6 +2,7 @@ package braintree
 
 import (
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -12,4 +13,13 @@ func init() {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "194b26f7-3399-4121-80f1-e24305708871", err.Error())
 	}
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "braintree",
+		Description:       "Braintree payment method",
+		Dependencies:      []string{"checkout"},
+		EnabledConfigPath: ConstGeneralConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
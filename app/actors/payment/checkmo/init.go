@@ -2,6 +2,7 @@ package checkmo
 
 import (
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -12,4 +13,14 @@ func init() {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "aa6367b6-3bf2-4843-915b-be8fbe3ffa6a", err.Error())
 	}
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "checkmo",
+		Description:       "Check/Money Order payment method",
+		Dependencies:      []string{"checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 )
 
@@ -33,8 +34,8 @@ func CompleteTransaction(orderInstance order.InterfaceOrder, token string, payer
 
 	// getting order information
 	//--------------------------
-	grandTotal := orderInstance.GetGrandTotal()
-	shippingPrice := orderInstance.GetShippingAmount()
+	currencyCode, grandTotal := currency.GetOrderAmount(orderInstance, orderInstance.GetGrandTotal())
+	_, shippingPrice := currency.GetOrderAmount(orderInstance, orderInstance.GetShippingAmount())
 
 	// getting request param values
 	//-----------------------------
@@ -47,7 +48,7 @@ func CompleteTransaction(orderInstance order.InterfaceOrder, token string, payer
 	shippingAmount := fmt.Sprintf("%.2f", shippingPrice)
 	itemAmount := fmt.Sprintf("%.2f", grandTotal-shippingPrice)
 
-	description := "Purchase%20for%20%24" + fmt.Sprintf("%.2f", grandTotal)
+	description := "Purchase%20for%20" + amount + "%20" + currencyCode
 	custom := orderInstance.GetID()
 
 	// making NVP request
@@ -63,7 +64,7 @@ func CompleteTransaction(orderInstance order.InterfaceOrder, token string, payer
 		"&PAYMENTREQUEST_0_ITEMAMT=" + itemAmount +
 		"&PAYMENTREQUEST_0_DESC=" + description +
 		"&PAYMENTREQUEST_0_CUSTOM=" + custom +
-		"&PAYMENTREQUEST_0_CURRENCYCODE=USD" +
+		"&PAYMENTREQUEST_0_CURRENCYCODE=" + currencyCode +
 		"&PAYERID=" + payerID +
 		"&TOKEN=" + token
 
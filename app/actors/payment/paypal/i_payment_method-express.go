@@ -9,9 +9,11 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 )
@@ -43,7 +45,7 @@ func (it *Express) IsTokenable(checkoutInstance checkout.InterfaceCheckout) bool
 
 // IsAllowed checks for method applicability
 func (it *Express) IsAllowed(checkoutInstance checkout.InterfaceCheckout) bool {
-	return utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathEnabled))
+	return extension.IsEnabled("paypal") && utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathEnabled))
 }
 
 // Authorize makes payment method authorize operation
@@ -51,8 +53,8 @@ func (it *Express) Authorize(orderInstance order.InterfaceOrder, paymentInfo map
 
 	// getting order information
 	//--------------------------
-	grandTotal := orderInstance.GetGrandTotal()
-	shippingPrice := orderInstance.GetShippingAmount()
+	currencyCode, grandTotal := currency.GetOrderAmount(orderInstance, orderInstance.GetGrandTotal())
+	_, shippingPrice := currency.GetOrderAmount(orderInstance, orderInstance.GetShippingAmount())
 
 	// getting request param values
 	//-----------------------------
@@ -65,7 +67,7 @@ func (it *Express) Authorize(orderInstance order.InterfaceOrder, paymentInfo map
 	shippingAmount := fmt.Sprintf("%.2f", shippingPrice)
 	itemAmount := fmt.Sprintf("%.2f", grandTotal-shippingPrice)
 
-	description := "Purchase%20for%20%24" + fmt.Sprintf("%.2f", grandTotal)
+	description := "Purchase%20for%20" + amount + "%20" + currencyCode
 	custom := orderInstance.GetID()
 
 	cancelURL := app.GetFoundationURL("paypal/cancel")
@@ -84,7 +86,7 @@ func (it *Express) Authorize(orderInstance order.InterfaceOrder, paymentInfo map
 		"&PAYMENTREQUEST_0_ITEMAMT=" + itemAmount +
 		"&PAYMENTREQUEST_0_DESC=" + description +
 		"&PAYMENTREQUEST_0_CUSTOM=" + custom +
-		"&PAYMENTREQUEST_0_CURRENCYCODE=USD" +
+		"&PAYMENTREQUEST_0_CURRENCYCODE=" + currencyCode +
 		"&cancelURL=" + cancelURL +
 		"&returnURL=" + returnURL
 
@@ -9,9 +9,11 @@ import (
 	"time"
 
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 	"io"
 )
@@ -38,7 +40,7 @@ func (it *PayFlowAPI) GetType() string {
 
 // IsTokenable checks for method applicability
 func (it *PayFlowAPI) IsTokenable(checkoutInstance checkout.InterfaceCheckout) bool {
-	return utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathPayPalPayflowEnabled)) && utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathPayPalPayflowTokenable))
+	return extension.IsEnabled("paypal") && utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathPayPalPayflowEnabled)) && utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathPayPalPayflowTokenable))
 }
 
 // IsAllowed checks for method applicability
@@ -82,7 +84,7 @@ func (it *PayFlowAPI) Authorize(orderInstance order.InterfaceOrder, paymentInfo
 
 	// getting order information
 	//--------------------------
-	grandTotal := orderInstance.GetGrandTotal()
+	currencyCode, grandTotal := currency.GetOrderAmount(orderInstance, orderInstance.GetGrandTotal())
 	amount := fmt.Sprintf("%.2f", grandTotal)
 
 	// paypal credentials
@@ -114,7 +116,7 @@ func (it *PayFlowAPI) Authorize(orderInstance order.InterfaceOrder, paymentInfo
 
 		// Payment Details Fields
 		"&AMT=" + amount +
-		"&CURRENCY=USD" +
+		"&CURRENCY=" + currencyCode +
 		"&VERBOSITY=HIGH" +
 		"&INVNUM=" + orderInstance.GetID()
 
@@ -3,6 +3,9 @@ package paypal
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+	"github.com/ottemo/foundation/env/health"
+	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -17,4 +20,28 @@ func init() {
 	}
 	api.RegisterOnRestServiceStart(setupAPI)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := health.RegisterProbe("payment/paypal", health.ConstKindReadiness, false, probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "paypal",
+		Description:  "PayPal payment methods",
+		Dependencies: []string{"checkout"},
+		Routes:       []string{"paypal/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// probe is a readiness health probe checking PayPal NVP gateway is reachable for enabled method
+func probe() error {
+	if !utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathEnabled)) {
+		return nil
+	}
+
+	nvpGateway := paymentPayPalExpress[ConstPaymentPayPalNvp][utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathPayPalExpressGateway))]
+
+	return health.CheckURL(nvpGateway)
 }
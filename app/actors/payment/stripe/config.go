@@ -54,8 +54,8 @@ func setupConfig() error {
 		Path:        ConstConfigPathAPIKey,
 		Label:       "API Key",
 		Value:       "",
-		Type:        env.ConstConfigTypeVarchar,
-		Editor:      "line_text",
+		Type:        env.ConstConfigTypeSecret,
+		Editor:      "password",
 		Description: "Your API Key will be located in your Stripe Dashboard.",
 	}, nil)
 	if err != nil {
@@ -1,6 +1,8 @@
 package stripe
 
 import (
+	"strings"
+
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
 	stripe "github.com/stripe/stripe-go"
@@ -9,6 +11,7 @@ import (
 	"github.com/stripe/stripe-go/customer"
 
 	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/order"
 	"github.com/ottemo/foundation/app/models/visitor"
 )
@@ -103,6 +106,11 @@ func (it *Payment) Authorize(orderInstance order.InterfaceOrder, paymentInfo map
 
 	// Charging: https://stripe.com/docs/api/go#create_charge
 	var ch *stripe.Charge
+	currencyCode, grandTotal := currency.GetOrderAmount(orderInstance, orderInstance.GetGrandTotal())
+
+	// float amount could be a fraction of cent below the expected value, so it is rounded rather than truncated
+	amount := uint64(utils.MoneyFromFloat(grandTotal).Cents(checkout.GetRoundingMode()))
+
 	ccInfo := paymentInfo["cc"]
 
 	// Token Charge
@@ -121,9 +129,9 @@ func (it *Payment) Authorize(orderInstance order.InterfaceOrder, paymentInfo map
 		}
 
 		chParams := stripe.ChargeParams{
-			Currency: "usd",
-			Amount:   uint64(orderInstance.GetGrandTotal() * 100), // Amount is in cents
-			Customer: stripeCID,                                   // Mandatory
+			Currency: stripe.Currency(strings.ToLower(currencyCode)),
+			Amount:   amount,    // Amount is in cents
+			Customer: stripeCID, // Mandatory
 		}
 		if err := chParams.SetSource(cardID); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelActor, "329ddd35-8fdc-4681-9a02-06290a405073", err.Error())
@@ -140,8 +148,8 @@ func (it *Payment) Authorize(orderInstance order.InterfaceOrder, paymentInfo map
 		// - email is stored on the charge's meta hashmap
 		var err error
 		chargeParams := stripe.ChargeParams{
-			Currency: "usd",
-			Amount:   uint64(orderInstance.GetGrandTotal() * 100), // Amount is in cents
+			Currency: stripe.Currency(strings.ToLower(currencyCode)),
+			Amount:   amount, // Amount is in cents
 		}
 		chargeParams.AddMeta("email", utils.InterfaceToString(orderInstance.Get("customer_email")))
 
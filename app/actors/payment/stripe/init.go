@@ -2,6 +2,9 @@ package stripe
 
 import (
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+	"github.com/ottemo/foundation/env/health"
+	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -11,4 +14,31 @@ func init() {
 		_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelActor, "ea29fa2a-f947-4e7f-aff0-b0965256c751", err.Error())
 	}
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := health.RegisterProbe("payment/stripe", health.ConstKindReadiness, false, probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "stripe",
+		Description:       "Stripe payment method",
+		Dependencies:      []string{"checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// probe is a readiness health probe checking Stripe API is configured and reachable for enabled method
+func probe() error {
+	if !utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathEnabled)) {
+		return nil
+	}
+
+	if utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathAPIKey)) == "" {
+		return env.Error(ConstErrorModule, env.ConstErrorLevelActor, "5a8c3e1f-b26d-4d9a-8f47-c0e9b2d6a371", "Stripe API key is not set")
+	}
+
+	return health.CheckURL("https://api.stripe.com")
 }
@@ -2,6 +2,7 @@ package zeropay
 
 import (
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -12,4 +13,14 @@ func init() {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c2f3aaed-7a1e-4b43-bc31-40cdec749ac5", err.Error())
 	}
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "zeropay",
+		Description:       "Zero amount payment method",
+		Dependencies:      []string{"checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
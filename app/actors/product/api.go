@@ -16,7 +16,9 @@ import (
 
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/cart"
+	"github.com/ottemo/foundation/app/models/currency"
 	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/app/models/store"
 	"github.com/ottemo/foundation/app/models/subscription"
 )
 
@@ -347,6 +349,11 @@ func APIGetProduct(context api.InterfaceApplicationContext) (interface{}, error)
 		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "153673ac-1008-40b5-ada9-2286ad3f02b0", "product not available")
 	}
 
+	// not allowing to see products of other stores
+	if !store.IsVisible(context, store.ConstObjectTypeProduct, productModel.GetID()) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c3e8a5d1-9f27-4b64-a0d3-6e1b9c4f7a52", "product not available")
+	}
+
 	mediaStorage, err := media.GetMediaStorage()
 	if err != nil {
 		return nil, env.ErrorDispatch(err)
@@ -357,6 +364,32 @@ func APIGetProduct(context api.InterfaceApplicationContext) (interface{}, error)
 
 	result := productModel.ToHashMap()
 
+	// shoppers are shown qty which is not reserved by carts and checkouts
+	if stockManager := product.GetRegisteredStock(); stockManager != nil && !api.IsAdminSession(context) {
+		result["qty"] = stockManager.GetAvailableQty(productModel.GetID(), productModel.GetAppliedOptions(), "")
+
+		// inventory records belong to product instance, so they are copied before change
+		if inventory, ok := result["inventory"].([]map[string]interface{}); ok {
+			var availableInventory []map[string]interface{}
+			for _, inventoryRecord := range inventory {
+				options := utils.InterfaceToMap(inventoryRecord["options"])
+				availableInventory = append(availableInventory, map[string]interface{}{
+					"options": options,
+					"qty":     stockManager.GetAvailableQty(productModel.GetID(), options, ""),
+				})
+			}
+			result["inventory"] = availableInventory
+		}
+	}
+
+	// price presented in currency visitor selected
+	currencyCode := currency.GetCurrentCurrency(context)
+	result["currency"] = currencyCode
+	result["display_price"], err = currency.GetProductPrice(productModel.GetID(), productModel.GetPrice(), currencyCode)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
 	itemImages, err := mediaStorage.GetAllSizes(product.ConstModelNameProduct, productModel.GetID(), ConstProductMediaTypeImage)
 	if err != nil {
 		return nil, env.ErrorDispatch(err)
@@ -788,6 +821,11 @@ func APIListProducts(context api.InterfaceApplicationContext) (interface{}, erro
 		}
 	}
 
+	// exclude products not assigned to store
+	if err := store.ApplyVisibilityFilter(context, productCollectionModel.GetDBCollection(), store.ConstObjectTypeProduct); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7f2c9e4a-b361-4d58-8e0b-5a1d7c3f9e26", err.Error())
+	}
+
 	// check "count" request
 	if context.GetRequestArgument(api.ConstRESTActionParameter) == "count" {
 		return productCollectionModel.GetDBCollection().Count()
@@ -815,8 +853,19 @@ func APIListProducts(context api.InterfaceApplicationContext) (interface{}, erro
 
 	var result []map[string]interface{}
 
+	currencyCode := currency.GetCurrentCurrency(context)
+
 	for _, listItem := range listItems {
 
+		// price requested as extra attribute is presented in currency visitor selected
+		if price, present := listItem.Extra["price"]; present {
+			listItem.Extra["currency"] = currencyCode
+			listItem.Extra["display_price"], err = currency.GetProductPrice(listItem.ID, utils.InterfaceToFloat64(price), currencyCode)
+			if err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+
 		itemImages, err := mediaStorage.GetAllSizes(product.ConstModelNameProduct, listItem.ID, ConstProductMediaTypeImage)
 		if err != nil {
 			return nil, env.ErrorDispatch(err)
@@ -884,6 +933,11 @@ func APIListRelatedProducts(context api.InterfaceApplicationContext) (interface{
 		}
 	}
 
+	// exclude related products not assigned to store
+	if err := store.ApplyVisibilityFilter(context, productsCollection.GetDBCollection(), store.ConstObjectTypeProduct); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c36e8b1f-7d24-4a95-8f0b-2e6d9a4c1b73", err.Error())
+	}
+
 	// add a limit
 	if err := productsCollection.ListLimit(models.GetListLimit(context)); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b52c8d72-0e43-4e40-b7e3-d35594d3d54d", err.Error())
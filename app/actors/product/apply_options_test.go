@@ -113,6 +113,12 @@ func TestProductApplyOptions(t *testing.T) {
 	}
 
 	checkResults(t, product.ToHashMap(), check.(map[string]interface{}))
+
+	if basePriced, ok := product.(interface {
+		GetBasePrice() float64
+	}); !ok || basePriced.GetBasePrice() != 1.1 {
+		t.Error("price before options were applied should be 1.1")
+	}
 }
 
 func TestProductApplyOptionsQty(t *testing.T) {
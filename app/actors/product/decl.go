@@ -48,6 +48,9 @@ type DefaultProduct struct {
 	// appliedOptions tracks options were applied to current instance
 	appliedOptions map[string]interface{}
 
+	// basePrice holds product price before options were applied
+	basePrice float64
+
 	// updatedQty holds qty should be updated during save operation ("" item holds qty value)
 	updatedQty []map[string]interface{}
 
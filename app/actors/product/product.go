@@ -95,6 +95,14 @@ func (it *DefaultProduct) GetPrice() float64 {
 	return it.Price
 }
 
+// GetBasePrice returns the price of the given product before options were applied
+func (it *DefaultProduct) GetBasePrice() float64 {
+	if it.appliedOptions == nil {
+		return it.Price
+	}
+	return it.basePrice
+}
+
 // GetWeight returns the weight for the given product
 func (it *DefaultProduct) GetWeight() float64 {
 	return it.Weight
@@ -147,6 +155,9 @@ func (it *DefaultProduct) ApplyOptions(options map[string]interface{}) error {
 
 	// storing start price for a case of percentage price modifier
 	var startPrice = it.GetPrice()
+	if it.appliedOptions == nil {
+		it.basePrice = startPrice
+	}
 
 	var selectedProductIDs []string
 	var storedImageName string
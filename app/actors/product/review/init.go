@@ -4,12 +4,22 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init makes package self-initialization routine before app start
 func init() {
 	db.RegisterOnDatabaseStart(setupDB)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "review",
+		Description:  "Product reviews and ratings",
+		Dependencies: []string{"product"},
+		Routes:       []string{"review*", "rating/*", "ratedreview/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // DB preparations for current model implementation
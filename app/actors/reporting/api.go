@@ -11,6 +11,7 @@ import (
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
 
+	"github.com/ottemo/foundation/app/actors/cart"
 	"github.com/ottemo/foundation/app/actors/discount/giftcard"
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/checkout"
@@ -30,6 +31,7 @@ func setupAPI() error {
 	service.GET("reporting/location-country", api.IsAdminHandler(listLocationCountry))
 	service.GET("reporting/location-us", api.IsAdminHandler(listLocationUS))
 	service.GET("reporting/gift-cards", api.IsAdminHandler(listGiftCards))
+	service.GET("reporting/abandoned-carts", api.IsAdminHandler(listAbandonedCarts))
 
 	return nil
 }
@@ -563,6 +565,73 @@ func listGiftCards(context api.InterfaceApplicationContext) (interface{}, error)
 	return results, nil
 }
 
+// listAbandonedCarts returns results of abandoned cart campaign steps, orders are attributed to the last email
+// sent for cart they were made of
+func listAbandonedCarts(context api.InterfaceApplicationContext) (interface{}, error) {
+	perfStart := time.Now()
+
+	emailCollection, err := db.GetCollection(cart.ConstCartAbandonEmailCollectionName)
+	if err != nil {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	err = ApplyDateRangeFilter(context, emailCollection)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	collectionRecords, err := emailCollection.Load()
+	if err != nil {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	keyedResults := make(map[int]AbandonCampaignStepItem)
+	var totalRevenue float64
+	var totalSent, totalRecovered int
+
+	for _, record := range collectionRecords {
+		step := utils.InterfaceToInt(record["step"])
+		item := keyedResults[step]
+		item.Step = step
+		item.EmailsSent++
+
+		if utils.InterfaceToString(record["coupon_code"]) != "" {
+			item.CouponsIssued++
+		}
+
+		if utils.InterfaceToString(record["order_id"]) != "" {
+			revenue := utils.InterfaceToFloat64(record["revenue"])
+			item.RecoveredOrders++
+			item.RecoveredRevenue += revenue
+			totalRevenue += revenue
+			totalRecovered++
+		}
+		totalSent++
+
+		keyedResults[step] = item
+	}
+
+	results := make([]AbandonCampaignStepItem, 0, len(keyedResults))
+	for _, item := range keyedResults {
+		item.RecoveredRevenue = utils.RoundPrice(item.RecoveredRevenue)
+		item.RecoveryRate = utils.RoundPrice(float64(item.RecoveredOrders) / float64(item.EmailsSent) * 100)
+		results = append(results, item)
+	}
+	sort.Sort(AbandonCampaignSteps(results))
+
+	response := map[string]interface{}{
+		"aggregate_items":   results,
+		"emails_sent":       totalSent,
+		"recovered_orders":  totalRecovered,
+		"recovered_revenue": utils.RoundPrice(totalRevenue),
+		"perf_ms":           time.Now().Sub(perfStart).Seconds() * 1e3, // in milliseconds
+	}
+
+	return response, nil
+}
+
 func ApplyDateRangeFilter(context api.InterfaceApplicationContext, collection db.InterfaceDBCollection) error {
 	// Expecting dates in UTC, and adjusted for your timezone `2006-01-02 15:04`
 	startDate := utils.InterfaceToTime(context.GetRequestArgument("start_date"))
@@ -114,3 +114,28 @@ func (a StatsBySales) Less(i, j int) bool {
 
 	return a[i].TotalSales > a[j].TotalSales
 }
+
+// AbandonCampaignStepItem is a container for abandoned cart campaign step results
+type AbandonCampaignStepItem struct {
+	Step             int     `json:"step"`
+	EmailsSent       int     `json:"emails_sent"`
+	CouponsIssued    int     `json:"coupons_issued"`
+	RecoveredOrders  int     `json:"recovered_orders"`
+	RecoveredRevenue float64 `json:"recovered_revenue"`
+	RecoveryRate     float64 `json:"recovery_rate"`
+}
+
+// AbandonCampaignSteps is an array of AbandonCampaignStepItems to be sorted by step.
+type AbandonCampaignSteps []AbandonCampaignStepItem
+
+func (a AbandonCampaignSteps) Len() int {
+	return len(a)
+}
+
+func (a AbandonCampaignSteps) Swap(i, j int) {
+	a[i], a[j] = a[j], a[i]
+}
+
+func (a AbandonCampaignSteps) Less(i, j int) bool {
+	return a[i].Step < a[j].Step
+}
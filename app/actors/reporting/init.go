@@ -2,8 +2,19 @@ package reporting
 
 import (
 	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 func init() {
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "reporting",
+		Description:  "Sales reports",
+		Dependencies: []string{"order"},
+		Routes:       []string{"reporting/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init makes package self-initialization routine before app start
@@ -13,6 +14,14 @@ func init() {
 	env.RegisterOnConfigStart(setupConfig)
 
 	db.RegisterOnDatabaseStart(onDatabaseStart)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "rts",
+		Description: "Real time statistics",
+		Routes:      []string{"rts/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func onDatabaseStart() error {
@@ -31,12 +40,12 @@ func onDatabaseStart() error {
 // DB preparations for current model implementation
 func initListners() error {
 	// env.EventRegisterListener("api.rts.visit", referrerHandler)
-	env.EventRegisterListener("api.rts.visit", visitsHandler)
-	env.EventRegisterListener("api.cart.addToCart", addToCartHandler)
-	env.EventRegisterListener("api.checkout.visit", visitCheckoutHandler)
-	env.EventRegisterListener("api.checkout.setPayment", setPaymentHandler)
-	env.EventRegisterListener("checkout.success", purchasedHandler)
-	env.EventRegisterListener("checkout.success", salesHandler)
+	extension.EventRegisterListener("rts", "api.rts.visit", visitsHandler)
+	extension.EventRegisterListener("rts", "api.cart.addToCart", addToCartHandler)
+	extension.EventRegisterListener("rts", "api.checkout.visit", visitCheckoutHandler)
+	extension.EventRegisterListener("rts", "api.checkout.setPayment", setPaymentHandler)
+	extension.EventRegisterListener("rts", "checkout.success", purchasedHandler)
+	extension.EventRegisterListener("rts", "checkout.success", salesHandler)
 
 	return nil
 }
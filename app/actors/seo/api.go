@@ -6,16 +6,14 @@ import (
 	"time"
 
 	"github.com/ottemo/foundation/api"
-	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/jobs"
 	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models"
-	"github.com/ottemo/foundation/app/models/category"
-	"github.com/ottemo/foundation/app/models/cms"
-	"github.com/ottemo/foundation/app/models/product"
 	"github.com/ottemo/foundation/app/models/seo"
+	"github.com/ottemo/foundation/app/models/store"
 	"io"
 )
 
@@ -56,6 +54,11 @@ func APIListSEOItems(context api.InterfaceApplicationContext) (interface{}, erro
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8f41ce59-fd0d-4755-b5f8-a11613adf9bc", err.Error())
 	}
 
+	// excluding items not assigned to store
+	if err := store.ApplyVisibilityFilter(context, seoItemCollectionModel.GetDBCollection(), store.ConstObjectTypeSEO); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4b9e2d7f-a136-4c58-8e0f-1d6a3c9b7e25", err.Error())
+	}
+
 	// check "count" request
 	if context.GetRequestArgument(api.ConstRESTActionParameter) == "count" {
 		return seoItemCollectionModel.GetDBCollection().Count()
@@ -142,6 +145,9 @@ func APIGetSEOItem(context api.InterfaceApplicationContext) (interface{}, error)
 	if err := collection.AddFilter("url", "=", specifiedURL); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3902720b-ad39-4b17-a8bc-d80989147808", err.Error())
 	}
+	if err := store.ApplyVisibilityFilter(context, collection, store.ConstObjectTypeSEO); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e7a2c5f9-3d81-4b64-a9e0-5c8f1b6d2a37", err.Error())
+	}
 	records, err := collection.Load()
 
 	if err != nil {
@@ -176,6 +182,11 @@ func APIGetSEOItemByID(context api.InterfaceApplicationContext) (interface{}, er
 		return nil, env.ErrorDispatch(err)
 	}
 
+	if !store.IsVisible(context, store.ConstObjectTypeSEO, seoItemModel.GetID()) {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "9c3f6b1e-d528-4a97-b0e4-2f7d5a8c1e63", "seo item is not available")
+	}
+
 	return seoItemModel.ToHashMap(), nil
 }
 
@@ -330,17 +341,24 @@ func APIDeleteSEOItem(context api.InterfaceApplicationContext) (interface{}, err
 	return "ok", nil
 }
 
-// APIGetSitemap returns SEO records based sitemap (auto re-generating it if needed)
+// APIGetSitemap returns SEO records based sitemap
+//   - expired sitemap is still returned while new one is generated by background job
+//   - sitemap is generated at once if there is no one yet
 //   - result is not a JSON but "text/xml"
 func APIGetSitemap(context api.InterfaceApplicationContext) (interface{}, error) {
 
-	// if sitemap expired - generate new one
 	info, err := os.Stat(ConstSitemapFilePath)
-	if err != nil || (time.Now().Unix()-info.ModTime().Unix() >= ConstSitemapExpireSec) {
-		return APIGenerateSitemap(context)
+	if err != nil {
+		if err := generateSitemap(); err != nil {
+			context.SetResponseStatusInternalServerError()
+			return nil, env.ErrorDispatch(err)
+		}
+	} else if time.Now().Unix()-info.ModTime().Unix() >= ConstSitemapExpireSec {
+		if _, err := jobs.RunOnce(ConstJobTypeSitemap, nil); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
 	}
 
-	// using generated otherwise
 	sitemapFile, err := os.Open(ConstSitemapFilePath)
 	if err != nil {
 		context.SetResponseStatusInternalServerError()
@@ -379,143 +397,15 @@ func APIGetSitemap(context api.InterfaceApplicationContext) (interface{}, error)
 	return nil, nil
 }
 
-// APIGenerateSitemap generates a new sitemap based on SEO records
-//   - generates sitemap any time called (no cache used)
-//   - result is not a JSON but "text/xml"
+// APIGenerateSitemap starts a new sitemap generation based on SEO records
+//   - generation is made by background job, job info returned
+//   - running generation job returned if there is one
 func APIGenerateSitemap(context api.InterfaceApplicationContext) (interface{}, error) {
-
-	if err := context.SetResponseContentType("text/xml"); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "f3c9b7e7-d0fd-4f7d-9a42-86a78f828231", err.Error())
-	}
-	responseWriter := context.GetResponseWriter()
-
-	// creating sitemap file
-	sitemapFile, err := os.Create(ConstSitemapFilePath)
+	job, err := jobs.RunOnce(ConstJobTypeSitemap, nil)
 	if err != nil {
 		context.SetResponseStatusInternalServerError()
 		return nil, env.ErrorDispatch(err)
 	}
-	defer func(c io.Closer){
-		if err := c.Close(); err != nil {
-			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5a85194f-7963-42a2-9d07-c97c463ae66b", err.Error())
-		}
-	}(sitemapFile)
-
-	// writer to write into HTTP and file simultaneously
-	newline := []byte("\n")
-	writeLine := func(line []byte) {
-		if responseWriter != nil {
-			if _, err := responseWriter.Write(line); err != nil {
-				_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "71fc4613-626a-4720-aac2-a13a188cf51e", err.Error())
-			}
-			if _, err := responseWriter.Write(newline); err != nil {
-				_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a8b5b411-d1a3-4481-abd0-e8fff0e1f642", err.Error())
-			}
-		}
-
-		if _, err := sitemapFile.Write(line); err != nil {
-			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "26265d8c-3293-4319-b1f3-5f1073b07cbb", err.Error())
-		}
-		if _, err := sitemapFile.Write(newline); err != nil {
-			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "50959d06-083d-4176-9aef-69f27b6cffc5", err.Error())
-		}
-	}
-
-	// sitemap file preparations
-	writeLine([]byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"))
-	writeLine([]byte("<urlset xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\">"))
-
-	baseURL := app.GetStorefrontURL("")
-	rewriteType := ""
-
-	// per database record iterator
-	iteratorFunc := func(record map[string]interface{}) bool {
-		pageURL := ""
-		if rewriteType == "" {
-			pageURL = baseURL + utils.InterfaceToString(record["url"])
-		} else {
-			pageURL = baseURL + rewriteType + "/" + utils.InterfaceToString(record["_id"])
-		}
-
-		writeLine([]byte("  <url><loc>" + pageURL + "</loc></url>"))
-
-		return true
-	}
-
-	// Re-writed pages
-	rewritesCollection, err := db.GetCollection(ConstCollectionNameURLRewrites)
-	if err := rewritesCollection.SetResultColumns("url"); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "33b433a2-e50e-4ae4-a509-8ba147779202", err.Error())
-	}
-	if err := rewritesCollection.Iterate(iteratorFunc); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5fec2d5d-388b-4ae6-8bb9-7f19dd1eccc8", err.Error())
-	}
-
-	if err := rewritesCollection.SetResultColumns("rewrite"); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4f55972e-323f-4cc4-81e1-517b5d94f8d5", err.Error())
-	}
-
-	// Product pages
-	rewriteType = "product"
-	if err := rewritesCollection.AddFilter("type", "=", rewriteType); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1add17b7-e505-404a-8905-04332be27ff6", err.Error())
-	}
-
-	productCollectionModel, _ := product.GetProductCollectionModel()
-	dbProductCollection := productCollectionModel.GetDBCollection()
-	if err := dbProductCollection.SetResultColumns("_id"); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "063b59b0-37da-4c9a-b13b-a1277c110598", err.Error())
-	}
-	if err := dbProductCollection.AddFilter("_id", "nin", rewritesCollection); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "ee74ae99-a295-47de-9fea-366045a75aca", err.Error())
-	}
-	if err := dbProductCollection.Iterate(iteratorFunc); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "510d47f9-66a6-4e49-a05f-016e3099aaf4", err.Error())
-	}
-
-	// Category pages
-	rewriteType = "category"
-	if err := rewritesCollection.ClearFilters(); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1517d748-9784-49be-8021-d3d99d68c52e", err.Error())
-	}
-	if err := rewritesCollection.AddFilter("type", "=", rewriteType); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "09452416-ec3c-4c48-b952-83ce528e20c3", err.Error())
-	}
-
-	categoryCollectionModel, _ := category.GetCategoryCollectionModel()
-	dbCategoryCollection := categoryCollectionModel.GetDBCollection()
-	if err := dbCategoryCollection.SetResultColumns("_id"); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "85e91980-eb5f-4f44-94b2-ae3cda6a7420", err.Error())
-	}
-	if err := dbCategoryCollection.AddFilter("_id", "nin", rewritesCollection); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "dd334247-273d-4dcd-81d6-8e2a68c5c09c", err.Error())
-	}
-	if err := dbCategoryCollection.Iterate(iteratorFunc); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "cd3958c9-3ba5-45fc-881d-86e5c6ffab12", err.Error())
-	}
 
-	// Cms pages
-	rewriteType = "page"
-	if err := rewritesCollection.ClearFilters(); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "eb511139-07dd-439e-926e-3107bac96281", err.Error())
-	}
-	if err := rewritesCollection.AddFilter("type", "=", rewriteType); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3f742f50-489d-41f8-b6f6-9dbbe204a7a9", err.Error())
-	}
-
-	cmsPageCollectionModel, _ := cms.GetCMSPageCollectionModel()
-	dbCMSPageCollection := cmsPageCollectionModel.GetDBCollection()
-	if err := dbCMSPageCollection.SetResultColumns("_id"); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1fae19a4-d2a3-47f4-a028-128a87fbf925", err.Error())
-	}
-	if err := dbCMSPageCollection.AddFilter("_id", "nin", rewritesCollection); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "484f4837-8cf7-49aa-922f-1c872642b3db", err.Error())
-	}
-	if err := dbCMSPageCollection.Iterate(iteratorFunc); err != nil {
-		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7b6cd323-5a7b-4c93-86ba-ea0833af69f2", err.Error())
-	}
-
-	writeLine([]byte("</urlset>"))
-
-	return nil, nil
+	return job.ToHashMap(), nil
 }
@@ -12,6 +12,7 @@ const (
 
 	ConstSitemapFilePath  = "sitemap.xml"
 	ConstSitemapExpireSec = 60 * 60 * 24
+	ConstJobTypeSitemap   = "seo.sitemap"
 
 	ConstErrorModule = "seo"
 	ConstErrorLevel  = env.ConstErrorLevelActor
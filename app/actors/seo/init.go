@@ -4,6 +4,8 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+	"github.com/ottemo/foundation/env/jobs"
 
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/seo"
@@ -25,6 +27,18 @@ func init() {
 
 	api.RegisterOnRestServiceStart(setupAPI)
 	db.RegisterOnDatabaseStart(setupDB)
+
+	if err := jobs.RegisterHandler(ConstJobTypeSitemap, 1, sitemapJob); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "seo",
+		Description: "SEO URL rewrites and sitemap",
+		Routes:      []string{"seo/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -0,0 +1,164 @@
+package seo
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/jobs"
+	"github.com/ottemo/foundation/utils"
+
+	"github.com/ottemo/foundation/app/models/category"
+	"github.com/ottemo/foundation/app/models/cms"
+	"github.com/ottemo/foundation/app/models/product"
+)
+
+// sitemapJob is a "seo.sitemap" job handler
+func sitemapJob(job jobs.InterfaceJob) (interface{}, error) {
+	if err := generateSitemap(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return ConstSitemapFilePath, nil
+}
+
+// generateSitemap makes a new sitemap file based on SEO records
+//   - sitemap is written to temporary file which replaces previous one, so it is available during generation
+func generateSitemap() error {
+	tempFilePath := ConstSitemapFilePath + ".tmp"
+
+	sitemapFile, err := os.Create(tempFilePath)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = writeSitemap(sitemapFile)
+	if closeErr := sitemapFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempFilePath)
+		return env.ErrorDispatch(err)
+	}
+
+	return os.Rename(tempFilePath, ConstSitemapFilePath)
+}
+
+// writeSitemap writes sitemap content to given writer
+func writeSitemap(output io.Writer) error {
+	writer := bufio.NewWriter(output)
+
+	newline := []byte("\n")
+	writeLine := func(line []byte) {
+		if _, err := writer.Write(line); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "26265d8c-3293-4319-b1f3-5f1073b07cbb", err.Error())
+		}
+		if _, err := writer.Write(newline); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "50959d06-083d-4176-9aef-69f27b6cffc5", err.Error())
+		}
+	}
+
+	// sitemap file preparations
+	writeLine([]byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"))
+	writeLine([]byte("<urlset xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\">"))
+
+	baseURL := app.GetStorefrontURL("")
+	rewriteType := ""
+
+	// per database record iterator
+	iteratorFunc := func(record map[string]interface{}) bool {
+		pageURL := ""
+		if rewriteType == "" {
+			pageURL = baseURL + utils.InterfaceToString(record["url"])
+		} else {
+			pageURL = baseURL + rewriteType + "/" + utils.InterfaceToString(record["_id"])
+		}
+
+		writeLine([]byte("  <url><loc>" + pageURL + "</loc></url>"))
+
+		return true
+	}
+
+	// Re-writed pages
+	rewritesCollection, err := db.GetCollection(ConstCollectionNameURLRewrites)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := rewritesCollection.SetResultColumns("url"); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "33b433a2-e50e-4ae4-a509-8ba147779202", err.Error())
+	}
+	if err := rewritesCollection.Iterate(iteratorFunc); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5fec2d5d-388b-4ae6-8bb9-7f19dd1eccc8", err.Error())
+	}
+
+	if err := rewritesCollection.SetResultColumns("rewrite"); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4f55972e-323f-4cc4-81e1-517b5d94f8d5", err.Error())
+	}
+
+	// Product pages
+	rewriteType = "product"
+	if err := rewritesCollection.AddFilter("type", "=", rewriteType); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1add17b7-e505-404a-8905-04332be27ff6", err.Error())
+	}
+
+	productCollectionModel, _ := product.GetProductCollectionModel()
+	dbProductCollection := productCollectionModel.GetDBCollection()
+	if err := dbProductCollection.SetResultColumns("_id"); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "063b59b0-37da-4c9a-b13b-a1277c110598", err.Error())
+	}
+	if err := dbProductCollection.AddFilter("_id", "nin", rewritesCollection); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "ee74ae99-a295-47de-9fea-366045a75aca", err.Error())
+	}
+	if err := dbProductCollection.Iterate(iteratorFunc); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "510d47f9-66a6-4e49-a05f-016e3099aaf4", err.Error())
+	}
+
+	// Category pages
+	rewriteType = "category"
+	if err := rewritesCollection.ClearFilters(); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1517d748-9784-49be-8021-d3d99d68c52e", err.Error())
+	}
+	if err := rewritesCollection.AddFilter("type", "=", rewriteType); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "09452416-ec3c-4c48-b952-83ce528e20c3", err.Error())
+	}
+
+	categoryCollectionModel, _ := category.GetCategoryCollectionModel()
+	dbCategoryCollection := categoryCollectionModel.GetDBCollection()
+	if err := dbCategoryCollection.SetResultColumns("_id"); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "85e91980-eb5f-4f44-94b2-ae3cda6a7420", err.Error())
+	}
+	if err := dbCategoryCollection.AddFilter("_id", "nin", rewritesCollection); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "dd334247-273d-4dcd-81d6-8e2a68c5c09c", err.Error())
+	}
+	if err := dbCategoryCollection.Iterate(iteratorFunc); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "cd3958c9-3ba5-45fc-881d-86e5c6ffab12", err.Error())
+	}
+
+	// Cms pages
+	rewriteType = "page"
+	if err := rewritesCollection.ClearFilters(); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "eb511139-07dd-439e-926e-3107bac96281", err.Error())
+	}
+	if err := rewritesCollection.AddFilter("type", "=", rewriteType); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3f742f50-489d-41f8-b6f6-9dbbe204a7a9", err.Error())
+	}
+
+	cmsPageCollectionModel, _ := cms.GetCMSPageCollectionModel()
+	dbCMSPageCollection := cmsPageCollectionModel.GetDBCollection()
+	if err := dbCMSPageCollection.SetResultColumns("_id"); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1fae19a4-d2a3-47f4-a028-128a87fbf925", err.Error())
+	}
+	if err := dbCMSPageCollection.AddFilter("_id", "nin", rewritesCollection); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "484f4837-8cf7-49aa-922f-1c872642b3db", err.Error())
+	}
+	if err := dbCMSPageCollection.Iterate(iteratorFunc); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7b6cd323-5a7b-4c93-86ba-ea0833af69f2", err.Error())
+	}
+
+	writeLine([]byte("</urlset>"))
+
+	return writer.Flush()
+}
@@ -78,8 +78,8 @@ func setupConfig() error {
 		err = config.RegisterItem(env.StructConfigItem{
 			Path:        ConstConfigPathKey,
 			Value:       "",
-			Type:        env.ConstConfigTypeVarchar,
-			Editor:      "line_text",
+			Type:        env.ConstConfigTypeSecret,
+			Editor:      "password",
 			Options:     nil,
 			Label:       "Account Key",
 			Description: "FedEx account key",
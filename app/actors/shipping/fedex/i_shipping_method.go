@@ -76,9 +76,9 @@ func (it *FedEx) GetRates(checkoutObject checkout.InterfaceCheckout) []checkout.
 	// calculating weight
 	//-------------------
 	var pounds float64
-	if checkoutCart := checkoutObject.GetCart(); checkoutCart != nil {
+	// checkout items are taken, so shipping group checkout is quoted for the group items only
+	if cartItems := checkoutObject.GetItems(); cartItems != nil {
 
-		cartItems := checkoutCart.GetItems()
 		if len(cartItems) == 0 {
 			return result
 		}
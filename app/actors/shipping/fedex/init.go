@@ -3,6 +3,9 @@ package fedex
 import (
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+	"github.com/ottemo/foundation/env/health"
+	"github.com/ottemo/foundation/utils"
 )
 
 // init makes package self-initialization routine
@@ -11,4 +14,32 @@ func init() {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "619e374e-c247-4db5-81fd-2baf8dd6f9f6", err.Error())
 	}
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := health.RegisterProbe("shipping/fedex", health.ConstKindReadiness, false, probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "fedex",
+		Description:       "FedEx shipping method",
+		Dependencies:      []string{"checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// probe is a readiness health probe checking FedEx gateway is configured and reachable for enabled method
+func probe() error {
+	if !utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathEnabled)) {
+		return nil
+	}
+
+	if utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathKey)) == "" ||
+		utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathPassword)) == "" {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "c81f4d27-6e3a-4b95-a0d8-3f7e9c2b5a64", "FedEx credentials are not set")
+	}
+
+	return health.CheckURL(utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathGateway)))
 }
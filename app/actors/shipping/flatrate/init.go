@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -17,6 +18,16 @@ func init() {
 	}
 
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "flatrate",
+		Description:       "Flat rate shipping method",
+		Dependencies:      []string{"checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // onAppStart makes module initialization on application startup
@@ -3,6 +3,7 @@ package flatweight
 import (
 	"github.com/ottemo/foundation/app/models/checkout"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 func init() {
@@ -13,4 +14,14 @@ func init() {
 	if err := checkout.RegisterShippingMethod(i); err != nil {
 		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "30e9fc93-1841-4429-b5a1-c7c6cf7cd3b7", err.Error())
 	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "flatweight",
+		Description:       "Flat weight shipping method",
+		Dependencies:      []string{"checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
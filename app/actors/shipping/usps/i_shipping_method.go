@@ -69,9 +69,9 @@ func (it *USPS) GetRates(checkoutObject checkout.InterfaceCheckout) []checkout.S
 
 	var pounds float64
 	var ounces float64
-	if checkoutCart := checkoutObject.GetCart(); checkoutCart != nil {
+	// checkout items are taken, so shipping group checkout is quoted for the group items only
+	if cartItems := checkoutObject.GetItems(); cartItems != nil {
 
-		cartItems := checkoutCart.GetItems()
 		if len(cartItems) == 0 {
 			return result
 		}
@@ -2,6 +2,9 @@ package usps
 
 import (
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+	"github.com/ottemo/foundation/env/health"
+	"github.com/ottemo/foundation/utils"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -15,4 +18,31 @@ func init() {
 	}
 
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := health.RegisterProbe("shipping/usps", health.ConstKindReadiness, false, probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "usps",
+		Description:       "USPS shipping method",
+		Dependencies:      []string{"checkout"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// probe is a readiness health probe checking USPS API is configured and reachable for enabled method
+func probe() error {
+	if !utils.InterfaceToBool(env.ConfigGetValue(ConstConfigPathEnabled)) {
+		return nil
+	}
+
+	if utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathUser)) == "" {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "2e6b9f3d-a41c-4d87-b5f0-8c3a7e1d6b29", "USPS user id is not set")
+	}
+
+	return health.CheckURL(ConstHTTPEndpoint)
 }
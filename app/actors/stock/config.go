@@ -77,6 +77,29 @@ func setupConfig() error {
 		if _, err := validateEnabled(env.ConfigGetValue(ConstConfigPathEnabled)); err != nil {
 			return env.ErrorDispatch(err)
 		}
+
+		validateReservationTTL := func(value interface{}) (interface{}, error) {
+			ttl := utils.InterfaceToInt(value)
+			if ttl < 0 {
+				return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d41b7c08-5e93-4a2f-86c1-3f9e20a7b6d5", "reservation time should not be negative")
+			}
+			return ttl, nil
+		}
+
+		err = config.RegisterItem(env.StructConfigItem{
+			Path:        ConstConfigPathReservationTTL,
+			Value:       15,
+			Type:        env.ConstConfigTypeInteger,
+			Editor:      "integer",
+			Options:     nil,
+			Label:       "Reservation Time",
+			Description: "minutes qty of products in cart or checkout is reserved for, 0 disables reservations",
+			Image:       "",
+		}, validateReservationTTL)
+
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
 	} else {
 		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "fdc4f498-3d03-48a9-b51b-46aeae42edd1", "Unable to obtain configuration for Stock")
 		return env.ErrorDispatch(err)
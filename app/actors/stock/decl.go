@@ -3,6 +3,8 @@
 package stock
 
 import (
+	"sync"
+
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/product"
 	"github.com/ottemo/foundation/env"
@@ -12,9 +14,11 @@ import (
 // Package global constants
 const (
 	ConstCollectionNameStock = "stock"
+	ConstCollectionNameStockReservation = "stock_reservation"
 
 	ConstConfigPathGroup   = "general.stock"
 	ConstConfigPathEnabled = "general.stock.enabled"
+	ConstConfigPathReservationTTL = "general.stock.reservationTTL"
 
 	ConstErrorModule = "stock"
 	ConstErrorLevel  = env.ConstErrorLevelActor
@@ -50,6 +54,11 @@ const (
 //	qty would be 6.
 //
 //	When the product going to be deleted, it removes all the records.
+//
+//	Qty of products which sit in carts and checkouts is reserved for a limited time. Reservations are kept
+//	separately from stock records, so they are not taken into account by GetProductQty, but available qty of
+//	stock record is lowered by all active reservations of options the record applies to. Reservations are
+//	released when order is proceeded, as stock is decremented at that moment.
 
 type DefaultStock struct{
 	id string
@@ -75,3 +84,9 @@ type StockDelegate struct {
 
 // stockDelegate variable that is currently used as a stock delegate to extend product attributes
 var stockDelegate models.InterfaceAttributesDelegate
+
+// Package global variables
+var (
+	reservationLocks      = make(map[string]*sync.Mutex) // per product locks reservations are checked and made under
+	reservationLocksMutex sync.Mutex                     // synchronization for reservationLocks map
+)
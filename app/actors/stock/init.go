@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/app/models/stock"
 )
@@ -27,6 +28,17 @@ func init() {
 	api.RegisterOnRestServiceStart(setupAPI)
 	db.RegisterOnDatabaseStart(setupDB)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:              "stock",
+		Description:       "Stock management",
+		Dependencies:      []string{"product"},
+		ConfigGroup:       ConstConfigPathGroup,
+		EnabledConfigPath: ConstConfigPathEnabled,
+		Routes:            []string{"stock/*", "product/:productID/stock"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -46,5 +58,25 @@ func setupDB() error {
 		return env.ErrorDispatch(err)
 	}
 
+	collection, err := db.GetCollection(ConstCollectionNameStockReservation)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("holder_id", db.ConstTypeID, true); err != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3b8e61d4-0f27-4c95-a1e3-7d52c9b06fa8", err.Error())
+	}
+	if err := collection.AddColumn("product_id", db.ConstTypeID, true); err != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c54f2a97-6e18-4d03-b7a9-1e8d3f60c2b5", err.Error())
+	}
+	if err := collection.AddColumn("options", db.ConstTypeJSON, false); err != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9a1d7e30-4c65-4b8f-82e6-f05b3c9d14a7", err.Error())
+	}
+	if err := collection.AddColumn("qty", db.ConstTypeInteger, false); err != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e2c06b58-91a4-47d3-bd1f-6a8e4f72c390", err.Error())
+	}
+	if err := collection.AddColumn("expires_at", db.ConstTypeDatetime, true); err != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "57f4e9a2-b3d1-4086-9c7e-2a0d6b18f5e3", err.Error())
+	}
+
 	return nil
 }
@@ -0,0 +1,231 @@
+package stock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// getReservationTTL returns time reservations are kept for, zero if reservations are disabled
+func getReservationTTL() time.Duration {
+	return time.Duration(utils.InterfaceToInt(env.ConfigGetValue(ConstConfigPathReservationTTL))) * time.Minute
+}
+
+// lockProductReservations locks reservations of product, so available qty check and reservation are made at once
+//   - returned function unlocks reservations
+func lockProductReservations(productID string) func() {
+	reservationLocksMutex.Lock()
+	lock, present := reservationLocks[productID]
+	if !present {
+		lock = new(sync.Mutex)
+		reservationLocks[productID] = lock
+	}
+	reservationLocksMutex.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// getActiveReservations returns not expired reservations of product made by holders other than given one
+func getActiveReservations(productID string, excludeHolderID string) ([]map[string]interface{}, error) {
+	dbCollection, err := db.GetCollection(ConstCollectionNameStockReservation)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := dbCollection.AddFilter("product_id", "=", productID); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := dbCollection.AddFilter("expires_at", ">", time.Now()); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if excludeHolderID != "" {
+		if err := dbCollection.AddFilter("holder_id", "!=", excludeHolderID); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	return dbCollection.Load()
+}
+
+// GetAvailableQty returns stock qty for a requested product-options pair lowered by active reservations
+//   - reservations of given holder are not taken into account, so holder could keep qty it have reserved
+//   - blank holder means all reservations are taken into account
+func (it *DefaultStock) GetAvailableQty(productID string, options map[string]interface{}, holderID string) int {
+
+	var qtySetFlag bool
+	var minQty int
+
+	dbCollection, err := db.GetCollection(ConstCollectionNameStock)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return minQty
+	}
+
+	if err := dbCollection.AddFilter("product_id", "=", productID); err != nil {
+		_ = env.ErrorDispatch(err)
+		return minQty
+	}
+
+	dbRecords, err := dbCollection.Load()
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return minQty
+	}
+
+	reservations, err := getActiveReservations(productID, holderID)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	// each stock record is lowered by reservations of options it applies to
+	for _, dbRecord := range dbRecords {
+		recordOptions, ok := dbRecord["options"].(map[string]interface{})
+		if !ok || !utils.MatchMapAValuesToMapB(recordOptions, options) {
+			continue
+		}
+
+		qty := utils.InterfaceToInt(dbRecord["qty"])
+		for _, reservation := range reservations {
+			if utils.MatchMapAValuesToMapB(recordOptions, utils.InterfaceToMap(reservation["options"])) {
+				qty -= utils.InterfaceToInt(reservation["qty"])
+			}
+		}
+
+		if !qtySetFlag || qty < minQty {
+			minQty = qty
+			qtySetFlag = true
+		}
+	}
+
+	if minQty < 0 {
+		minQty = 0
+	}
+
+	return minQty
+}
+
+// ReserveProductQty reserves qty of product-options pair for a holder (cart), previous reservation of the same pair
+// made by holder is replaced and expiration time is renewed
+//   - zero qty removes reservation
+//   - returns error if there is not enough available qty to reserve
+//   - does nothing if reservations are disabled
+//   - reservations of product are made one at a time, new reservation is checked again after it was saved as other
+//     process could reserve the same qty meanwhile, so the one not fitting available qty is removed
+func (it *DefaultStock) ReserveProductQty(holderID string, productID string, options map[string]interface{}, qty int) error {
+	ttl := getReservationTTL()
+	if ttl <= 0 || holderID == "" {
+		return nil
+	}
+
+	defer lockProductReservations(productID)()
+
+	dbCollection, err := db.GetCollection(ConstCollectionNameStockReservation)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	// expired reservations are not used anymore, so they are cleaned up along with new ones made
+	if err := dbCollection.AddFilter("expires_at", "<=", time.Now()); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if _, err := dbCollection.Delete(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := dbCollection.ClearFilters(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := dbCollection.AddFilter("holder_id", "=", holderID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := dbCollection.AddFilter("product_id", "=", productID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	dbRecords, err := dbCollection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if qty > 0 {
+		if available := it.GetAvailableQty(productID, options, holderID); available < qty {
+			return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "8c3f05e1-7a2d-4b96-9e48-d16b0f7a23c9", "only "+utils.InterfaceToString(available)+" items of product are available")
+		}
+	}
+
+	// new reservation is saved before previous one is removed, so holder keeps previous one if new does not fit
+	if qty > 0 {
+		reservationID, err := dbCollection.Save(map[string]interface{}{
+			"holder_id":  holderID,
+			"product_id": productID,
+			"options":    options,
+			"qty":        qty,
+			"expires_at": time.Now().Add(ttl),
+		})
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+
+		if available := it.GetAvailableQty(productID, options, holderID); available < qty {
+			if err := dbCollection.DeleteByID(reservationID); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+			return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4d7a9c2e-61b3-4f58-8e0a-b5c3d9f1e246", "only "+utils.InterfaceToString(available)+" items of product are available")
+		}
+	}
+
+	for _, dbRecord := range dbRecords {
+		recordOptions := utils.InterfaceToMap(dbRecord["options"])
+		if utils.MatchMapAValuesToMapB(recordOptions, options) && utils.MatchMapAValuesToMapB(options, recordOptions) {
+			if err := dbCollection.DeleteByID(utils.InterfaceToString(dbRecord["_id"])); err != nil {
+				return env.ErrorDispatch(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetReservations returns reservations made by a holder, each one is a record with "product_id", "options" and "qty"
+func (it *DefaultStock) GetReservations(holderID string) ([]map[string]interface{}, error) {
+	if holderID == "" {
+		return nil, nil
+	}
+
+	dbCollection, err := db.GetCollection(ConstCollectionNameStockReservation)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := dbCollection.AddFilter("holder_id", "=", holderID); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return dbCollection.Load()
+}
+
+// ReleaseReservations removes all reservations made by a holder
+func (it *DefaultStock) ReleaseReservations(holderID string) error {
+	if holderID == "" {
+		return nil
+	}
+
+	dbCollection, err := db.GetCollection(ConstCollectionNameStockReservation)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := dbCollection.AddFilter("holder_id", "=", holderID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if _, err := dbCollection.Delete(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package stock_test
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/ottemo/foundation/env"
@@ -467,6 +468,212 @@ func testDuplicatesForSetInventory(t *testing.T) {
 	}
 }
 
+// TestStockReservation validates reserved qty to be unavailable for other holders
+func TestStockReservation(t *testing.T) {
+	// start app
+	err := test.StartAppInTestingMode()
+	if err != nil {
+		t.Error(err)
+	}
+
+	db.RegisterOnDatabaseStart(func () error {
+		testStockReservation(t)
+		return nil
+	})
+}
+
+// testStockReservation validates reserved qty to be unavailable for other holders
+func testStockReservation(t *testing.T) {
+	initConfig(t)
+
+	productData, err := utils.DecodeJSONToStringKeyMap(`{
+		"sku": "test 5",
+		"name": "Test Product 5",
+		"price": 1,
+		"weight": 1,
+		"qty": 10,
+		"options": {
+			"color": {
+				"order": 1,
+				"required": true,
+				"options": {
+					"black": {"sku": "-black"},
+					"blue":  {"sku": "-blue"}
+				}
+			}
+		}
+	}`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	productModel, err := product.GetProductModel()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = productModel.FromHashMap(productData)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = productModel.Save()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func(p product.InterfaceProduct){
+		if err := p.Delete(); err != nil {
+			t.Error(err)
+		}
+	}(productModel)
+
+	productID := productModel.GetID()
+	registeredStock := product.GetRegisteredStock()
+
+	optionsBlack := map[string]interface{}{"color": "black"}
+	if err := registeredStock.SetProductQty(productID, optionsBlack, 3); err != nil {
+		t.Error(err)
+	}
+
+	if err := registeredStock.ReserveProductQty("holderA", productID, optionsBlack, 2); err != nil {
+		t.Error(err)
+	}
+	defer func() {
+		if err := registeredStock.ReleaseReservations("holderA"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// reservation lowers available qty for others, but not for holder itself
+	qtyBlack := registeredStock.GetAvailableQty(productID, optionsBlack, "holderB")
+	qtyBlackOwn := registeredStock.GetAvailableQty(productID, optionsBlack, "holderA")
+	qty := registeredStock.GetAvailableQty(productID, map[string]interface{}{}, "holderB")
+	if qtyBlack != 1 || qtyBlackOwn != 3 || qty != 8 {
+		msg := fmt.Sprintln("Reservation error")
+		msg += fmt.Sprintln("\t qty(color=black):", qtyBlack, "(1 expected)")
+		msg += fmt.Sprintln("\t qty(color=black) of holder:", qtyBlackOwn, "(3 expected)")
+		msg += fmt.Sprintln("\t qty:", qty, "(8 expected)")
+
+		t.Error(msg)
+		return
+	}
+
+	if reservations, err := registeredStock.GetReservations("holderA"); err != nil || len(reservations) != 1 || utils.InterfaceToInt(reservations[0]["qty"]) != 2 {
+		t.Error("Holder should have one reservation of 2 items:", reservations, err)
+	}
+
+	// stock qty is not changed by reservation
+	if qty := registeredStock.GetProductQty(productID, optionsBlack); qty != 3 {
+		t.Error("Stock qty should not be changed by reservation:", qty, "(3 expected)")
+		return
+	}
+
+	if err := registeredStock.ReserveProductQty("holderB", productID, optionsBlack, 2); err == nil {
+		t.Error("Should be error, because only 1 item is available")
+		return
+	}
+
+	if err := registeredStock.ReleaseReservations("holderA"); err != nil {
+		t.Error(err)
+	}
+	if qty := registeredStock.GetAvailableQty(productID, optionsBlack, "holderB"); qty != 3 {
+		t.Error("Released qty should be available:", qty, "(3 expected)")
+	}
+}
+
+// TestConcurrentStockReservation validates concurrent reservations not to exceed stock qty
+func TestConcurrentStockReservation(t *testing.T) {
+	// start app
+	err := test.StartAppInTestingMode()
+	if err != nil {
+		t.Error(err)
+	}
+
+	db.RegisterOnDatabaseStart(func () error {
+		testConcurrentStockReservation(t)
+		return nil
+	})
+}
+
+// testConcurrentStockReservation validates concurrent reservations not to exceed stock qty
+func testConcurrentStockReservation(t *testing.T) {
+	initConfig(t)
+
+	productData, err := utils.DecodeJSONToStringKeyMap(`{
+		"sku": "test 6",
+		"name": "Test Product 6",
+		"price": 1,
+		"weight": 1,
+		"qty": 3
+	}`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	productModel, err := product.GetProductModel()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = productModel.FromHashMap(productData)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = productModel.Save()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func(p product.InterfaceProduct){
+		if err := p.Delete(); err != nil {
+			t.Error(err)
+		}
+	}(productModel)
+
+	productID := productModel.GetID()
+	registeredStock := product.GetRegisteredStock()
+	options := map[string]interface{}{}
+
+	var waitGroup sync.WaitGroup
+	var reservedMutex sync.Mutex
+	var reserved int
+
+	for i := 0; i < 10; i++ {
+		holderID := "concurrentHolder" + utils.InterfaceToString(i)
+		defer func() {
+			if err := registeredStock.ReleaseReservations(holderID); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if err := registeredStock.ReserveProductQty(holderID, productID, options, 1); err == nil {
+				reservedMutex.Lock()
+				reserved++
+				reservedMutex.Unlock()
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	if reserved != 3 {
+		t.Error("Reserved qty should not exceed stock qty:", reserved, "(3 expected)")
+	}
+	if qty := registeredStock.GetAvailableQty(productID, options, ""); qty != 0 {
+		t.Error("No qty should be available:", qty, "(0 expected)")
+	}
+}
+
 // initConfig initializes configuration for tests
 func initConfig(t *testing.T) {
 	if config := env.GetConfig(); config != nil {
@@ -0,0 +1,247 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/store"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("store/current", APIGetCurrentStore)
+
+	// Admin Only
+	service.GET("stores", api.IsAdminHandler(APIListStores))
+	service.GET("stores/attributes", api.IsAdminHandler(APIListStoreAttributes))
+	service.GET("store/:storeID", api.IsAdminHandler(APIGetStore))
+	service.POST("store", api.IsAdminHandler(APICreateStore))
+	service.PUT("store/:storeID", api.IsAdminHandler(APIUpdateStore))
+	service.DELETE("store/:storeID", api.IsAdminHandler(APIDeleteStore))
+
+	service.GET("store/visibility/:objectType/:objectID", api.IsAdminHandler(APIGetObjectStores))
+	service.PUT("store/visibility/:objectType/:objectID", api.IsAdminHandler(APISetObjectStores))
+
+	return nil
+}
+
+// APIGetCurrentStore returns public information about store request was made to
+//   - blank store id means there are no stores configured (or no default one for unknown host)
+func APIGetCurrentStore(context api.InterfaceApplicationContext) (interface{}, error) {
+	storeID := api.GetCurrentStoreID()
+	result := map[string]interface{}{"_id": storeID}
+
+	if storeID != "" {
+		storeModel, err := store.LoadStoreByID(storeID)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+
+		result["code"] = storeModel.GetCode()
+		result["name"] = storeModel.GetName()
+	}
+
+	return result, nil
+}
+
+// APIListStoreAttributes returns a list of store attributes
+func APIListStoreAttributes(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	storeModel, err := store.GetStoreModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return storeModel.GetAttributesInfo(), nil
+}
+
+// APIListStores returns a list of existing stores
+//   - if "action" parameter is set to "count" result value will be just a number of list items
+func APIListStores(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	storeCollectionModel, err := store.GetStoreCollectionModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	// applying request filters
+	if err := models.ApplyFilters(context, storeCollectionModel.GetDBCollection()); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "2b7e4c9a-d615-4f38-8a0e-9c3d1f7b5e26", err.Error())
+	}
+
+	// checking for a "count" request
+	if context.GetRequestArgument(api.ConstRESTActionParameter) == "count" {
+		return storeCollectionModel.GetDBCollection().Count()
+	}
+
+	// limit parameter handle
+	if err := storeCollectionModel.ListLimit(models.GetListLimit(context)); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e8a3d6f1-7b24-4c59-b0d2-4f6e9a1c3b87", err.Error())
+	}
+
+	// extra parameter handle
+	if err := models.ApplyExtraAttributes(context, storeCollectionModel); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5c1f9b3e-a482-4d67-9e0b-2d8c7a4f1e53", err.Error())
+	}
+
+	return storeCollectionModel.List()
+}
+
+// APIGetStore returns specified store information
+//   - store id should be specified in "storeID" argument
+func APIGetStore(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	storeID := context.GetRequestArgument("storeID")
+	if storeID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "9a6d2e8c-3f71-4b05-a4e9-1c7b5f3d8a62", "store id should be specified")
+	}
+
+	storeModel, err := store.LoadStoreByID(storeID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return storeModel.ToHashMap(), nil
+}
+
+// APICreateStore creates a new store
+//   - store attributes should be specified in request content, "code" attribute is required
+func APICreateStore(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	requestData, err := api.GetRequestContentAsMap(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	storeModel, err := store.GetStoreModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	for attribute, value := range requestData {
+		if err := storeModel.Set(attribute, value); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "f3c8a1d6-4e97-4b25-8d0f-6a2e9c5b7d14", err.Error())
+		}
+	}
+
+	if err := storeModel.SetID(""); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7d4b9e2f-c156-4a83-b9e0-3f1a6d8c2e47", err.Error())
+	}
+	if err := storeModel.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return storeModel.ToHashMap(), nil
+}
+
+// APIUpdateStore updates existing store
+//   - store id should be specified in "storeID" argument
+func APIUpdateStore(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	storeID := context.GetRequestArgument("storeID")
+	if storeID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "1e7a5c3d-b928-4f64-a0d5-8b2f6e9c4a31", "store id should be specified")
+	}
+
+	requestData, err := api.GetRequestContentAsMap(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	storeModel, err := store.LoadStoreByID(storeID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	for attribute, value := range requestData {
+		if err := storeModel.Set(attribute, value); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c5e2f8b4-9a13-4d76-8e0c-4b7d1a6f3e95", err.Error())
+		}
+	}
+
+	if err := storeModel.SetID(storeID); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8b1d6a4f-e375-4c29-9f0a-5e3c8d2b7f16", err.Error())
+	}
+	if err := storeModel.Save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return storeModel.ToHashMap(), nil
+}
+
+// APIDeleteStore deletes existing store
+//   - store id should be specified in "storeID" argument
+func APIDeleteStore(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	storeID := context.GetRequestArgument("storeID")
+	if storeID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4f9c3b7e-2d58-4a61-b8e3-7a1f5c9d2b84", "store id should be specified")
+	}
+
+	storeModel, err := store.GetStoreModelAndSetID(storeID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := storeModel.Delete(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
+
+// APIGetObjectStores returns ids of stores object is visible within, blank list means all stores
+//   - object type ("product", "category", "cms_page", "seo") should be specified in "objectType" argument
+//   - object id should be specified in "objectID" argument
+func APIGetObjectStores(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	visibility := store.GetRegisteredVisibility()
+	if visibility == nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a2e7c5d9-6b14-4f83-9d0e-1c8f4a7b3e52", "store visibility service is not registered")
+	}
+
+	return visibility.GetObjectStores(context.GetRequestArgument("objectType"), context.GetRequestArgument("objectID"))
+}
+
+// APISetObjectStores replaces stores object is visible within
+//   - object type ("product", "category", "cms_page", "seo") should be specified in "objectType" argument
+//   - object id should be specified in "objectID" argument
+//   - "stores" content attribute is a list of store ids, blank list makes object visible within all stores
+func APISetObjectStores(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	objectType := context.GetRequestArgument("objectType")
+	objectID := context.GetRequestArgument("objectID")
+	if !utils.IsAmongStr(objectType, store.ConstObjectTypeProduct, store.ConstObjectTypeCategory, store.ConstObjectTypeCMSPage, store.ConstObjectTypeSEO) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "6d3f1a8e-c249-4b57-a0e6-9f2b7d5c1e38", "unknown object type '"+objectType+"'")
+	}
+	if objectID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "e1b8d4c7-5a36-4f92-8e0d-3c6a9f2b7d45", "object id should be specified")
+	}
+
+	requestData, err := api.GetRequestContentAsMap(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	storeIDs := utils.InterfaceToStringArray(requestData["stores"])
+	for _, storeID := range storeIDs {
+		if _, err := store.LoadStoreByID(storeID); err != nil {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3a9e6c2f-d714-4b85-b1e0-7f5d2a8c4e69", "unknown store '"+storeID+"'")
+		}
+	}
+
+	visibility := store.GetRegisteredVisibility()
+	if visibility == nil {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9c5b2e7d-a843-4f16-b9e2-6d1f8a3c5b74", "store visibility service is not registered")
+	}
+
+	if err := visibility.SetObjectStores(objectType, objectID, storeIDs); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return visibility.GetObjectStores(objectType, objectID)
+}
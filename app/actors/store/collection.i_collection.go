@@ -0,0 +1,86 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/store"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// List enumerates items of store model
+func (it *DefaultStoreCollection) List() ([]models.StructListItem, error) {
+	var result []models.StructListItem
+
+	dbRecords, err := it.listCollection.Load()
+	if err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	for _, dbRecordData := range dbRecords {
+		storeModel, err := store.GetStoreModel()
+		if err != nil {
+			return result, env.ErrorDispatch(err)
+		}
+		if err := storeModel.FromHashMap(dbRecordData); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3e8b1d6f-c527-4a94-8f0e-1b6d9a4c7e32", err.Error())
+		}
+
+		// retrieving minimal data needed for list
+		resultItem := new(models.StructListItem)
+
+		resultItem.ID = storeModel.GetID()
+		resultItem.Name = storeModel.GetName()
+		resultItem.Image = ""
+		resultItem.Desc = storeModel.GetCode()
+
+		// if extra attributes were required
+		if len(it.listExtraAtributes) > 0 {
+			resultItem.Extra = make(map[string]interface{})
+
+			for _, attributeName := range it.listExtraAtributes {
+				resultItem.Extra[attributeName] = storeModel.Get(attributeName)
+			}
+		}
+
+		result = append(result, *resultItem)
+	}
+
+	return result, nil
+}
+
+// ListAddExtraAttribute allows to obtain additional attributes from  List() function
+func (it *DefaultStoreCollection) ListAddExtraAttribute(attribute string) error {
+
+	if utils.IsAmongStr(attribute, "_id", "id", "code", "name", "hosts", "default", "enabled", "created_at", "updated_at") {
+		if !utils.IsInListStr(attribute, it.listExtraAtributes) {
+			it.listExtraAtributes = append(it.listExtraAtributes, attribute)
+		} else {
+			return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9d4f7b2a-e831-4c66-b5a0-2c7e9f1d3b84", "attribute already in list")
+		}
+	} else {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "62c9e5a1-7f3b-4d08-a9e4-5b1d8c3f7a26", "not allowed attribute")
+	}
+
+	return nil
+}
+
+// ListFilterAdd adds selection filter to List() function
+func (it *DefaultStoreCollection) ListFilterAdd(Attribute string, Operator string, Value interface{}) error {
+	if err := it.listCollection.AddFilter(Attribute, Operator, Value); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	return nil
+}
+
+// ListFilterReset clears presets made by ListFilterAdd() and ListAddExtraAttribute() functions
+func (it *DefaultStoreCollection) ListFilterReset() error {
+	if err := it.listCollection.ClearFilters(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	return nil
+}
+
+// ListLimit sets select pagination
+func (it *DefaultStoreCollection) ListLimit(offset int, limit int) error {
+	return it.listCollection.SetLimit(offset, limit)
+}
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/store"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+)
+
+// GetModelName returns model name
+func (it *DefaultStoreCollection) GetModelName() string {
+	return store.ConstModelNameStoreCollection
+}
+
+// GetImplementationName returns model implementation name
+func (it *DefaultStoreCollection) GetImplementationName() string {
+	return "Default" + store.ConstModelNameStoreCollection
+}
+
+// New returns new instance of model implementation object
+func (it *DefaultStoreCollection) New() (models.InterfaceModel, error) {
+	dbCollection, err := db.GetCollection(ConstCollectionNameStore)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return &DefaultStoreCollection{listCollection: dbCollection, listExtraAtributes: make([]string, 0)}, nil
+}
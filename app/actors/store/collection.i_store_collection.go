@@ -0,0 +1,36 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/app/models/store"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+)
+
+// GetDBCollection returns database collection
+func (it *DefaultStoreCollection) GetDBCollection() db.InterfaceDBCollection {
+	return it.listCollection
+}
+
+// ListStores returns list of store model items
+func (it *DefaultStoreCollection) ListStores() []store.InterfaceStore {
+	var result []store.InterfaceStore
+
+	dbRecords, err := it.listCollection.Load()
+	if err != nil {
+		return result
+	}
+
+	for _, recordData := range dbRecords {
+		storeModel, err := store.GetStoreModel()
+		if err != nil {
+			return result
+		}
+		if err := storeModel.FromHashMap(recordData); err != nil {
+			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a26d8f3c-4e19-4b75-9c0d-6f2e8b1a5d47", err.Error())
+		}
+
+		result = append(result, storeModel)
+	}
+
+	return result
+}
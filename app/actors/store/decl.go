@@ -0,0 +1,57 @@
+// Package store is a default implementation of store related interfaces declared in
+// "github.com/ottemo/foundation/app/models/store" package
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstCollectionNameStore           = "store"
+	ConstCollectionNameStoreVisibility = "store_visibility"
+
+	ConstHostsCacheTTL = 60 // seconds hosts to stores map is kept before reload
+
+	ConstErrorModule = "store"
+	ConstErrorLevel  = env.ConstErrorLevelActor
+)
+
+// Package global variables
+var (
+	hostsCache        map[string]string // request host to store id map
+	hostsCacheDefault string            // id of store used for unknown hosts
+	hostsCacheTime    time.Time         // time hosts map was loaded
+	hostsCacheMutex   sync.RWMutex      // synchronization on hosts map access
+
+	visibilityCache      = make(map[string]map[string][]string) // object type to object id to assigned stores map
+	visibilityCacheMutex sync.RWMutex                           // synchronization on visibility map access
+	visibilityWriteMutex sync.Mutex                             // synchronization on object stores assignment
+)
+
+// DefaultStore is a default implementer of InterfaceStore
+type DefaultStore struct {
+	id string
+
+	Code    string
+	Name    string
+	Hosts   []string
+	Default bool
+	Enabled bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DefaultStoreCollection is a default implementer of InterfaceStoreCollection
+type DefaultStoreCollection struct {
+	listCollection     db.InterfaceDBCollection
+	listExtraAtributes []string
+}
+
+// DefaultStoreVisibility is a default implementer of InterfaceStoreVisibility
+type DefaultStoreVisibility struct{}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/store"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
+)
+
+// init makes package self-initialization routine
+func init() {
+	storeInstance := new(DefaultStore)
+	var _ store.InterfaceStore = storeInstance
+	if err := models.RegisterModel(store.ConstModelNameStore, storeInstance); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d7b3e9a1-5c48-4f26-b0e7-3a9c1f6d8e52", err.Error())
+	}
+
+	storeCollectionInstance := new(DefaultStoreCollection)
+	var _ store.InterfaceStoreCollection = storeCollectionInstance
+	if err := models.RegisterModel(store.ConstModelNameStoreCollection, storeCollectionInstance); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4a1f8c6e-e293-4b57-9d0a-7c5e2b8f1d39", err.Error())
+	}
+
+	visibilityInstance := new(DefaultStoreVisibility)
+	var _ store.InterfaceStoreVisibility = visibilityInstance
+	if err := store.RegisterVisibility(visibilityInstance); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b9e5d2a7-1f64-4c83-a0b6-8d3f7e1c5a24", err.Error())
+	}
+
+	if err := api.RegisterStoreResolver(resolveStore); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6e2c9f4b-a837-4d15-8b1e-5f9a3c7d2e68", err.Error())
+	}
+
+	db.RegisterOnDatabaseStart(setupDB)
+	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "store",
+		Description: "Multi-store support",
+		Core:        true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// setupDB prepares system database for package usage
+func setupDB() error {
+	collection, err := db.GetCollection(ConstCollectionNameStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddColumn("code", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("name", db.ConstTypeVarchar, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("hosts", db.TypeArrayOf(db.ConstTypeVarchar), false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("default", db.ConstTypeBoolean, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("enabled", db.ConstTypeBoolean, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("created_at", db.ConstTypeDatetime, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("updated_at", db.ConstTypeDatetime, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	collection, err = db.GetCollection(ConstCollectionNameStoreVisibility)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddColumn("store_id", db.ConstTypeID, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("object_type", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("object_id", db.ConstTypeID, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
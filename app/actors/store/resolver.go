@@ -0,0 +1,87 @@
+package store
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// resolveStore is a store resolver returning id of store request host belongs to
+//   - default store is used for unknown hosts, blank value returned if there is no default store
+func resolveStore(context api.InterfaceApplicationContext) string {
+	request, ok := context.GetRequest().(*http.Request)
+	if !ok {
+		return ""
+	}
+
+	return getStoreIDByHost(normalizeHost(request.Host))
+}
+
+// getStoreIDByHost returns id of enabled store given host belongs to, hosts map is reloaded after TTL expiration
+func getStoreIDByHost(host string) string {
+	hostsCacheMutex.RLock()
+	isValid := hostsCache != nil && time.Now().Sub(hostsCacheTime) < ConstHostsCacheTTL*time.Second
+	hostsCacheMutex.RUnlock()
+
+	if !isValid {
+		if err := loadHostsCache(); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	hostsCacheMutex.RLock()
+	defer hostsCacheMutex.RUnlock()
+
+	if storeID, present := hostsCache[host]; present {
+		return storeID
+	}
+	return hostsCacheDefault
+}
+
+// loadHostsCache reads hosts of enabled stores from database
+func loadHostsCache() error {
+	collection, err := db.GetCollection(ConstCollectionNameStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("enabled", "=", true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	hosts := make(map[string]string)
+	defaultStoreID := ""
+	for _, record := range records {
+		storeID := utils.InterfaceToString(record["_id"])
+		for _, host := range utils.InterfaceToStringArray(record["hosts"]) {
+			hosts[normalizeHost(host)] = storeID
+		}
+		if utils.InterfaceToBool(record["default"]) {
+			defaultStoreID = storeID
+		}
+	}
+
+	hostsCacheMutex.Lock()
+	hostsCache = hosts
+	hostsCacheDefault = defaultStoreID
+	hostsCacheTime = time.Now()
+	hostsCacheMutex.Unlock()
+
+	return nil
+}
+
+// resetHostsCache makes hosts map to be reloaded on next request
+func resetHostsCache() {
+	hostsCacheMutex.Lock()
+	hostsCache = nil
+	hostsCacheMutex.Unlock()
+}
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/store"
+)
+
+// GetCollection returns collection of current instance type
+func (it *DefaultStore) GetCollection() models.InterfaceCollection {
+	model, _ := models.GetModel(store.ConstModelNameStoreCollection)
+	if result, ok := model.(store.InterfaceStoreCollection); ok {
+		return result
+	}
+
+	return nil
+}
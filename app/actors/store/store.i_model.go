@@ -0,0 +1,21 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/store"
+)
+
+// GetModelName returns model name
+func (it *DefaultStore) GetModelName() string {
+	return store.ConstModelNameStore
+}
+
+// GetImplementationName returns model implementation name
+func (it *DefaultStore) GetImplementationName() string {
+	return "DefaultStore"
+}
+
+// New returns new instance of model implementation object
+func (it *DefaultStore) New() (models.InterfaceModel, error) {
+	return &DefaultStore{Enabled: true}, nil
+}
@@ -0,0 +1,211 @@
+package store
+
+import (
+	"strings"
+
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/store"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// Get returns object attribute value or nil
+func (it *DefaultStore) Get(attribute string) interface{} {
+	switch strings.ToLower(attribute) {
+	case "_id", "id":
+		return it.GetID()
+	case "code":
+		return it.GetCode()
+	case "name":
+		return it.GetName()
+	case "hosts":
+		return it.GetHosts()
+	case "default":
+		return it.IsDefault()
+	case "enabled":
+		return it.GetEnabled()
+	case "created_at":
+		return it.CreatedAt
+	case "updated_at":
+		return it.UpdatedAt
+	}
+
+	return nil
+}
+
+// Set sets attribute value to object or returns error
+func (it *DefaultStore) Set(attribute string, value interface{}) error {
+	attribute = strings.ToLower(attribute)
+
+	switch attribute {
+	case "_id", "id":
+		return it.SetID(utils.InterfaceToString(value))
+	case "code":
+		return it.SetCode(utils.InterfaceToString(value))
+	case "name":
+		return it.SetName(utils.InterfaceToString(value))
+	case "hosts":
+		// hosts could be given as list or as comma/new line separated string
+		if stringValue, ok := value.(string); ok {
+			return it.SetHosts(strings.FieldsFunc(stringValue, func(r rune) bool { return r == ',' || r == '\n' }))
+		}
+		return it.SetHosts(utils.InterfaceToStringArray(value))
+	case "default":
+		return it.SetDefault(utils.InterfaceToBool(value))
+	case "enabled":
+		return it.SetEnabled(utils.InterfaceToBool(value))
+	case "created_at":
+		it.CreatedAt = utils.InterfaceToTime(value)
+		return nil
+	case "updated_at":
+		it.UpdatedAt = utils.InterfaceToTime(value)
+		return nil
+	}
+
+	return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8e3c6a1f-b254-4d97-9f0e-2a7d5c9b1e64", "unknown attribute '"+attribute+"'")
+}
+
+// FromHashMap fills object attributes from map[string]interface{}
+func (it *DefaultStore) FromHashMap(input map[string]interface{}) error {
+
+	for attribute, value := range input {
+		if err := it.Set(attribute, value); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// ToHashMap represents object as map[string]interface{}
+func (it *DefaultStore) ToHashMap() map[string]interface{} {
+
+	result := make(map[string]interface{})
+
+	result["_id"] = it.id
+
+	result["code"] = it.Get("code")
+	result["name"] = it.Get("name")
+	result["hosts"] = it.Get("hosts")
+	result["default"] = it.Get("default")
+	result["enabled"] = it.Get("enabled")
+	result["created_at"] = it.Get("created_at")
+	result["updated_at"] = it.Get("updated_at")
+
+	return result
+}
+
+// GetAttributesInfo returns information about object attributes
+func (it *DefaultStore) GetAttributesInfo() []models.StructAttributeInfo {
+
+	info := []models.StructAttributeInfo{
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "_id",
+			Type:       db.ConstTypeID,
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "ID",
+			Group:      "General",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "code",
+			Type:       db.ConstTypeVarchar,
+			IsRequired: true,
+			IsStatic:   true,
+			Label:      "Code",
+			Group:      "General",
+			Editors:    "line_text",
+			Options:    "",
+			Default:    "",
+			Validators: "sku",
+		},
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "name",
+			Type:       db.ConstTypeVarchar,
+			IsRequired: true,
+			IsStatic:   true,
+			Label:      "Name",
+			Group:      "General",
+			Editors:    "line_text",
+			Options:    "",
+			Default:    "",
+		},
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "hosts",
+			Type:       db.TypeArrayOf(db.ConstTypeVarchar),
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Hosts",
+			Group:      "General",
+			Editors:    "multiline_text",
+			Options:    "",
+			Default:    "",
+		},
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "default",
+			Type:       db.ConstTypeBoolean,
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Default",
+			Group:      "General",
+			Editors:    "boolean",
+			Options:    "",
+			Default:    "",
+		},
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "enabled",
+			Type:       db.ConstTypeBoolean,
+			IsRequired: false,
+			IsStatic:   true,
+			Label:      "Enabled",
+			Group:      "General",
+			Editors:    "boolean",
+			Options:    "",
+			Default:    "",
+		},
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "created_at",
+			Type:       db.ConstTypeDatetime,
+			IsRequired: true,
+			IsStatic:   true,
+			Label:      "Created At",
+			Group:      "General",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
+		models.StructAttributeInfo{
+			Model:      store.ConstModelNameStore,
+			Collection: ConstCollectionNameStore,
+			Attribute:  "updated_at",
+			Type:       db.ConstTypeDatetime,
+			IsRequired: true,
+			IsStatic:   true,
+			Label:      "Updated At",
+			Group:      "General",
+			Editors:    "not_editable",
+			Options:    "",
+			Default:    "",
+		},
+	}
+
+	return info
+}
@@ -0,0 +1,157 @@
+package store
+
+import (
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// GetID returns id for store
+func (it *DefaultStore) GetID() string {
+	return it.id
+}
+
+// SetID sets id for store
+func (it *DefaultStore) SetID(newID string) error {
+	it.id = newID
+	return nil
+}
+
+// Load loads store information from DB
+func (it *DefaultStore) Load(id string) error {
+	collection, err := db.GetCollection(ConstCollectionNameStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	dbValues, err := collection.LoadByID(id)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := it.SetID(utils.InterfaceToString(dbValues["_id"])); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1c7e4a9d-3b58-4f26-a0d1-8e5f2c6b9a37", err.Error())
+	}
+
+	it.Code = utils.InterfaceToString(dbValues["code"])
+	it.Name = utils.InterfaceToString(dbValues["name"])
+	it.Hosts = utils.InterfaceToStringArray(dbValues["hosts"])
+	it.Default = utils.InterfaceToBool(dbValues["default"])
+	it.Enabled = utils.InterfaceToBool(dbValues["enabled"])
+	it.CreatedAt = utils.InterfaceToTime(dbValues["created_at"])
+	it.UpdatedAt = utils.InterfaceToTime(dbValues["updated_at"])
+
+	return nil
+}
+
+// Delete removes current store and its objects visibility assignments from DB
+func (it *DefaultStore) Delete() error {
+	collection, err := db.GetCollection(ConstCollectionNameStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.DeleteByID(it.GetID()); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	visibilityCollection, err := db.GetCollection(ConstCollectionNameStoreVisibility)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := visibilityCollection.AddFilter("store_id", "=", it.GetID()); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if _, err := visibilityCollection.Delete(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	resetHostsCache()
+	resetVisibilityCache("")
+
+	return nil
+}
+
+// Save stores current store to DB
+//   - store code should be unique, only one store can be default one
+func (it *DefaultStore) Save() error {
+	if it.GetCode() == "" {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "7b2f9d4e-a163-4c85-8e0a-5d1c7f3b9e62", "store code should be specified")
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("code", "=", it.GetCode()); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	for _, record := range records {
+		if utils.InterfaceToString(record["_id"]) != it.GetID() {
+			return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "e4a81c6b-2d97-4f53-b0e6-9c3a7d1f5b28", "store with code '"+it.GetCode()+"' already exists")
+		}
+	}
+
+	// packing data before save
+	storingValues := make(map[string]interface{})
+
+	storingValues["_id"] = it.GetID()
+
+	storingValues["code"] = it.GetCode()
+	storingValues["name"] = it.GetName()
+	storingValues["hosts"] = it.GetHosts()
+	storingValues["default"] = it.IsDefault()
+	storingValues["enabled"] = it.GetEnabled()
+
+	currentTime := time.Now()
+
+	if it.CreatedAt.IsZero() {
+		it.CreatedAt = currentTime
+	}
+	storingValues["created_at"] = it.CreatedAt
+
+	it.UpdatedAt = currentTime
+	storingValues["updated_at"] = it.UpdatedAt
+
+	if err := collection.ClearFilters(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	newID, err := collection.Save(storingValues)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := it.SetID(newID); err != nil {
+		_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5f9c2e7a-b418-4d36-a1e5-7c8b3d6f2a91", err.Error())
+	}
+
+	// there is only one default store
+	if it.IsDefault() {
+		if err := collection.AddFilter("default", "=", true); err != nil {
+			return env.ErrorDispatch(err)
+		}
+		records, err := collection.Load()
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+		for _, record := range records {
+			if utils.InterfaceToString(record["_id"]) != it.GetID() {
+				record["default"] = false
+				if _, err := collection.Save(record); err != nil {
+					return env.ErrorDispatch(err)
+				}
+			}
+		}
+	}
+
+	resetHostsCache()
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"strings"
+)
+
+// GetCode returns store code
+func (it *DefaultStore) GetCode() string {
+	return it.Code
+}
+
+// SetCode sets store code value
+func (it *DefaultStore) SetCode(newValue string) error {
+	it.Code = newValue
+	return nil
+}
+
+// GetName returns store name
+func (it *DefaultStore) GetName() string {
+	return it.Name
+}
+
+// SetName sets store name value
+func (it *DefaultStore) SetName(newValue string) error {
+	it.Name = newValue
+	return nil
+}
+
+// GetHosts returns hosts store is resolved by
+func (it *DefaultStore) GetHosts() []string {
+	return it.Hosts
+}
+
+// SetHosts sets hosts store is resolved by, hosts are normalized to lower case without port
+func (it *DefaultStore) SetHosts(newValue []string) error {
+	var hosts []string
+	for _, host := range newValue {
+		if host = normalizeHost(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	it.Hosts = hosts
+	return nil
+}
+
+// IsDefault returns true if store is used for requests to unknown hosts
+func (it *DefaultStore) IsDefault() bool {
+	return it.Default
+}
+
+// SetDefault sets store default flag
+func (it *DefaultStore) SetDefault(newValue bool) error {
+	it.Default = newValue
+	return nil
+}
+
+// GetEnabled returns store enabled flag
+func (it *DefaultStore) GetEnabled() bool {
+	return it.Enabled
+}
+
+// SetEnabled sets store enabled flag
+func (it *DefaultStore) SetEnabled(newValue bool) error {
+	it.Enabled = newValue
+	return nil
+}
+
+// normalizeHost makes host value comparable: lower case, no port, no spaces
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if index := strings.LastIndex(host, ":"); index > 0 && !strings.HasSuffix(host, "]") {
+		host = host[:index]
+	}
+	return host
+}
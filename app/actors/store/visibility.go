@@ -0,0 +1,185 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// getVisibilityMap returns object id to assigned stores map for given object type, map is loaded on first access
+func getVisibilityMap(objectType string) map[string][]string {
+	visibilityCacheMutex.RLock()
+	result, present := visibilityCache[objectType]
+	visibilityCacheMutex.RUnlock()
+
+	if present {
+		return result
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameStoreVisibility)
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return nil
+	}
+
+	if err := collection.AddFilter("object_type", "=", objectType); err != nil {
+		_ = env.ErrorDispatch(err)
+		return nil
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		_ = env.ErrorDispatch(err)
+		return nil
+	}
+
+	result = make(map[string][]string)
+	for _, record := range records {
+		objectID := utils.InterfaceToString(record["object_id"])
+		result[objectID] = append(result[objectID], utils.InterfaceToString(record["store_id"]))
+	}
+
+	visibilityCacheMutex.Lock()
+	visibilityCache[objectType] = result
+	visibilityCacheMutex.Unlock()
+
+	return result
+}
+
+// resetVisibilityCache makes visibility map of given object type (all types for blank value) to be reloaded
+func resetVisibilityCache(objectType string) {
+	visibilityCacheMutex.Lock()
+	if objectType == "" {
+		visibilityCache = make(map[string]map[string][]string)
+	} else {
+		delete(visibilityCache, objectType)
+	}
+	visibilityCacheMutex.Unlock()
+}
+
+// GetObjectStores returns ids of stores object is assigned to, blank list means object is visible within all stores
+func (it *DefaultStoreVisibility) GetObjectStores(objectType string, objectID string) ([]string, error) {
+	collection, err := db.GetCollection(ConstCollectionNameStoreVisibility)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("object_type", "=", objectType); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if err := collection.AddFilter("object_id", "=", objectID); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	result := make([]string, 0, len(records))
+	for _, record := range records {
+		result = append(result, utils.InterfaceToString(record["store_id"]))
+	}
+
+	return result, nil
+}
+
+// SetObjectStores replaces stores object is assigned to, blank list makes object visible within all stores
+//   - there are no transactions on database layer, so new assignments are saved before obsolete ones are deleted,
+//     failure in the middle leaves object restricted to previous or new stores, but never visible within all of them
+func (it *DefaultStoreVisibility) SetObjectStores(objectType string, objectID string, storeIDs []string) error {
+	visibilityWriteMutex.Lock()
+	defer visibilityWriteMutex.Unlock()
+
+	defer resetVisibilityCache(objectType)
+
+	collection, err := db.GetCollection(ConstCollectionNameStoreVisibility)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("object_type", "=", objectType); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddFilter("object_id", "=", objectID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	// store id to record id map of existing assignments
+	existing := make(map[string]string)
+	for _, record := range records {
+		existing[utils.InterfaceToString(record["store_id"])] = utils.InterfaceToString(record["_id"])
+	}
+
+	if err := collection.ClearFilters(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	var added []string
+	required := make(map[string]bool)
+	for _, storeID := range storeIDs {
+		if storeID == "" || required[storeID] {
+			continue
+		}
+		required[storeID] = true
+
+		if _, present := existing[storeID]; present {
+			continue
+		}
+
+		record := map[string]interface{}{
+			"store_id":    storeID,
+			"object_type": objectType,
+			"object_id":   objectID,
+		}
+		recordID, err := collection.Save(record)
+		if err != nil {
+			// rolling back assignments made by this call
+			for _, addedID := range added {
+				if err := collection.DeleteByID(addedID); err != nil {
+					_ = env.ErrorDispatch(err)
+				}
+			}
+			return env.ErrorDispatch(err)
+		}
+		added = append(added, recordID)
+	}
+
+	for storeID, recordID := range existing {
+		if required[storeID] {
+			continue
+		}
+		if err := collection.DeleteByID(recordID); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// IsVisible checks if object is visible within given store
+func (it *DefaultStoreVisibility) IsVisible(storeID string, objectType string, objectID string) bool {
+	storeIDs, present := getVisibilityMap(objectType)[objectID]
+	if !present {
+		return true
+	}
+
+	return utils.IsInListStr(storeID, storeIDs)
+}
+
+// GetHiddenIDs returns ids of objects of given type not visible within given store
+func (it *DefaultStoreVisibility) GetHiddenIDs(storeID string, objectType string) []string {
+	var result []string
+	for objectID, storeIDs := range getVisibilityMap(objectType) {
+		if !utils.IsInListStr(storeID, storeIDs) {
+			result = append(result, objectID)
+		}
+	}
+
+	return result
+}
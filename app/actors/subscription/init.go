@@ -7,6 +7,7 @@ import (
 	"github.com/ottemo/foundation/app/models/subscription"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -28,6 +29,15 @@ func init() {
 
 	api.RegisterOnRestServiceStart(setupAPI)
 	env.RegisterOnConfigStart(setupConfig)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "subscription",
+		Description:  "Recurring orders subscriptions",
+		Dependencies: []string{"checkout"},
+		Routes:       []string{"subscription*", "visit/subscriptions*", "update/subscriptions"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func onDatabaseStart() error {
@@ -126,12 +136,12 @@ func onAppStart() error {
 
 	subscriptionProducts = products
 
-	env.EventRegisterListener("checkout.success", checkoutSuccessHandler)
-	env.EventRegisterListener("product.getOptions", getOptionsExtend)
+	extension.EventRegisterListener("subscription", "checkout.success", checkoutSuccessHandler)
+	extension.EventRegisterListener("subscription", "product.getOptions", getOptionsExtend)
 
 	// process order creation every one hour
 	if scheduler := env.GetScheduler(); scheduler != nil {
-		if err := scheduler.RegisterTask(ConstSchedulerTaskName, placeOrders); err != nil {
+		if err := extension.RegisterTask("subscription", ConstSchedulerTaskName, placeOrders); err != nil {
 			_ = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6f4451a3-6f11-404c-86b5-d6dab58bcd44", err.Error())
 		}
 		if _, err := scheduler.ScheduleRepeat(
@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 	"github.com/ottemo/foundation/media"
 )
 
@@ -11,6 +12,15 @@ import (
 func init() {
 	app.OnAppStart(onAppStart)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "swatch",
+		Description:  "Product option swatches",
+		Dependencies: []string{"product"},
+		Routes:       []string{"swatch/*"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 func onAppStart() error {
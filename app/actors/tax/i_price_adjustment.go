@@ -4,6 +4,7 @@ import (
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/utils"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 
 	"github.com/ottemo/foundation/app/models/checkout"
 )
@@ -27,7 +28,8 @@ func (it *DefaultTax) GetPriority() []float64 {
 // processRecords processes records from database collection
 func (it *DefaultTax) processRecords(records []map[string]interface{}, result []checkout.StructPriceAdjustment) []checkout.StructPriceAdjustment {
 	for _, record := range records {
-		amount := utils.InterfaceToFloat64(record["rate"])
+		// rate is taken with Money precision, so percent of amount is calculated exactly
+		amount := utils.InterfaceToMoney(record["rate"]).Float64()
 
 		taxRate := checkout.StructPriceAdjustment{
 			Code:      utils.InterfaceToString(record["code"]),
@@ -45,9 +47,113 @@ func (it *DefaultTax) processRecords(records []map[string]interface{}, result []
 	return result
 }
 
+// getTaxRates loads tax rate records applicable for a destination, rates for any state and zip code are followed
+// by rates of the state and rates of the zip code
+func getTaxRates(state string, zip string) []map[string]interface{} {
+	var result []map[string]interface{}
+
+	if dbEngine := db.GetDBEngine(); dbEngine != nil {
+		if collection, err := dbEngine.GetCollection("Taxes"); err == nil {
+			if err := collection.AddFilter("state", "=", "*"); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "6ff4f7b9-204d-4cef-933e-1b50c0a7810f", err.Error())
+			}
+			if err := collection.AddFilter("zip", "=", "*"); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "56e7bcc3-da2f-4b14-a9d5-207144bab513", err.Error())
+			}
+
+			if records, err := collection.Load(); err == nil {
+				result = append(result, records...)
+			}
+
+			if err := collection.ClearFilters(); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "45f36a5a-7039-4383-af0a-5d7014fd2972", err.Error())
+			}
+			if err := collection.AddFilter("state", "=", state); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "48cf0d87-3335-4276-9a9c-97d400fe3229", err.Error())
+			}
+			if err := collection.AddFilter("zip", "=", "*"); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "0dcafa80-5e1a-4848-8761-4aad8b9e7fff", err.Error())
+			}
+
+			if records, err := collection.Load(); err == nil {
+				result = append(result, records...)
+			}
+
+			if err := collection.ClearFilters(); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "7c2fb5ab-15f9-4d61-a0e7-a4611796d783", err.Error())
+			}
+			if err := collection.AddFilter("state", "=", state); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "500125dd-5e8f-4130-9877-b5df489373dd", err.Error())
+			}
+			if err := collection.AddFilter("zip", "=", zip); err != nil {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "e5f12dd7-70ca-4563-aa51-1bfd913ac797", err.Error())
+			}
+
+			if records, err := collection.Load(); err == nil {
+				result = append(result, records...)
+			}
+		}
+	}
+
+	return result
+}
+
+// processGroupRecords makes taxes of a shipping group, rates of group destination are applied to group items and to
+// group shipping amount which is shared between items in proportion to their totals
+//   - items with zero total (non taxable) get no shipping share
+func (it *DefaultTax) processGroupRecords(groupCheckout checkout.InterfaceCheckout, result []checkout.StructPriceAdjustment) []checkout.StructPriceAdjustment {
+	shippingAddress := groupCheckout.GetShippingAddress()
+	if shippingAddress == nil {
+		return result
+	}
+
+	var itemIndexes []string
+	var itemsTotal float64
+	for _, cartItem := range groupCheckout.GetItems() {
+		itemIndex := utils.InterfaceToString(cartItem.GetIdx())
+		itemIndexes = append(itemIndexes, itemIndex)
+		itemsTotal += groupCheckout.GetItemSpecificTotal(itemIndex, checkout.ConstLabelGrandTotal)
+	}
+
+	if itemsTotal <= 0 {
+		return result
+	}
+
+	taxableRatio := (itemsTotal + groupCheckout.GetShippingAmount()) / itemsTotal
+
+	for _, record := range getTaxRates(shippingAddress.GetState(), shippingAddress.GetZipCode()) {
+		amount := utils.InterfaceToMoney(record["rate"]).Float64()
+
+		// items are taxed on increased percent, so their tax includes tax of their shipping share
+		perItem := make(map[string]float64)
+		for _, itemIndex := range itemIndexes {
+			perItem[itemIndex] = amount * taxableRatio
+		}
+
+		taxRate := checkout.StructPriceAdjustment{
+			Code:      utils.InterfaceToString(record["code"]),
+			Name:      it.GetName(),
+			Amount:    amount,
+			IsPercent: true,
+			Priority:  priority,
+			Labels:    []string{checkout.ConstLabelTax},
+			PerItem:   perItem,
+		}
+
+		priority += float64(0.00001)
+		result = append(result, taxRate)
+	}
+
+	return result
+}
+
 // Calculate calculates a taxes for a given checkout
 func (it *DefaultTax) Calculate(currentCheckout checkout.InterfaceCheckout, currentPriority float64) []checkout.StructPriceAdjustment {
 	var result []checkout.StructPriceAdjustment
+	if !extension.IsEnabled("tax") {
+		return result
+	}
+
 	priority = ConstPriorityValue
 
 	if currentPriority == checkout.ConstCalculateTargetSubtotal || currentPriority == checkout.ConstCalculateTargetGrandTotal {
@@ -119,52 +225,19 @@ func (it *DefaultTax) Calculate(currentCheckout checkout.InterfaceCheckout, curr
 		return result
 	}
 
-	if shippingAddress := currentCheckout.GetShippingAddress(); shippingAddress != nil {
-		state := shippingAddress.GetState()
-		zip := shippingAddress.GetZipCode()
-
-		if dbEngine := db.GetDBEngine(); dbEngine != nil {
-			if collection, err := dbEngine.GetCollection("Taxes"); err == nil {
-				if err := collection.AddFilter("state", "=", "*"); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "6ff4f7b9-204d-4cef-933e-1b50c0a7810f", err.Error())
-				}
-				if err := collection.AddFilter("zip", "=", "*"); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "56e7bcc3-da2f-4b14-a9d5-207144bab513", err.Error())
-				}
-
-				if records, err := collection.Load(); err == nil {
-					result = it.processRecords(records, result)
-				}
-
-				if err := collection.ClearFilters(); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "45f36a5a-7039-4383-af0a-5d7014fd2972", err.Error())
-				}
-				if err := collection.AddFilter("state", "=", state); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "48cf0d87-3335-4276-9a9c-97d400fe3229", err.Error())
-				}
-				if err := collection.AddFilter("zip", "=", "*"); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "0dcafa80-5e1a-4848-8761-4aad8b9e7fff", err.Error())
-				}
-
-				if records, err := collection.Load(); err == nil {
-					result = it.processRecords(records, result)
-				}
-
-				if err := collection.ClearFilters(); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "7c2fb5ab-15f9-4d61-a0e7-a4611796d783", err.Error())
-				}
-				if err := collection.AddFilter("state", "=", state); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "500125dd-5e8f-4130-9877-b5df489373dd", err.Error())
-				}
-				if err := collection.AddFilter("zip", "=", zip); err != nil {
-					_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "e5f12dd7-70ca-4563-aa51-1bfd913ac797", err.Error())
-				}
-
-				if records, err := collection.Load(); err == nil {
-					result = it.processRecords(records, result)
-				}
+	// items split over several destinations are taxed by rates of their own destination
+	if shippingGroups := currentCheckout.GetShippingGroups(); len(shippingGroups) > 0 {
+		for groupIdx := range shippingGroups {
+			if groupCheckout := currentCheckout.GetShippingGroupCheckout(groupIdx); groupCheckout != nil {
+				result = it.processGroupRecords(groupCheckout, result)
 			}
 		}
+
+		return result
+	}
+
+	if shippingAddress := currentCheckout.GetShippingAddress(); shippingAddress != nil {
+		result = it.processRecords(getTaxRates(shippingAddress.GetState(), shippingAddress.GetZipCode()), result)
 	}
 
 	return result
@@ -30,6 +30,8 @@ func setupAPI() error {
 	service.PUT("visitor/:visitorID", APIUpdateVisitor)
 	service.DELETE("visitor/:visitorID", api.IsAdminHandler(APIDeleteVisitor))
 	service.GET("visitor/:visitorID", api.IsAdminHandler(APIGetVisitor))
+	service.GET("visitor/:visitorID/sessions", api.IsAdminHandler(APIListVisitorSessions))
+	service.DELETE("visitor/:visitorID/sessions", api.IsAdminHandler(APICloseVisitorSessions))
 
 	service.GET("visitors", api.IsAdminHandler(APIListVisitors))
 	service.GET("visitors/attributes", APIListVisitorAttributes)
@@ -54,6 +56,9 @@ func setupAPI() error {
 	service.POST("visit/login", APILogin)
 	service.POST("visit/login-facebook", APIFacebookLogin)
 	service.POST("visit/login-google", APIGoogleLogin)
+	service.GET("visit/sessions", APIListVisitSessions)
+	service.DELETE("visit/sessions", APICloseVisitSessions)
+	service.DELETE("visit/sessions/:sessionKey", APICloseVisitSession)
 
 	return nil
 }
@@ -288,6 +293,10 @@ func APIDeleteVisitor(context api.InterfaceApplicationContext) (interface{}, err
 		return nil, env.ErrorDispatch(err)
 	}
 
+	if err := api.GetSessionService().CloseOwnerSessions(visitorID, ""); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
 	return "ok", nil
 }
 
@@ -557,7 +566,9 @@ func APIRegisterVisitor(context api.InterfaceApplicationContext) (interface{}, e
 		}
 	} else {
 		// log visitor in, if site is not using verification emails
-		context.GetSession().Set(visitor.ConstSessionKeyVisitorID, visitorModel.GetID())
+		if _, err := loginVisitor(context, visitorModel.GetID()); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
 	}
 
 	return visitorModel.ToHashMap(), nil
@@ -786,6 +797,7 @@ func APILogout(context api.InterfaceApplicationContext) (interface{}, error) {
 
 // APILogin makes login for a current visit
 //   - "email" and "password" attributes required
+//   - returns "ok" or login details, like "cart_merge" summary of guest cart merged into visitor cart
 func APILogin(context api.InterfaceApplicationContext) (interface{}, error) {
 
 	// check request context
@@ -807,6 +819,9 @@ func APILogin(context api.InterfaceApplicationContext) (interface{}, error) {
 		rootPassword := utils.InterfaceToString(env.ConfigGetValue(app.ConstConfigPathStoreRootPassword))
 
 		if requestLogin == rootLogin && requestPassword == rootPassword {
+			if _, err := api.RegenerateSession(context); err != nil {
+				return nil, env.ErrorDispatch(err)
+			}
 			context.GetSession().Set(api.ConstSessionKeyAdminRights, true)
 
 			return "ok", nil
@@ -832,22 +847,27 @@ func APILogin(context api.InterfaceApplicationContext) (interface{}, error) {
 	}
 
 	// api session updates
+	var loginDetails map[string]interface{}
 	if visitorModel.IsVerified() {
-		context.GetSession().Set(visitor.ConstSessionKeyVisitorID, visitorModel.GetID())
+		if loginDetails, err = loginVisitor(context, visitorModel.GetID()); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
 	} else {
-		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "29fba7a4-bd85-400e-81c2-69189c50d0d0", "This account has not been verfied, please check your email account: ,"+visitorModel.GetEmail()+" for a verification link sent to you.")
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "29fba7a4-bd85-400e-81c2-69189c50d0d0", "This account has not been verfied, please check your email account: ,"+visitorModel.GetEmail()+" for a verification link sent to you.")
+		return nil, env.ErrorWithDetails(err, map[string]interface{}{"email": visitorModel.GetEmail()})
 	}
 
 	if visitorModel.IsAdmin() {
 		context.GetSession().Set(api.ConstSessionKeyAdminRights, true)
 	}
 
-	return "ok", nil
+	return getLoginResult(loginDetails), nil
 }
 
 // APIFacebookLogin makes login and/or registration via Facebook
 //   - "access_token" and "user_id" arguments required needed
 //   - visitor attributes will be taken from Facebook
+//   - returns "ok" or login details, like "cart_merge" summary of guest cart merged into visitor cart
 func APIFacebookLogin(context api.InterfaceApplicationContext) (interface{}, error) {
 	// check request context
 	//---------------------
@@ -950,18 +970,22 @@ func APIFacebookLogin(context api.InterfaceApplicationContext) (interface{}, err
 	}
 
 	// api session updates
-	context.GetSession().Set(visitor.ConstSessionKeyVisitorID, visitorModel.GetID())
+	loginDetails, err := loginVisitor(context, visitorModel.GetID())
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
 
 	if visitorModel.IsAdmin() {
 		context.GetSession().Set(api.ConstSessionKeyAdminRights, true)
 	}
 
-	return "ok", nil
+	return getLoginResult(loginDetails), nil
 }
 
 // APIGoogleLogin associates the specified email address with a Google account
 //   - "access_token" attribute needed
 //   - visitor attributes will be taken from Google
+//   - returns "ok" or login details, like "cart_merge" summary of guest cart merged into visitor cart
 func APIGoogleLogin(context api.InterfaceApplicationContext) (interface{}, error) {
 	// check request context
 	//---------------------
@@ -1051,13 +1075,16 @@ func APIGoogleLogin(context api.InterfaceApplicationContext) (interface{}, error
 	}
 
 	// api session updates
-	context.GetSession().Set(visitor.ConstSessionKeyVisitorID, visitorModel.GetID())
+	loginDetails, err := loginVisitor(context, visitorModel.GetID())
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
 
 	if visitorModel.IsAdmin() {
 		context.GetSession().Set(api.ConstSessionKeyAdminRights, true)
 	}
 
-	return "ok", nil
+	return getLoginResult(loginDetails), nil
 }
 
 // APIMailToVisitor sends email to specified visitors
@@ -1090,3 +1117,75 @@ func APIMailToVisitor(context api.InterfaceApplicationContext) (interface{}, err
 
 	return "ok", nil
 }
+
+// APIListVisitSessions returns active sessions of current visitor
+func APIListVisitSessions(context api.InterfaceApplicationContext) (interface{}, error) {
+	visitorID := visitor.GetCurrentVisitorID(context)
+	if visitorID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4d7b2a9e-c158-4f03-b6e2-8a1f5c9d3e70", "Not logged in, please login.")
+	}
+
+	return getSessionsList(visitorID, context.GetSession().GetID()), nil
+}
+
+// APICloseVisitSessions closes all sessions of current visitor except current one ("log out everywhere")
+func APICloseVisitSessions(context api.InterfaceApplicationContext) (interface{}, error) {
+	visitorID := visitor.GetCurrentVisitorID(context)
+	if visitorID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "a0e6c3f9-2d71-4b58-9e4a-7c5b1f8d2a63", "Not logged in, please login.")
+	}
+
+	if err := api.GetSessionService().CloseOwnerSessions(visitorID, context.GetSession().GetID()); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
+
+// APICloseVisitSession closes particular session of current visitor
+//   - session key (from sessions list) should be specified in "sessionKey" argument
+func APICloseVisitSession(context api.InterfaceApplicationContext) (interface{}, error) {
+	visitorID := visitor.GetCurrentVisitorID(context)
+	if visitorID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "6f1d8b4c-e297-4a30-85c6-2b9e7d3f1a84", "Not logged in, please login.")
+	}
+
+	sessionKey := context.GetRequestArgument("sessionKey")
+	for _, sessionInfo := range api.GetSessionService().ListOwnerSessions(visitorID) {
+		if getSessionKey(sessionInfo.ID) == sessionKey {
+			if err := api.GetSessionService().Close(sessionInfo.ID); err != nil {
+				return nil, env.ErrorDispatch(err)
+			}
+			return "ok", nil
+		}
+	}
+
+	context.SetResponseStatusNotFound()
+	return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b8c2e5a1-93f4-4d6b-a7e0-1d4f9c6b3e25", "Session not found.")
+}
+
+// APIListVisitorSessions returns active sessions of specified visitor
+//   - visitor id should be specified in "visitorID" argument
+func APIListVisitorSessions(context api.InterfaceApplicationContext) (interface{}, error) {
+	visitorID := context.GetRequestArgument("visitorID")
+	if visitorID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3e9a6d2f-b014-4c87-9f5d-6a2c8e1b7d49", "No Visitor ID found, please specify a Visitor ID.")
+	}
+
+	return getSessionsList(visitorID, context.GetSession().GetID()), nil
+}
+
+// APICloseVisitorSessions closes all sessions of specified visitor (force logout)
+//   - visitor id should be specified in "visitorID" argument
+func APICloseVisitorSessions(context api.InterfaceApplicationContext) (interface{}, error) {
+	visitorID := context.GetRequestArgument("visitorID")
+	if visitorID == "" {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c5f1b8e3-7a26-4d94-b0c8-9e3d6a2f4b71", "No Visitor ID found, please specify a Visitor ID.")
+	}
+
+	if err := api.GetSessionService().CloseOwnerSessions(visitorID, ""); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
@@ -0,0 +1,45 @@
+package visitor
+
+import (
+	"net/http"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// setupErrorCatalog registers package errors API clients could branch on within error catalog
+func setupErrorCatalog() error {
+	items := []env.StructErrorCatalogItem{
+		{
+			Code:          "e7a97b45-a22a-48c5-96f8-f4ecd3f8380f",
+			Name:          "visitor_not_logged_in",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusUnauthorized,
+			Message:       "Not logged in, please login.",
+			Documentation: "Request requires visitor to be logged in within current session.",
+		},
+		{
+			Code:          "9fd0a895-4b42-4d89-9aa7-9104ba23f96a",
+			Name:          "visitor_wrong_password",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusUnauthorized,
+			Message:       "The password entered does not match the stored password.",
+			Documentation: "Visitor login failed as given password is wrong.",
+		},
+		{
+			Code:          "29fba7a4-bd85-400e-81c2-69189c50d0d0",
+			Name:          "visitor_not_verified",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusForbidden,
+			Message:       "This account has not been verified, please check your email account {{.email}} for a verification link sent to you.",
+			Documentation: "Visitor can not login until email is verified. Details contain \"email\" verification link was sent to.",
+		},
+	}
+
+	for _, item := range items {
+		if err := env.ErrorRegisterCatalogItem(item); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package visitor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+
+	"github.com/ottemo/foundation/app/models/visitor"
+)
+
+// loginVisitor makes new session for logged in visitor keeping current session data (to prevent session fixation)
+// and assigns session to visitor, so visitor sessions could be listed and closed
+//   - returns login details other packages added on login event (like guest cart merge summary)
+func loginVisitor(context api.InterfaceApplicationContext, visitorID string) (map[string]interface{}, error) {
+	session, err := api.RegenerateSession(context)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	session.Set(visitor.ConstSessionKeyVisitorID, visitorID)
+
+	if err := api.GetSessionService().SetOwner(session.GetID(), visitorID); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	result := make(map[string]interface{})
+	env.Event(visitor.ConstEventLogin, map[string]interface{}{"session": session, "visitor_id": visitorID, "result": result})
+
+	return result, nil
+}
+
+// getLoginResult returns login API result, it is "ok" unless there are login details to return
+func getLoginResult(loginDetails map[string]interface{}) interface{} {
+	if len(loginDetails) > 0 {
+		return loginDetails
+	}
+	return "ok"
+}
+
+// getSessionKey returns session identifier which is safe to pass to client, as session id itself is a secret
+func getSessionKey(sessionID string) string {
+	hash := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(hash[:8])
+}
+
+// getSessionsList returns information about sessions of given visitor, current session flagged
+func getSessionsList(visitorID string, currentSessionID string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+
+	for _, sessionInfo := range api.GetSessionService().ListOwnerSessions(visitorID) {
+		result = append(result, map[string]interface{}{
+			"key":        getSessionKey(sessionInfo.ID),
+			"updated_at": sessionInfo.UpdatedAt,
+			"current":    sessionInfo.ID == currentSessionID,
+		})
+	}
+
+	return result
+}
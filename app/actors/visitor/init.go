@@ -6,6 +6,7 @@ import (
 	"github.com/ottemo/foundation/app/models/visitor"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init makes package self-initialization routine
@@ -25,6 +26,15 @@ func init() {
 	db.RegisterOnDatabaseStart(setupDB)
 	api.RegisterOnRestServiceStart(setupAPI)
 	env.RegisterOnConfigStart(setupConfig)
+	env.RegisterOnConfigIniStart(setupErrorCatalog)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "visitor",
+		Description: "Visitor accounts",
+		Core:        true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -6,6 +6,7 @@ import (
 	"github.com/ottemo/foundation/app/models/visitor"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init makes package self-initialization routine
@@ -24,6 +25,15 @@ func init() {
 
 	db.RegisterOnDatabaseStart(setupDB)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:         "visitor_token",
+		Description:  "Visitor payment tokens",
+		Dependencies: []string{"visitor"},
+		Core:         true,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // DB preparations for current model implementation
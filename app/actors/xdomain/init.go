@@ -3,6 +3,7 @@ package xdomain
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/extension"
 )
 
 // init performs self-initialization routine before app start
@@ -10,6 +11,14 @@ func init() {
 
 	env.RegisterOnConfigIniStart(setupIniConfig)
 	api.RegisterOnRestServiceStart(setupAPI)
+
+	if err := extension.Register(extension.StructManifest{
+		Name:        "xdomain",
+		Description: "Cross domain requests proxy",
+		Routes:      []string{"proxy.html"},
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupIniConfig reads the setting from the ottemo.ini file
@@ -24,6 +24,7 @@ func setupAPI() error {
 	service.GET("app/status", restStatusInfo)
 	service.POST("app/location", setSessionTimeZone)
 	service.GET("app/location", getSessionTimeZone)
+	service.GET("app/errors", restErrorCatalog)
 
 	return nil
 }
@@ -53,6 +54,10 @@ func restLogin(context api.InterfaceApplicationContext) (interface{}, error) {
 	rootPassword := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathStoreRootPassword))
 
 	if requestLogin == rootLogin && requestPassword == rootPassword {
+		// new session id for raised privileges, to prevent session fixation
+		if _, err := api.RegenerateSession(context); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
 		context.GetSession().Set(api.ConstSessionKeyAdminRights, true)
 
 		return "ok", nil
@@ -250,3 +255,20 @@ func setSessionTimeZone(context api.InterfaceApplicationContext) (interface{}, e
 
 	return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "a33f0f5d-2110-4208-8fb6-023da3ffd241", "time zone should be specified")
 }
+
+// WEB REST API function to get catalog of well known errors, so clients could localize or branch on them
+//   - "module" argument could be used to limit result to particular module errors
+func restErrorCatalog(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	module := context.GetRequestArgument("module")
+
+	result := make([]env.StructErrorCatalogItem, 0)
+	for _, item := range env.ErrorGetCatalog() {
+		if module != "" && item.Module != module {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
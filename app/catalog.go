@@ -0,0 +1,37 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// setupErrorCatalog registers package errors API clients could branch on within error catalog
+func setupErrorCatalog() error {
+	items := []env.StructErrorCatalogItem{
+		{
+			Code:          "fee28a56-adb1-44b9-a0e2-1c9be6bd6fdb",
+			Name:          "app_login_credentials_required",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusBadRequest,
+			Message:       "Login and password should be specified.",
+			Documentation: "Root login requires \"login\" and \"password\" to be given as request arguments or content.",
+		},
+		{
+			Code:          "68546aa8-a6be-4c31-ac44-ea4278dfbdb0",
+			Name:          "app_login_failed",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusUnauthorized,
+			Message:       "Wrong login or password.",
+			Documentation: "Given credentials do not match root login and password set within store config.",
+		},
+	}
+
+	for _, item := range items {
+		if err := env.ErrorRegisterCatalogItem(item); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
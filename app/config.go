@@ -424,6 +424,27 @@ func setupConfig() error {
 		return env.ErrorDispatch(err)
 	}
 
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathShutdownTimeout,
+		Value:       ConstDefaultShutdownTimeout,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Shutdown timeout",
+		Description: "seconds application waits for in-flight requests, async API calls and scheduled tasks to finish on stop",
+		Image:       "",
+	}, func(value interface{}) (interface{}, error) {
+		timeout := utils.InterfaceToInt(value)
+		if timeout < 0 {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "6b2d8e4a-f137-4c59-a0e6-3d9b7c1f5a28", "shutdown timeout should not be negative")
+		}
+		return timeout, nil
+	})
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
 	// API settings
 	err = config.RegisterItem(env.StructConfigItem{
 		Path:        rest.ConstConfigPathAPI,
@@ -47,6 +47,9 @@ const (
 
 	ConstConfigPathVerfifyEmail = ConstConfigPathAppGroup + ".verifyemail"
 
+	ConstConfigPathShutdownTimeout = "general.app.shutdown_timeout"
+	ConstDefaultShutdownTimeout    = 30 // seconds application waits for in-flight requests and background jobs on stop
+
 	ConstErrorModule = "app"
 	ConstErrorLevel  = env.ConstErrorLevelService
 
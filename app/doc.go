@@ -9,5 +9,10 @@ functions for administrator login, system configuration values, etc. So, this pa
 application instance. Ottemo packages should address this package to interact with running application instance but not
 to "github.com/ottemo/foundaton" package".
 
+Application end is graceful: readiness health check starts failing, HTTP listener stops accepting connections and
+in-flight requests, async API calls and scheduled tasks are waited for up to "general.app.shutdown_timeout" seconds.
+Then stop event callbacks (OnAppStop) flush sessions and other in-memory state, and end event callbacks (OnAppEnd)
+are fired after all.
+
 */
 package app
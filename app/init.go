@@ -3,10 +3,24 @@ package app
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
 )
 
 // init makes package self-initialization routine
 func init() {
 	env.RegisterOnConfigStart(setupConfig)
 	api.RegisterOnRestServiceStart(setupAPI)
+	env.RegisterOnConfigIniStart(setupErrorCatalog)
+
+	if err := health.RegisterProbe("app", health.ConstKindReadiness, true, startedProbe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// startedProbe is a readiness probe which fails until application start event is processed
+func startedProbe() error {
+	if !IsStarted() {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "a2f6c8d1-3e7b-4950-8c1d-9b4e0f6a2d73", "application is not started yet")
+	}
+	return nil
 }
@@ -3,8 +3,12 @@ package app
 import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
+	"github.com/ottemo/foundation/utils"
 
+	"strconv"
 	"sync"
+	"time"
 )
 
 // Package global variables
@@ -14,6 +18,9 @@ var (
 	startFlag bool
 	endFlag   bool
 
+	// application runs command line command, so background processing (scheduler, job workers) is not started
+	commandMode bool
+
 	// synchronize locks to prevent simultaneous processing
 	initMutex  sync.RWMutex
 	startMutex sync.RWMutex
@@ -22,6 +29,7 @@ var (
 	// registered callbacks for application events
 	callbacksOnAppInit  = []func() error{}
 	callbacksOnAppStart = []func() error{}
+	callbacksOnAppStop  = []func() error{}
 	callbacksOnAppEnd   = []func() error{}
 )
 
@@ -44,7 +52,13 @@ func OnAppStart(callback func() error) {
 	}
 }
 
-// OnAppEnd registers callback function on application start event
+// OnAppStop registers callback function on application stop event, it happens on application end after in-flight
+// requests and background jobs were finished (or shutdown timeout expired), but before end event
+func OnAppStop(callback func() error) {
+	callbacksOnAppStop = append(callbacksOnAppStop, callback)
+}
+
+// OnAppEnd registers callback function on application end event
 func OnAppEnd(callback func() error) {
 	callbacksOnAppEnd = append(callbacksOnAppEnd, callback)
 }
@@ -90,6 +104,8 @@ func Start() error {
 			}
 		}
 		startFlag = true
+		health.SetDraining(false)
+		api.ResumeOperations()
 	}
 
 	return nil
@@ -103,6 +119,11 @@ func End() error {
 	if !endFlag {
 		endFlag = true
 
+		// readiness check fails since now, so no new traffic is routed to application
+		health.SetDraining(true)
+
+		stop()
+
 		for _, callback := range callbacksOnAppEnd {
 			if err := callback(); err != nil {
 				return env.ErrorDispatch(err)
@@ -117,6 +138,52 @@ func End() error {
 	return nil
 }
 
+// stop waits for in-flight requests and background operations up to configured timeout, then fires application
+// stop event
+func stop() {
+	timeout := time.Duration(ConstDefaultShutdownTimeout) * time.Second
+	if value := env.ConfigGetValue(ConstConfigPathShutdownTimeout); value != nil {
+		timeout = time.Duration(utils.InterfaceToInt(value)) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	// new connections are not accepted since now
+	if restService := api.GetRestService(); restService != nil {
+		if err := restService.Stop(timeout); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	// async API calls and scheduled tasks
+	if running := api.StopOperations(deadline.Sub(time.Now())); running > 0 {
+		err := env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c3e7a9d1-4f26-4b8e-9a05-d6b1f8e2c473", strconv.Itoa(running)+" background operations were not finished in "+timeout.String())
+		_ = env.ErrorDispatch(err)
+	}
+
+	for _, callback := range callbacksOnAppStop {
+		if err := callback(); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+}
+
+// SetCommandMode makes application to run without background processing, it supposed to be called before Start()
+func SetCommandMode(value bool) {
+	commandMode = value
+}
+
+// IsCommandMode returns true if application runs command line command rather than HTTP server
+func IsCommandMode() bool {
+	return commandMode
+}
+
+// IsStarted returns true if application start event was processed
+func IsStarted() bool {
+	startMutex.RLock()
+	defer startMutex.RUnlock()
+	return startFlag
+}
+
 // Serve runs HTTP server in current go routine
 func Serve() error {
 	return api.GetRestService().Run()
@@ -80,40 +80,13 @@ func GetCurrentCart(context api.InterfaceApplicationContext, createNew bool) (In
 	if sessionCartID != nil && sessionCartID != "" {
 		// cart id was found in session - loading cart by id
 		sessionCart, err := LoadCartByID(utils.InterfaceToString(sessionCartID))
-		if err == nil && sessionCart != nil {
-
-			if visitorID != nil && sessionCart.GetVisitorID() == "" {
-				visitorCart, err := GetCartForVisitor(utils.InterfaceToString(visitorID))
-				if err == nil && visitorCart != nil {
-					for _, item := range sessionCart.GetItems() {
-						if _, err := visitorCart.AddItem(item.GetProductID(), item.GetQty(), item.GetOptions()); err != nil {
-							return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "971c7278-0db5-4101-a5a7-b12c096dfa83", "unable to add item to visitor cart: "+err.Error())
-						}
-					}
-
-					if err := visitorCart.SetSessionID(context.GetSession().GetID()); err != nil {
-						return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "de31bc21-4073-47be-b773-e409f9f4ed55", "unable to set session for visitor cart: "+err.Error())
-					}
-
-					if err := visitorCart.Save(); err != nil {
-						return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "6a9da8ec-f1a8-401a-9dec-209220f642af", "unable to save visitor cart: "+err.Error())
-					}
-
-					if err := sessionCart.Delete(); err != nil {
-						return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "8dfd01f9-2ffb-4347-84dc-ad3c3c67d0fd", "unable to delete session cart: "+err.Error())
-					}
-
-					context.GetSession().Set(ConstSessionKeyCurrentCart, visitorCart.GetID())
-
-					return visitorCart, nil
-				}
-			}
-
+		// guest cart is merged into visitor cart on login, so it is not used for visitor
+		if err == nil && sessionCart != nil && (visitorID == nil || sessionCart.GetVisitorID() != "") {
 			return sessionCart, nil
 		}
 	}
 
-	// no cart id was in session, trying to get cart for visitor
+	// no visitor cart was in session, trying to get cart for visitor
 	if visitorID != nil {
 		currentCart, err := GetCartForVisitor(utils.InterfaceToString(visitorID))
 		if err != nil {
@@ -137,6 +110,9 @@ func GetCurrentCart(context api.InterfaceApplicationContext, createNew bool) (In
 			if err := currentCart.SetSessionID(context.GetSession().GetID()); err != nil {
 				return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c5f6b9b8-8cf0-4c6e-bf9f-3900d84ab91c", "unable to set cart session: "+err.Error())
 			}
+			if err := currentCart.SetStoreID(api.GetCurrentStoreID()); err != nil {
+				return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4b8e2d6f-a153-4c97-8e0d-2f6a9c1b7e35", "unable to set cart store: "+err.Error())
+			}
 			if err := currentCart.Activate(); err != nil {
 				return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "1271856b-dd11-4b35-8d71-8676dec7e02f", "unable to activate cart: "+err.Error())
 			}
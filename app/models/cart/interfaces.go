@@ -15,6 +15,20 @@ const (
 	ConstCartModelName         = "Cart"
 	ConstSessionKeyCurrentCart = "cart_id"
 
+	ConstEventMerged = "cart.merged" // data: "session", "cart", "visitor_id", "previous_session_id" and "summary"
+
+	// ConstEventAbandonCoupon is emitted when abandoned cart campaign step needs single use coupon, listener should
+	// put code of coupon it made to "code" key, data: "cart_id", "email", "amount", "percent" and "days"
+	ConstEventAbandonCoupon = "cart.abandonCoupon"
+
+	// ConstEventAbandonCouponRevoke is emitted when coupon made for abandoned cart email was not sent, listener should
+	// remove the coupon, data: "code"
+	ConstEventAbandonCouponRevoke = "cart.abandonCouponRevoke"
+
+	ConstCartTypeCart     = "cart"     // visitor shopping cart, only one of them is active
+	ConstCartTypeSaved    = "saved"    // named cart saved by visitor for later
+	ConstCartTypeWishlist = "wishlist" // named list of products visitor wish to buy
+
 	ConstErrorModule = "cart"
 	ConstErrorLevel  = env.ConstErrorLevelModel
 )
@@ -59,6 +73,15 @@ type InterfaceCart interface {
 	GetSessionID() string
 	SetSessionID(sessionID string) error
 
+	GetStoreID() string
+	SetStoreID(storeID string) error
+
+	GetType() string
+	SetType(cartType string) error
+
+	GetName() string
+	SetName(name string) error
+
 	Activate() error
 	Deactivate() error
 	IsActive() bool
@@ -10,6 +10,12 @@ const (
 	ConstConfigPathConfirmationEmail                = "general.checkout.order_confirmation_email"
 	ConstConfigPathSendOrderConfirmEmailToMerchant  = "general.checkout.send_order_confirm_email_to_merchant"
 	ConstConfigPathOversell                         = "general.checkout.oversell"
+	ConstConfigPathRoundingMode                     = "general.checkout.rounding_mode"
+	ConstConfigPathRoundingLevel                    = "general.checkout.rounding_level"
+	ConstConfigPathCalculationTrace                 = "general.checkout.calculation_trace"
+
+	ConstRoundingLevelLine  = "line"  // each item amount is rounded, totals are sums of rounded amounts
+	ConstRoundingLevelTotal = "total" // totals are rounded, rounded total is allocated over items
 
 	ConstConfigPathShippingGroup              = "shipping"
 	ConstConfigPathShippingOriginGroup        = "shipping.origin"
@@ -57,6 +63,10 @@ const (
 
 	ConstDiscountObjectCart = "cart"
 
+	ConstTraceSourceCheckout = "checkout" // calculation trace source of subtotal and shipping amounts
+
+	ConstOrderInfoCalculationTrace = "calculation_trace" // order custom info key calculation trace is stored under
+
 	ConstSessionKeyCurrentCheckout = "Checkout"
 
 	ConstErrorModule = "checkout"
@@ -8,6 +8,7 @@ import (
 	"github.com/ottemo/foundation/app/models/cart"
 	"github.com/ottemo/foundation/app/models/visitor"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
 )
 
 // GetCheckoutModel retrieves current InterfaceCheckout model implementation
@@ -167,3 +168,39 @@ func ValidateAddress(address interface{}) (visitor.InterfaceVisitorAddress, erro
 
 	return visitorAddress, nil
 }
+
+// GetRoundingMode returns configured money rounding mode, half up rounding is used by default
+func GetRoundingMode() string {
+	if mode := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathRoundingMode)); utils.IsRoundingMode(mode) {
+		return mode
+	}
+	return utils.ConstRoundingHalfUp
+}
+
+// IsPerLineRounding returns true if item amounts should be rounded before they are summed up to totals
+func IsPerLineRounding() bool {
+	return utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathRoundingLevel)) != ConstRoundingLevelTotal
+}
+
+// ValidateCheckout evaluates registered restriction rules against checkout, returns violations of all of them
+func ValidateCheckout(checkoutInstance InterfaceCheckout) []StructViolation {
+	result := make([]StructViolation, 0)
+	for _, validationRule := range registeredValidationRules {
+		result = append(result, validationRule.Validate(checkoutInstance)...)
+	}
+	return result
+}
+
+// ViolationsError makes error of checkout restriction rules violations, nil if there are no violations
+func ViolationsError(violations []StructViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, violation := range violations {
+		messages = append(messages, violation.Message)
+	}
+
+	return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "a2d7e5c1-6b94-4f30-8e1d-37c9b0f462a8", strings.Join(messages, "; "))
+}
@@ -30,6 +30,15 @@ type InterfaceCheckout interface {
 	SetShippingRate(shippingRate StructShippingRate) error
 	GetShippingRate() *StructShippingRate
 
+	// shipping groups split items over several destinations, each group has own address, shipping method and rate,
+	// checkout shipping address, method and rate are not used while groups are set
+	SetShippingGroups(groups []StructShippingGroup) error
+	GetShippingGroups() []StructShippingGroup
+
+	// GetShippingGroupCheckout returns checkout limited to items, address, shipping method and rate of a group,
+	// so shipping methods and price adjustments could be asked for a group
+	GetShippingGroupCheckout(groupIdx int) InterfaceCheckout
+
 	// positions in array are not equals to index used for specific total
 	GetItems() []cart.InterfaceCartItem
 	GetDiscountableItems() []cart.InterfaceCartItem
@@ -52,6 +61,9 @@ type InterfaceCheckout interface {
 	CalculateAmount(calculateTarget float64) float64
 	GetGrandTotal() float64
 
+	// GetCalculationTrace returns steps of checkout calculation, tracing is made regardless of settings
+	GetCalculationTrace() []StructCalculationStep
+
 	SetCart(checkoutCart cart.InterfaceCart) error
 	GetCart() cart.InterfaceCart
 
@@ -114,6 +126,15 @@ type InterfacePriceAdjustment interface {
 	Calculate(checkoutInstance InterfaceCheckout, currentPriority float64) []StructPriceAdjustment
 }
 
+// InterfaceValidationRule represents interface to access business layer implementation of checkout restriction rule,
+// rules are evaluated on cart changes and checkout submit
+type InterfaceValidationRule interface {
+	GetName() string
+	GetCode() string
+
+	Validate(checkoutInstance InterfaceCheckout) []StructViolation
+}
+
 // StructShippingRate represents type to hold shipping rate information generated by implementation of InterfaceShippingMethod
 type StructShippingRate struct {
 	Name  string
@@ -121,6 +142,15 @@ type StructShippingRate struct {
 	Price float64
 }
 
+// StructShippingGroup represents type to hold group of checkout items shipped to own address with own shipping
+// method and rate
+type StructShippingGroup struct {
+	Items              []int                  `json:"Items"` // cart item indexes
+	Address            map[string]interface{} `json:"Address"`
+	ShippingMethodCode string                 `json:"ShippingMethodCode"`
+	ShippingRate       StructShippingRate     `json:"ShippingRate"`
+}
+
 // StructPriceAdjustment represents type to hold  information generated by implementation of InterfacePriceAdjustment (calculating entities of checkout)
 type StructPriceAdjustment struct {
 	Code      string             `json:"Code"`
@@ -131,3 +161,22 @@ type StructPriceAdjustment struct {
 	Labels    []string           `json:"Labels"`
 	PerItem   map[string]float64 `json:"PerItem,string"`
 }
+
+// StructViolation represents type to hold checkout restriction rule violation generated by implementation of
+// InterfaceValidationRule
+type StructViolation struct {
+	Rule    string `json:"Rule"`    // code of InterfaceValidationRule implementer
+	ItemIdx int    `json:"ItemIdx"` // index of violating cart item, 0 for violations of whole order
+	Message string `json:"Message"`
+}
+
+// StructCalculationStep represents type to hold checkout calculation trace record, it describes price adjustment applied
+// within calculation and amounts it changed
+type StructCalculationStep struct {
+	Priority   float64               `json:"Priority"`
+	Source     string                `json:"Source"`     // code of InterfacePriceAdjustment implementer
+	Adjustment StructPriceAdjustment `json:"Adjustment"` // price adjustment as it was provided
+	Amount     float64               `json:"Amount"`     // amount applied to cart grand total
+	Deltas     map[string]float64    `json:"Deltas"`     // changes of item grand totals by item index
+	Totals     map[string]float64    `json:"Totals"`     // cart totals by label after step
+}
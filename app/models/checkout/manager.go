@@ -10,6 +10,8 @@ var (
 	registeredPaymentMethods  = make([]InterfacePaymentMethod, 0)
 
 	registeredPriceAdjustments = make([]InterfacePriceAdjustment, 0)
+
+	registeredValidationRules = make([]InterfaceValidationRule, 0)
 )
 
 // RegisterShippingMethod registers given shipping method in system
@@ -51,6 +53,19 @@ func RegisterPriceAdjustment(priceAdjustment InterfacePriceAdjustment) error {
 	return nil
 }
 
+// RegisterValidationRule registers given checkout restriction rule in system
+func RegisterValidationRule(validationRule InterfaceValidationRule) error {
+	for _, registeredRule := range registeredValidationRules {
+		if registeredRule == validationRule {
+			return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "4f9c2b7e-1a58-4d03-b6e2-8c07d3a5f914", "validation rule already registered")
+		}
+	}
+
+	registeredValidationRules = append(registeredValidationRules, validationRule)
+
+	return nil
+}
+
 // GetRegisteredShippingMethods returns list of registered shipping methods
 func GetRegisteredShippingMethods() []InterfaceShippingMethod {
 	return registeredShippingMethods
@@ -65,3 +80,8 @@ func GetRegisteredPaymentMethods() []InterfacePaymentMethod {
 func GetRegisteredPriceAdjustments() []InterfacePriceAdjustment {
 	return registeredPriceAdjustments
 }
+
+// GetRegisteredValidationRules returns list of registered checkout restriction rules
+func GetRegisteredValidationRules() []InterfaceValidationRule {
+	return registeredValidationRules
+}
@@ -0,0 +1,165 @@
+package currency
+
+import (
+	"strings"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/app/models/checkout"
+	"github.com/ottemo/foundation/app/models/product"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// GetBaseCurrency returns code of currency all amounts are calculated and stored in
+func GetBaseCurrency() string {
+	if service := GetRegisteredCurrencyService(); service != nil {
+		return service.GetBaseCurrency()
+	}
+	return ConstDefaultBaseCurrency
+}
+
+// IsDisplayCurrency checks if given currency is one of display currencies of current store
+func IsDisplayCurrency(currencyCode string) bool {
+	service := GetRegisteredCurrencyService()
+	if service == nil {
+		return currencyCode == ConstDefaultBaseCurrency
+	}
+
+	for _, displayCurrency := range service.GetDisplayCurrencies() {
+		if displayCurrency == currencyCode {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSessionCurrency returns currency visitor selected within given session, store default currency is used if
+// there is no selected one or it is not a display currency of current store
+func GetSessionCurrency(session api.InterfaceSession) string {
+	service := GetRegisteredCurrencyService()
+	if service == nil {
+		return ConstDefaultBaseCurrency
+	}
+
+	if session != nil {
+		currencyCode := strings.ToUpper(utils.InterfaceToString(session.Get(ConstSessionKeyCurrency)))
+		if currencyCode != "" && IsDisplayCurrency(currencyCode) {
+			return currencyCode
+		}
+	}
+
+	return service.GetDefaultCurrency()
+}
+
+// GetCurrentCurrency returns currency given request amounts should be presented in
+func GetCurrentCurrency(context api.InterfaceApplicationContext) string {
+	return GetSessionCurrency(context.GetSession())
+}
+
+// GetRate returns amount of given currency one unit of base currency is worth
+func GetRate(currencyCode string) (float64, error) {
+	service := GetRegisteredCurrencyService()
+	if service == nil || currencyCode == service.GetBaseCurrency() {
+		return 1, nil
+	}
+
+	return service.GetRate(currencyCode)
+}
+
+// ConvertFromBase converts base currency amount to given currency, result is rounded to cents
+func ConvertFromBase(amount float64, currencyCode string) (float64, error) {
+	rate, err := GetRate(currencyCode)
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	return utils.RoundPrice(amount * rate), nil
+}
+
+// Convert converts amount from one currency to another, result is rounded to cents
+func Convert(amount float64, fromCurrency string, toCurrency string) (float64, error) {
+	if fromCurrency == toCurrency {
+		return amount, nil
+	}
+
+	fromRate, err := GetRate(fromCurrency)
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+	toRate, err := GetRate(toCurrency)
+	if err != nil {
+		return 0, env.ErrorDispatch(err)
+	}
+
+	return utils.RoundPrice(amount / fromRate * toRate), nil
+}
+
+// GetProductPrice returns product price within given currency - explicit price if product has one, converted base
+// price otherwise
+func GetProductPrice(productID string, basePrice float64, currencyCode string) (float64, error) {
+	if service := GetRegisteredCurrencyService(); service != nil {
+		if price, present := service.GetPriceOverride(productID, currencyCode); present {
+			return price, nil
+		}
+	}
+
+	return ConvertFromBase(basePrice, currencyCode)
+}
+
+// GetBaseEquivalentPrice returns base currency amount product price within given currency corresponds to, so
+// explicit product price is charged after conversion to order currency
+//   - product options price modifications are kept as they are
+func GetBaseEquivalentPrice(productInstance product.InterfaceProduct, currencyCode string) float64 {
+	price := productInstance.GetPrice()
+
+	service := GetRegisteredCurrencyService()
+	if service == nil || currencyCode == "" || currencyCode == service.GetBaseCurrency() {
+		return price
+	}
+
+	override, present := service.GetPriceOverride(productInstance.GetID(), currencyCode)
+	if !present {
+		return price
+	}
+
+	rate, err := service.GetRate(currencyCode)
+	if err != nil || rate <= 0 {
+		return price
+	}
+
+	// product instance keeps price options were applied to, it is loaded again if it can't tell price before
+	var basePrice float64
+	if basePriced, ok := productInstance.(interface {
+		GetBasePrice() float64
+	}); ok {
+		basePrice = basePriced.GetBasePrice()
+	} else {
+		storedProduct, err := product.LoadProductByID(productInstance.GetID())
+		if err != nil {
+			return price
+		}
+		basePrice = storedProduct.GetPrice()
+	}
+
+	return price - basePrice + override/rate
+}
+
+// GetOrderAmount returns currency order was placed in and given base currency amount converted to it by rate
+// fixed on order placement
+func GetOrderAmount(orderObject models.InterfaceObject, amount float64) (string, float64) {
+	currencyCode := utils.InterfaceToString(orderObject.Get(ConstOrderAttributeCurrency))
+	rate := utils.InterfaceToFloat64(orderObject.Get(ConstOrderAttributeRate))
+
+	if currencyCode == "" || rate <= 0 {
+		return GetBaseCurrency(), amount
+	}
+
+	return currencyCode, ConvertByRate(amount, rate)
+}
+
+// ConvertByRate converts base currency amount by given rate, result is rounded to money precision using configured
+// rounding mode, so amounts presented to visitor and charged are rounded the same way
+func ConvertByRate(amount float64, rate float64) float64 {
+	return utils.MoneyFromFloat(amount).Convert(rate, checkout.GetRoundingMode()).Float64()
+}
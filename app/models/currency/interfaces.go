@@ -0,0 +1,47 @@
+// Package currency represents abstraction of business layer currency service - a base currency all amounts are
+// calculated in, display currencies prices are presented and orders are charged in, exchange rates between them and
+// explicit product prices within particular currencies
+package currency
+
+import (
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstDefaultBaseCurrency = "USD"
+
+	ConstSessionKeyCurrency = "currency" // session key visitor selected currency is kept within
+
+	ConstOrderAttributeCurrency = "currency"      // order attribute holding currency order was placed in
+	ConstOrderAttributeRate     = "currency_rate" // order attribute holding base to order currency rate
+
+	ConstErrorModule = "currency"
+	ConstErrorLevel  = env.ConstErrorLevelModel
+)
+
+// InterfaceRateProvider represents interface to access business layer implementation of exchange rates source
+type InterfaceRateProvider interface {
+	GetCode() string
+	GetName() string
+
+	// GetRates returns amounts of currencies provider knows one unit of given base currency is worth
+	GetRates(baseCurrency string) (map[string]float64, error)
+}
+
+// InterfaceCurrencyService represents interface to access business layer implementation of currency service
+//   - display and default currencies could differ per store, base currency is the same for all stores
+type InterfaceCurrencyService interface {
+	GetBaseCurrency() string
+	GetDefaultCurrency() string
+	GetDisplayCurrencies() []string
+
+	GetRate(currencyCode string) (float64, error)
+	GetRates() map[string]float64
+	UpdateRates() error
+
+	GetPriceOverride(productID string, currencyCode string) (float64, bool)
+	GetPriceOverrides(productID string) map[string]float64
+	SetPriceOverride(productID string, currencyCode string, price float64) error
+	RemovePriceOverride(productID string, currencyCode string) error
+}
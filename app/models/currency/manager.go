@@ -0,0 +1,47 @@
+package currency
+
+import (
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global variables
+var (
+	registeredCurrencyService InterfaceCurrencyService
+	registeredRateProviders   = make(map[string]InterfaceRateProvider)
+)
+
+// RegisterCurrencyService registers given currency service in system
+func RegisterCurrencyService(service InterfaceCurrencyService) error {
+	if registeredCurrencyService != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5b9e2d7a-c416-4f83-a0e5-8d3c1b6f9a27", "Already registered")
+	}
+	registeredCurrencyService = service
+
+	return nil
+}
+
+// GetRegisteredCurrencyService returns currently using currency service or nil
+func GetRegisteredCurrencyService() InterfaceCurrencyService {
+	return registeredCurrencyService
+}
+
+// RegisterRateProvider registers given exchange rates provider in system
+func RegisterRateProvider(provider InterfaceRateProvider) error {
+	code := provider.GetCode()
+	if _, present := registeredRateProviders[code]; present {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e1a7c4f9-3b62-4d58-9e0a-6f2d8b5c1e73", "rate provider '"+code+"' already registered")
+	}
+	registeredRateProviders[code] = provider
+
+	return nil
+}
+
+// GetRateProvider returns exchange rates provider registered with given code or nil
+func GetRateProvider(code string) InterfaceRateProvider {
+	return registeredRateProviders[code]
+}
+
+// GetRateProviders returns all registered exchange rates providers
+func GetRateProviders() map[string]InterfaceRateProvider {
+	return registeredRateProviders
+}
@@ -79,6 +79,9 @@ type InterfaceOrder interface {
 	GetShippingMethod() string
 	GetPaymentMethod() string
 
+	// GetShipments returns groups of items shipped to different addresses, empty for orders shipped to one address
+	GetShipments() []StructShipment
+
 	GetStatus() string
 	SetStatus(status string) error
 
@@ -120,3 +123,19 @@ type StructDiscount struct {
 	Code   string
 	Amount float64
 }
+
+// StructShipment represents type to hold group of order items shipped to own address with own shipping method
+type StructShipment struct {
+	Items          []int // order item indexes
+	Address        map[string]interface{}
+	ShippingMethod string // shipping method and rate codes joined with "/"
+	ShippingName   string
+	ShippingAmount float64
+	TaxAmount      float64
+	GrandTotal     float64
+
+	Carrier        string
+	Service        string
+	TrackingNumber string
+	TrackingURL    string
+}
@@ -35,6 +35,11 @@ type InterfaceStock interface {
 	RemoveProductQty(productID string, options map[string]interface{}) error
 	UpdateProductQty(productID string, options map[string]interface{}, deltaQty int) error
 
+	ReserveProductQty(holderID string, productID string, options map[string]interface{}, qty int) error
+	GetAvailableQty(productID string, options map[string]interface{}, holderID string) int
+	GetReservations(holderID string) ([]map[string]interface{}, error)
+	ReleaseReservations(holderID string) error
+
 	models.InterfaceModel
 	models.InterfaceObject
 	models.InterfaceStorable
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+)
+
+// GetStoreCollectionModel retrieves current InterfaceStoreCollection model implementation
+func GetStoreCollectionModel() (InterfaceStoreCollection, error) {
+	model, err := models.GetModel(ConstModelNameStoreCollection)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	storeCollectionModel, ok := model.(InterfaceStoreCollection)
+	if !ok {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "2d7f5b1e-9a46-4c83-b0e2-7e4c1a9d3f58", "model "+model.GetImplementationName()+" is not 'InterfaceStoreCollection' capable")
+	}
+
+	return storeCollectionModel, nil
+}
+
+// GetStoreModel retrieves current InterfaceStore model implementation
+func GetStoreModel() (InterfaceStore, error) {
+	model, err := models.GetModel(ConstModelNameStore)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	storeModel, ok := model.(InterfaceStore)
+	if !ok {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b84c1e6a-3f92-4d05-8e7b-1a5d9c2f6e43", "model "+model.GetImplementationName()+" is not 'InterfaceStore' capable")
+	}
+
+	return storeModel, nil
+}
+
+// GetStoreModelAndSetID retrieves current InterfaceStore model implementation and sets its ID to some value
+func GetStoreModelAndSetID(storeID string) (InterfaceStore, error) {
+
+	storeModel, err := GetStoreModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	err = storeModel.SetID(storeID)
+	if err != nil {
+		return storeModel, env.ErrorDispatch(err)
+	}
+
+	return storeModel, nil
+}
+
+// LoadStoreByID loads store data into current InterfaceStore model implementation
+func LoadStoreByID(storeID string) (InterfaceStore, error) {
+
+	storeModel, err := GetStoreModel()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	err = storeModel.Load(storeID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return storeModel, nil
+}
+
+// GetRequestStoreID returns id of store request should be scoped to
+//   - admin requests are scoped to store given in "store_id" argument, blank value means all stores
+//   - other requests are scoped to store resolved for request
+func GetRequestStoreID(context api.InterfaceApplicationContext) string {
+	if api.IsAdminSession(context) {
+		return context.GetRequestArgument("store_id")
+	}
+	return api.GetCurrentStoreID()
+}
+
+// getVisibilityScope returns id of store objects visibility should be checked for, false means no restriction
+//   - admin requests without "store_id" argument are not restricted
+//   - other requests are restricted to resolved store, objects assigned to stores are hidden for blank store id
+//     (request to unknown host without default store)
+func getVisibilityScope(context api.InterfaceApplicationContext) (string, bool) {
+	if api.IsAdminSession(context) {
+		storeID := context.GetRequestArgument("store_id")
+		return storeID, storeID != ""
+	}
+	return api.GetCurrentStoreID(), true
+}
+
+// IsVisible checks if object is visible within store of given request context
+func IsVisible(context api.InterfaceApplicationContext, objectType string, objectID string) bool {
+	visibility := GetRegisteredVisibility()
+	if visibility == nil {
+		return true
+	}
+
+	storeID, restricted := getVisibilityScope(context)
+	if !restricted {
+		return true
+	}
+
+	return visibility.IsVisible(storeID, objectType, objectID)
+}
+
+// IsVisibleInStore checks if object is visible within given store, blank value means store of current request
+//   - calls made outside of request (cron tasks, etc.) are not restricted for blank store id
+func IsVisibleInStore(storeID string, objectType string, objectID string) bool {
+	visibility := GetRegisteredVisibility()
+	if visibility == nil {
+		return true
+	}
+
+	if storeID == "" {
+		currentStoreID, isRequest := api.LookupCurrentStoreID()
+		if !isRequest {
+			return true
+		}
+		storeID = currentStoreID
+	}
+
+	return visibility.IsVisible(storeID, objectType, objectID)
+}
+
+// ApplyVisibilityFilter excludes objects not visible within store of given request context from database collection
+func ApplyVisibilityFilter(context api.InterfaceApplicationContext, collection db.InterfaceDBCollection, objectType string) error {
+	visibility := GetRegisteredVisibility()
+	if visibility == nil {
+		return nil
+	}
+
+	storeID, restricted := getVisibilityScope(context)
+	if !restricted {
+		return nil
+	}
+
+	hiddenIDs := visibility.GetHiddenIDs(storeID, objectType)
+	if len(hiddenIDs) == 0 {
+		return nil
+	}
+
+	if err := collection.AddFilter("_id", "nin", hiddenIDs); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// ApplyStoreFilter limits database collection of store tagged records (orders, carts, etc.) to store of given
+// request context
+//   - admin requests are limited to store given in "store_id" argument, blank value means all stores
+//   - other requests are limited to resolved store
+func ApplyStoreFilter(context api.InterfaceApplicationContext, collection db.InterfaceDBCollection) error {
+	storeID := GetRequestStoreID(context)
+	if storeID == "" && api.IsAdminSession(context) {
+		return nil
+	}
+
+	if err := collection.AddFilter("store_id", "=", storeID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
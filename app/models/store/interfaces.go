@@ -0,0 +1,63 @@
+// Package store represents abstraction of business layer store object - a storefront (brand, website) sharing
+// application instance with others, store is resolved by request host and scopes configuration, catalog visibility,
+// carts and orders
+package store
+
+import (
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstModelNameStore           = "Store"
+	ConstModelNameStoreCollection = "StoreCollection"
+
+	ConstObjectTypeProduct  = "product"
+	ConstObjectTypeCategory = "category"
+	ConstObjectTypeCMSPage  = "cms_page"
+	ConstObjectTypeSEO      = "seo"
+
+	ConstErrorModule = "store"
+	ConstErrorLevel  = env.ConstErrorLevelModel
+)
+
+// InterfaceStore represents interface to access business layer implementation of store object
+type InterfaceStore interface {
+	GetCode() string
+	SetCode(string) error
+
+	GetName() string
+	SetName(string) error
+
+	GetHosts() []string
+	SetHosts([]string) error
+
+	IsDefault() bool
+	SetDefault(bool) error
+
+	GetEnabled() bool
+	SetEnabled(bool) error
+
+	models.InterfaceModel
+	models.InterfaceObject
+	models.InterfaceStorable
+	models.InterfaceListable
+}
+
+// InterfaceStoreCollection represents interface to access business layer implementation of store collection
+type InterfaceStoreCollection interface {
+	ListStores() []InterfaceStore
+
+	models.InterfaceCollection
+}
+
+// InterfaceStoreVisibility represents interface to access business layer implementation of objects visibility per
+// store, object not assigned to any store is visible within all stores
+type InterfaceStoreVisibility interface {
+	GetObjectStores(objectType string, objectID string) ([]string, error)
+	SetObjectStores(objectType string, objectID string, storeIDs []string) error
+
+	IsVisible(storeID string, objectType string, objectID string) bool
+	GetHiddenIDs(storeID string, objectType string) []string
+}
@@ -0,0 +1,25 @@
+package store
+
+import (
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global variables
+var (
+	registeredVisibility InterfaceStoreVisibility
+)
+
+// RegisterVisibility registers given store visibility service in system
+func RegisterVisibility(visibility InterfaceStoreVisibility) error {
+	if registeredVisibility != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6a2e9f4c-d183-4b57-a0e8-3c7b5d1f9e26", "Already registered")
+	}
+	registeredVisibility = visibility
+
+	return nil
+}
+
+// GetRegisteredVisibility returns currently using store visibility service or nil
+func GetRegisteredVisibility() InterfaceStoreVisibility {
+	return registeredVisibility
+}
@@ -19,6 +19,8 @@ const (
 
 	ConstSessionKeyVisitorID = "visitor_id"
 
+	ConstEventLogin = "visitor.login" // data: "session", "visitor_id" and "result" map listeners could add login details to
+
 	ConstErrorModule = "visitor"
 	ConstErrorLevel  = env.ConstErrorLevelModel
 )
@@ -1,12 +1,16 @@
 package basebuild
 
 import (
-	_ "github.com/ottemo/foundation/env/config"   // System Configuration service
-	_ "github.com/ottemo/foundation/env/cron"     // Schedule service
-	_ "github.com/ottemo/foundation/env/errorbus" // Error Bus service
-	_ "github.com/ottemo/foundation/env/eventbus" // Event Bus service
-	_ "github.com/ottemo/foundation/env/ini"      // INI Configuration service
-	_ "github.com/ottemo/foundation/env/logger"   // File-based Logging service
+	_ "github.com/ottemo/foundation/env/config"    // System Configuration service
+	_ "github.com/ottemo/foundation/env/cron"      // Schedule service
+	_ "github.com/ottemo/foundation/env/errorbus"  // Error Bus service
+	_ "github.com/ottemo/foundation/env/eventbus"  // Event Bus service
+	_ "github.com/ottemo/foundation/env/extension" // Extensions registry
+	_ "github.com/ottemo/foundation/env/ini"       // INI Configuration service
+	_ "github.com/ottemo/foundation/env/logger"    // File-based Logging service
+	_ "github.com/ottemo/foundation/env/metrics"   // Metrics registry
+	_ "github.com/ottemo/foundation/env/health"    // Health checks
+	_ "github.com/ottemo/foundation/env/jobs"      // Background jobs queue
 
 	_ "github.com/ottemo/foundation/api/context"   // Context runtime transfer service
 	_ "github.com/ottemo/foundation/api/rest"      // RESTful API service
@@ -18,17 +22,20 @@ import (
 	_ "github.com/ottemo/foundation/app/actors/cms"             // CMS Page/Block module
 	_ "github.com/ottemo/foundation/app/actors/product"         // Product module
 	_ "github.com/ottemo/foundation/app/actors/product/review"  // Product Reviews module
+	_ "github.com/ottemo/foundation/app/actors/store"           // Multi-store module
 	_ "github.com/ottemo/foundation/app/actors/swatch"          // Product Reviews module
 	_ "github.com/ottemo/foundation/app/actors/visitor"         // Visitor module
 	_ "github.com/ottemo/foundation/app/actors/visitor/address" // Visitor Address module
 	_ "github.com/ottemo/foundation/app/actors/visitor/token"   // Visitor Token module
 
-	_ "github.com/ottemo/foundation/app/actors/cart"         // Shopping Cart module
-	_ "github.com/ottemo/foundation/app/actors/checkout"     // Checkout module
-	_ "github.com/ottemo/foundation/app/actors/order"        // Purchase Order module
-	_ "github.com/ottemo/foundation/app/actors/stock"        // Stock Management module
-	_ "github.com/ottemo/foundation/app/actors/subscription" // subscription extension
-	_ "github.com/ottemo/foundation/app/actors/xdomain"      // XDomain support module
+	_ "github.com/ottemo/foundation/app/actors/cart"           // Shopping Cart module
+	_ "github.com/ottemo/foundation/app/actors/checkout"       // Checkout module
+	_ "github.com/ottemo/foundation/app/actors/checkout/rules" // Checkout restriction rules
+	_ "github.com/ottemo/foundation/app/actors/currency"       // Multi-currency module
+	_ "github.com/ottemo/foundation/app/actors/order"          // Purchase Order module
+	_ "github.com/ottemo/foundation/app/actors/stock"          // Stock Management module
+	_ "github.com/ottemo/foundation/app/actors/subscription"   // subscription extension
+	_ "github.com/ottemo/foundation/app/actors/xdomain"        // XDomain support module
 
 	_ "github.com/ottemo/foundation/app/actors/payment/authorizenet" // Authorize.Net payment method
 	_ "github.com/ottemo/foundation/app/actors/payment/braintree"    // Braintree payment method
@@ -46,9 +53,10 @@ import (
 	_ "github.com/ottemo/foundation/app/actors/discount/saleprice" // Sale Price
 	_ "github.com/ottemo/foundation/app/actors/tax"                // Tax Rates
 
-	_ "github.com/ottemo/foundation/app/actors/reporting" // Reporting
-	_ "github.com/ottemo/foundation/app/actors/rts"       // Real Time Statistics service
-	_ "github.com/ottemo/foundation/app/actors/seo"       // URL Rewrite support
+	_ "github.com/ottemo/foundation/app/actors/errorreport" // Errors aggregation and alerting
+	_ "github.com/ottemo/foundation/app/actors/reporting"   // Reporting
+	_ "github.com/ottemo/foundation/app/actors/rts"         // Real Time Statistics service
+	_ "github.com/ottemo/foundation/app/actors/seo"         // URL Rewrite support
 
 	_ "github.com/ottemo/foundation/app/actors/other/friendmail"  // email friend extension
 	_ "github.com/ottemo/foundation/app/actors/other/grouping"    // products grouping extension
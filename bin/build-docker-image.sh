@@ -21,7 +21,7 @@ done
 
 indocker="${indocker:-false}"
 
-GOIMAGE="ottemo/golang:1.7.5" # images that used to build foundation binary
+GOIMAGE="golang:1.10" # images that used to build foundation binary, go 1.10 at least is required (see bin/make.sh)
 FOUNDATIONIMAGE="ottemo/foundation"
 
 MYDIR=$(cd `dirname ${BASH_SOURCE[0]}` && pwd)
@@ -42,6 +42,7 @@ if [ "$indocker" = "true" ] ; then
   echo "build image under docker container"
   echo "generate temporary Dockerfile"
   echo "FROM $GOIMAGE" >Dockerfile.temporary
+  echo 'RUN apt-get update && apt-get install -y --no-install-recommends gawk' >>Dockerfile.temporary
   echo 'COPY . /go/src/github.com/ottemo/foundation' >>Dockerfile.temporary
   echo 'RUN cd /go/src/github.com/ottemo/foundation && bin/make.sh -tags mongo,redis' >>Dockerfile.temporary
 
@@ -56,7 +57,8 @@ if [ "$indocker" = "true" ] ; then
 else
 
   echo "build foundation executable with $GOIMAGE docker image"
-  docker run -v "$FOUNDATIONREPO":/go/src/github.com/ottemo/foundation -w /go/src/github.com/ottemo/foundation -e GOOS=linux -e CGO_ENABLED=0 $GOIMAGE bin/make.sh -tags mongo,redis
+  docker run -v "$FOUNDATIONREPO":/go/src/github.com/ottemo/foundation -w /go/src/github.com/ottemo/foundation -e GOOS=linux -e CGO_ENABLED=0 $GOIMAGE \
+    sh -c "apt-get update && apt-get install -y --no-install-recommends gawk && bin/make.sh -tags mongo,redis"
   if [ $? -ne 0 ]; then
     echo "error in build foundation executable"
     exit 2
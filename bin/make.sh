@@ -59,12 +59,21 @@ LDFLAGS+="-X \"github.com/ottemo/foundation/app.buildBranch=$BRANCH\" "
 LDFLAGS+="-X \"github.com/ottemo/foundation/app.buildHash=$HASH\" "
 LDFLAGS+="'"
 
-# need to convert GOVERSION string to number
+# need to compare GOVERSION minor number as a number ("1.10" is newer than "1.5")
 #
-if [ "`${AWK} "BEGIN{ if (($GOVERSION +0) < 1.5) print 1 }"`" == "1" ]; then
+GOMINOR=`echo $GOVERSION | ${AWK} -F. '{print $2 +0}'`
+if [ "$GOMINOR" -lt 5 ]; then
   LDFLAGS=${LDFLAGS//=/\" \"}
 fi
 
+# go 1.10 at least is required: sort.Slice and http.Server.Shutdown were added in go 1.8, math.Round in go 1.10
+#
+GOMINVERSION=10
+if [ "$GOMINOR" -lt "$GOMINVERSION" ]; then
+  echo "go 1.$GOMINVERSION or newer is required to build foundation, go $GOVERSION found"
+  exit 1
+fi
+
 if [ -z "$GOPATH" ]; then
 REPLACE="/src/$OTTEMOPKG"
 export GOPATH="${OTTEMODIR/$REPLACE/}"
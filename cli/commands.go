@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/app/models/visitor"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/impex"
+	"github.com/ottemo/foundation/media"
+	"github.com/ottemo/foundation/utils"
+)
+
+// newFlagSet makes flag set for command arguments parsing
+func newFlagSet(name string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.SetOutput(os.Stderr)
+	return flagSet
+}
+
+// openInput opens file for reading, "-" means standard input
+func openInput(fileName string) (io.ReadCloser, error) {
+	if fileName == "-" {
+		return os.Stdin, nil
+	}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	return file, nil
+}
+
+// commandImport imports csv files, impex script is expected unless model is specified
+//   - "-model" imports plain csv to given model, "-test" checks data without applying changes
+func commandImport(args []string, output io.Writer) error {
+	flagSet := newFlagSet("import")
+	modelName := flagSet.String("model", "", "model to import plain csv data to")
+	testMode := flagSet.Bool("test", false, "check data without applying changes")
+	if err := flagSet.Parse(args); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if flagSet.NArg() == 0 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7f2b9c4e-a153-4d86-b0e7-5c3a8d1f6e29", "csv file should be specified")
+	}
+
+	for _, fileName := range flagSet.Args() {
+		file, err := openInput(fileName)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+
+		csvReader := csv.NewReader(file)
+		csvReader.Comma = ','
+
+		if *modelName != "" {
+			err = impex.ImportModel(*modelName, csvReader, output, *testMode)
+		} else {
+			err = impex.ImportCSVScript(csvReader, output, *testMode)
+		}
+		_ = file.Close()
+
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+		fmt.Fprintln(output, fileName, "processed")
+	}
+
+	return nil
+}
+
+// commandExport exports model data as csv to standard output or file
+func commandExport(args []string, output io.Writer) error {
+	flagSet := newFlagSet("export")
+	fileName := flagSet.String("output", "", "file to write csv data to, standard output is used if not specified")
+	if err := flagSet.Parse(args); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if flagSet.NArg() != 1 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d36a8e1b-4f92-4c57-a0d8-9b2e7f5c1a64", "model name should be specified")
+	}
+
+	records, err := impex.ExportModel(flagSet.Arg(0))
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if *fileName != "" {
+		file, err := os.Create(*fileName)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+		defer file.Close()
+
+		output = file
+	}
+
+	return impex.MapToCSV(records, csv.NewWriter(output))
+}
+
+// commandMediaResize resizes all media images for currently configured sizes
+func commandMediaResize(args []string, output io.Writer) error {
+	mediaStorage, err := media.GetMediaStorage()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	start := time.Now()
+	if err := mediaStorage.ResizeAllMediaImages(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	fmt.Fprintln(output, "images resized in", time.Now().Sub(start))
+
+	return nil
+}
+
+// commandCronList outputs registered scheduler tasks
+func commandCronList(args []string, output io.Writer) error {
+	scheduler := env.GetScheduler()
+	if scheduler == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1a9e5c3f-b742-4d08-8e6a-f3c7d2b9e015", "scheduler is not registered")
+	}
+
+	tasks := scheduler.ListTasks()
+	sort.Strings(tasks)
+	for _, task := range tasks {
+		fmt.Fprintln(output, task)
+	}
+
+	return nil
+}
+
+// commandCronRun executes scheduler task immediately, task params are given as "key=value" arguments
+func commandCronRun(args []string, output io.Writer) error {
+	if len(args) == 0 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c5f81d2a-e396-4b7c-9d04-6a2e8b3f7c19", "task name should be specified")
+	}
+
+	scheduler := env.GetScheduler()
+	if scheduler == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "84b2e7d9-3c15-4fa6-b8e0-2d9f6a1c5b73", "scheduler is not registered")
+	}
+
+	params := make(map[string]interface{})
+	for _, arg := range args[1:] {
+		if parts := strings.SplitN(arg, "=", 2); len(parts) == 2 {
+			params[parts[0]] = parts[1]
+		}
+	}
+
+	start := time.Now()
+	if err := scheduler.RunTask(args[0], params); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	fmt.Fprintln(output, "task", args[0], "finished in", time.Now().Sub(start))
+
+	return nil
+}
+
+// commandConfigGet outputs config value
+func commandConfigGet(args []string, output io.Writer) error {
+	if len(args) != 1 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e27c4a9b-6d51-4f83-a0e9-8b5d3c1f7e46", "config path should be specified")
+	}
+
+	config := env.GetConfig()
+	if config == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5b8d1f3e-c927-4a64-9e0b-7f2a6c4d8e31", "config is not registered")
+	}
+
+	fmt.Fprintln(output, utils.InterfaceToString(config.GetValue(args[0])))
+
+	return nil
+}
+
+// commandConfigSet updates config value, value is converted and validated by config item
+func commandConfigSet(args []string, output io.Writer) error {
+	if len(args) != 2 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9c3e6a1d-f485-4b27-8d0a-e1b7f5c9a362", "config path and value should be specified")
+	}
+
+	config := env.GetConfig()
+	if config == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a61f9d4c-2b78-4e35-b0c6-3d8e1a7f5b92", "config is not registered")
+	}
+
+	if err := config.SetValue(args[0], args[1]); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	fmt.Fprintln(output, args[0], "=", utils.InterfaceToString(config.GetValue(args[0])))
+
+	return nil
+}
+
+// commandUserCreateAdmin makes visitor with admin rights, existing visitor with given email gets admin rights and
+// new password
+func commandUserCreateAdmin(args []string, output io.Writer) error {
+	flagSet := newFlagSet("user create-admin")
+	email := flagSet.String("email", "", "admin email")
+	password := flagSet.String("password", "", "admin password")
+	firstName := flagSet.String("first-name", "", "admin first name")
+	lastName := flagSet.String("last-name", "", "admin last name")
+	if err := flagSet.Parse(args); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if *email == "" || *password == "" {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "f4d27b8e-1a36-4c95-9e0d-b6c3a8f1e527", "email and password should be specified")
+	}
+
+	visitorModel, err := visitor.GetVisitorModel()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	values := map[string]interface{}{
+		"email":    strings.ToLower(*email),
+		"password": *password,
+		"is_admin": true,
+	}
+	if *firstName != "" {
+		values["first_name"] = *firstName
+	}
+	if *lastName != "" {
+		values["last_name"] = *lastName
+	}
+
+	if err := visitorModel.LoadByEmail(*email); err != nil {
+		values["created_at"] = time.Now()
+	}
+
+	for attribute, value := range values {
+		if err := visitorModel.Set(attribute, value); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	if err := visitorModel.Save(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	fmt.Fprintln(output, "admin", visitorModel.GetEmail(), "saved, id:", visitorModel.GetID())
+
+	return nil
+}
+
+// commandDBMigrate executes registered database migrations, database structure is already prepared by packages on
+// database start, so command outputs structure state and runs data migrations one by one
+func commandDBMigrate(args []string, output io.Writer) error {
+	flagSet := newFlagSet("db migrate")
+	testMode := flagSet.Bool("test", false, "output changes to be made without saving them")
+	if err := flagSet.Parse(args); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	fmt.Fprintln(output, "database structure is up to date")
+	for _, migration := range migrations {
+		fmt.Fprintln(output, migration.Name+":", migration.Description)
+
+		var migrationArgs []string
+		if *testMode {
+			migrationArgs = append(migrationArgs, "-test")
+		}
+		if err := migration.Handler(migrationArgs, output); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// commandHelp is a placeholder for usage output, it is handled by Run()
+func commandHelp(args []string, output io.Writer) error {
+	return nil
+}
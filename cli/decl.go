@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"io"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstDatabaseTimeout = 60 // seconds to wait for database connection before command fails
+
+	ConstErrorModule = "cli"
+	ConstErrorLevel  = env.ConstErrorLevelService
+)
+
+// Package global variables
+var (
+	commands   = make(map[string]*StructCommand) // registered commands by name
+	migrations = make([]*StructCommand, 0)       // registered database migrations in registration order
+)
+
+// FuncCommand is a command handler, args are command line arguments following command name
+type FuncCommand func(args []string, output io.Writer) error
+
+// StructCommand describes command line command
+//   - "Name" could consist of several words ("media resize"), longest registered name matching arguments is used
+type StructCommand struct {
+	Name        string
+	Usage       string
+	Description string
+	Handler     FuncCommand
+}
@@ -0,0 +1,26 @@
+// Copyright 2014 The Ottemo Authors. All rights reserved.
+
+/*
+Package cli is a command line mode of application executable, it allows to make maintenance operations without HTTP
+API and session.
+
+Command runs within usual application lifecycle: application is started, database connection is awaited (so all
+packages prepared database structure), command is executed and application is ended. HTTP listener, scheduler and
+background job workers are not started.
+
+	foundation import -model Product products.csv
+	foundation export -output orders.csv Order
+	foundation media resize
+	foundation cron run abandonCartEmail
+	foundation config set general.store.name "My Store"
+	foundation config rotate-key new-secret-key
+	foundation user create-admin -email admin@example.com -password secret
+	foundation order migrate-money -test
+	foundation db migrate -test
+
+Application flags ("--test", "--iniSection=") are accepted along with commands. Arguments not starting a registered
+command are ignored and application runs as HTTP server, so launch scripts passing own arguments keep working. Packages could provide own commands
+with RegisterCommand() called within package init(), data migrations executed by "db migrate" are registered with
+RegisterMigration().
+*/
+package cli
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"github.com/ottemo/foundation/env"
+)
+
+// init makes package self-initialization routine
+func init() {
+	for _, command := range []StructCommand{
+		{Name: "help", Description: "outputs list of commands", Handler: commandHelp},
+		{Name: "import", Usage: "[-model name] [-test] file.csv...", Description: "imports impex script or model csv data (\"-\" is a standard input)", Handler: commandImport},
+		{Name: "export", Usage: "[-output file.csv] model", Description: "exports model data as csv", Handler: commandExport},
+		{Name: "media resize", Description: "resizes all media images for configured sizes", Handler: commandMediaResize},
+		{Name: "cron list", Description: "outputs registered scheduler tasks", Handler: commandCronList},
+		{Name: "cron run", Usage: "task [key=value...]", Description: "executes scheduler task immediately", Handler: commandCronRun},
+		{Name: "config get", Usage: "path", Description: "outputs config value", Handler: commandConfigGet},
+		{Name: "config set", Usage: "path value", Description: "updates config value", Handler: commandConfigSet},
+		{Name: "db migrate", Usage: "[-test]", Description: "prepares database structure and executes registered data migrations", Handler: commandDBMigrate},
+		{Name: "user create-admin", Usage: "-email email -password password", Description: "makes visitor with admin rights", Handler: commandUserCreateAdmin},
+	} {
+		if err := RegisterCommand(command); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+}
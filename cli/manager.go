@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/ini"
+)
+
+// RegisterCommand registers command line command, it supposed to be called within package init()
+func RegisterCommand(command StructCommand) error {
+	if command.Name == "" || command.Handler == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "0c7e4a92-d5b1-4f38-9a6e-e3b8f1d27c45", "command name and handler should be specified")
+	}
+
+	command.Name = strings.Join(strings.Fields(command.Name), " ")
+	if _, present := commands[command.Name]; present {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b49d2e17-6a83-4c05-8f1b-7d5c9a3e0b68", "command '"+command.Name+"' already registered")
+	}
+	commands[command.Name] = &command
+
+	return nil
+}
+
+// RegisterMigration registers database data migration executed by "db migrate" command, it supposed to be called
+// within package init()
+//   - migration handler gets "db migrate" command arguments, so it should accept "-test" flag and make no changes
+//     within test mode
+//   - migration is executed on each "db migrate" run, so it should skip already migrated records
+func RegisterMigration(migration StructCommand) error {
+	if migration.Name == "" || migration.Handler == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6f1d83b2-4ac9-4e57-b20d-91e7c3a5f846", "migration name and handler should be specified")
+	}
+
+	for _, registered := range migrations {
+		if registered.Name == migration.Name {
+			return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d3a92c47-e815-4b6f-9c0a-5b7e28f1d4c9", "migration '"+migration.Name+"' already registered")
+		}
+	}
+	migrations = append(migrations, &migration)
+
+	return nil
+}
+
+// GetCommandArgs returns command line arguments without application flags ("--test", "--iniSection=", etc.), blank
+// result means application should run as HTTP server
+//   - arguments not starting with registered command name are not considered as command
+func GetCommandArgs() []string {
+	return getCommandArgs(os.Args[1:])
+}
+
+// getCommandArgs is a GetCommandArgs() routine working on given arguments
+func getCommandArgs(args []string) []string {
+	var result []string
+	for _, arg := range args {
+		if arg == ini.ConstCmdArgStoreAllFlag || arg == ini.ConstCmdArgTestFlag || strings.HasPrefix(arg, ini.ConstCmdArgSectionName) {
+			continue
+		}
+		result = append(result, arg)
+	}
+
+	if command, _ := findCommand(result); command == nil {
+		return nil
+	}
+	return result
+}
+
+// findCommand returns registered command with longest name matching given arguments along with rest of arguments
+func findCommand(args []string) (*StructCommand, []string) {
+	for count := len(args); count > 0; count-- {
+		if command, present := commands[strings.Join(args[:count], " ")]; present {
+			return command, args[count:]
+		}
+	}
+	return nil, args
+}
+
+// printUsage outputs list of registered commands
+func printUsage(output io.Writer) {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(output, "Usage: foundation [command] [arguments]")
+	fmt.Fprintln(output, "Runs HTTP server if no command given, commands are:")
+	for _, name := range names {
+		command := commands[name]
+		fmt.Fprintf(output, "  %-45s %s\n", strings.TrimSpace(command.Name+" "+command.Usage), command.Description)
+	}
+}
+
+// Run executes command given by arguments within application lifecycle without HTTP listener, returns process exit
+// code
+//   - application is started in command mode (no scheduler and job workers) and database connection is awaited
+//     before command, application is ended after
+func Run(args []string) int {
+	command, commandArgs := findCommand(args)
+	if command == nil {
+		printUsage(os.Stderr)
+		return 2
+	}
+
+	if command.Name == "help" {
+		printUsage(os.Stdout)
+		return 0
+	}
+
+	// database connection is async, callback registered last is called after all packages prepared database
+	databaseReady := make(chan bool, 1)
+	db.RegisterOnDatabaseStart(func() error {
+		databaseReady <- true
+		return nil
+	})
+
+	exitCode := 0
+	app.SetCommandMode(true)
+	if err := app.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	select {
+	case <-databaseReady:
+		if err := command.Handler(commandArgs, os.Stdout); err != nil {
+			_ = env.ErrorDispatch(err)
+			fmt.Fprintln(os.Stderr, err.Error())
+			exitCode = 1
+		}
+	case <-time.After(ConstDatabaseTimeout * time.Second):
+		fmt.Fprintln(os.Stderr, "database was not started in", ConstDatabaseTimeout, "seconds")
+		exitCode = 1
+	}
+
+	if err := app.End(); err != nil {
+		_ = env.ErrorDispatch(err)
+		fmt.Fprintln(os.Stderr, err.Error())
+	}
+
+	return exitCode
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"io"
+	"testing"
+)
+
+// TestFindCommand tests command lookup by arguments prefers longest command name
+func TestFindCommand(t *testing.T) {
+	command, args := findCommand([]string{"cron", "run", "abandonCartEmail", "days=2"})
+	if command == nil || command.Name != "cron run" {
+		t.Fatal("'cron run' command expected:", command)
+	}
+	if len(args) != 2 || args[0] != "abandonCartEmail" || args[1] != "days=2" {
+		t.Error("unexpected command arguments:", args)
+	}
+
+	command, args = findCommand([]string{"export", "Order"})
+	if command == nil || command.Name != "export" || len(args) != 1 {
+		t.Error("'export' command expected:", command, args)
+	}
+
+	if command, _ := findCommand([]string{"cron"}); command != nil {
+		t.Error("incomplete command name should not match:", command.Name)
+	}
+
+	if command, _ := findCommand([]string{"unknown", "command"}); command != nil {
+		t.Error("unknown command should not match:", command.Name)
+	}
+}
+
+// TestGetCommandArgs tests application flags are skipped and unknown arguments make application run as HTTP server
+func TestGetCommandArgs(t *testing.T) {
+	args := getCommandArgs([]string{"--test", "cron", "run", "--iniSection=dev", "abandonCartEmail"})
+	if len(args) != 3 || args[0] != "cron" || args[2] != "abandonCartEmail" {
+		t.Error("unexpected command arguments:", args)
+	}
+
+	if args := getCommandArgs([]string{"--test"}); args != nil {
+		t.Error("no command arguments expected:", args)
+	}
+
+	if args := getCommandArgs([]string{"-port", "3000"}); args != nil {
+		t.Error("unknown arguments should not be considered as command:", args)
+	}
+}
+
+// TestRegisterMigration tests migrations to keep registration order and reject duplicate names
+func TestRegisterMigration(t *testing.T) {
+	registered := migrations
+	defer func() { migrations = registered }()
+	migrations = nil
+
+	handler := func(args []string, output io.Writer) error { return nil }
+	for _, name := range []string{"second", "first"} {
+		if err := RegisterMigration(StructCommand{Name: name, Handler: handler}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RegisterMigration(StructCommand{Name: "first", Handler: handler}); err == nil {
+		t.Error("duplicate migration should not be registered")
+	}
+	if err := RegisterMigration(StructCommand{Name: "no handler"}); err == nil {
+		t.Error("migration without handler should not be registered")
+	}
+
+	if len(migrations) != 2 || migrations[0].Name != "second" || migrations[1].Name != "first" {
+		t.Error("migrations should keep registration order")
+	}
+}
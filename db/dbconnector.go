@@ -4,6 +4,8 @@ import (
 	"time"
 	"fmt"
 
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -14,7 +16,21 @@ type DBConnector struct {
 
 // NewDBConnector returns new DBConnector instance
 func NewDBConnector(connector InterfaceDBConnector) *DBConnector {
-	return &DBConnector{connector: connector}
+	result := &DBConnector{connector: connector}
+
+	if err := health.RegisterProbe("db", health.ConstKindReadiness, true, result.probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return result
+}
+
+// probe is a readiness health probe checking database connection is alive
+func (it *DBConnector) probe() error {
+	if !it.connector.IsConnected() {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "3c9e7a15-d4b8-4f62-9a0e-6b1d8f2c5e73", it.connector.GetEngineName()+" DB is not connected")
+	}
+	return it.connector.Ping()
 }
 
 // ConnectAsync makes connection process asynchronously
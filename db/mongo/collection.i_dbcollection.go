@@ -3,6 +3,7 @@ package mongo
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"gopkg.in/mgo.v2/bson"
 
@@ -12,6 +13,8 @@ import (
 
 // LoadByID loads one record from DB by record _id
 func (it *DBCollection) LoadByID(id string) (map[string]interface{}, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "load_by_id")
+
 	result := make(map[string]interface{})
 
 	err := it.collection.FindId(id).One(&result)
@@ -24,6 +27,8 @@ func (it *DBCollection) LoadByID(id string) (map[string]interface{}, error) {
 
 // Load loads records from DB for current collection and filter if it set
 func (it *DBCollection) Load() ([]map[string]interface{}, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "load")
+
 	var result []map[string]interface{}
 
 	err := it.prepareQuery().All(&result)
@@ -33,6 +38,8 @@ func (it *DBCollection) Load() ([]map[string]interface{}, error) {
 
 // Iterate applies [iterator] function to each record, stops on return false
 func (it *DBCollection) Iterate(iteratorFunc func(record map[string]interface{}) bool) error {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "iterate")
+
 	record := make(map[string]interface{})
 
 	iterator := it.prepareQuery().Iter()
@@ -52,11 +59,15 @@ func (it *DBCollection) Iterate(iteratorFunc func(record map[string]interface{})
 
 // Count returns count of rows matching current select statement
 func (it *DBCollection) Count() (int, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "count")
+
 	return it.collection.Find(it.makeSelector()).Count()
 }
 
 // Distinct returns distinct values of specified attribute
 func (it *DBCollection) Distinct(columnName string) ([]interface{}, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "distinct")
+
 	var result []interface{}
 
 	err := it.prepareQuery().Distinct(columnName, &result)
@@ -66,6 +77,7 @@ func (it *DBCollection) Distinct(columnName string) ([]interface{}, error) {
 
 // Save stores record in DB for current collection
 func (it *DBCollection) Save(Item map[string]interface{}) (string, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "save")
 
 	// id verification/updating
 	//-----------------------
@@ -108,6 +120,8 @@ func (it *DBCollection) Save(Item map[string]interface{}) (string, error) {
 
 // Delete removes records that matches current select statement from DB, returns amount of affected rows
 func (it *DBCollection) Delete() (int, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "delete")
+
 	changeInfo, err := it.collection.RemoveAll(it.makeSelector())
 
 	return changeInfo.Removed, env.ErrorDispatch(err)
@@ -115,6 +129,8 @@ func (it *DBCollection) Delete() (int, error) {
 
 // DeleteByID removes record from DB by is's id
 func (it *DBCollection) DeleteByID(id string) error {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "delete_by_id")
+
 	return it.collection.RemoveId(id)
 }
 
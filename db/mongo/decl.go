@@ -8,12 +8,16 @@ import (
 	"time"
 
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/metrics"
 )
 
 // Package global variables
 var (
 	attributeTypes      = make(map[string]map[string]string) // cached values of collection attribute types
 	attributeTypesMutex sync.RWMutex                         // syncronization for attributeTypes modification
+
+	// metricOperationDuration is a duration of collection data operations, the same metric is used by all db engines
+	metricOperationDuration = metrics.NewHistogram("ottemo_db_operation_duration_seconds", "Database operations duration.", nil, "collection", "operation")
 )
 
 // Package global constants
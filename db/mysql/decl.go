@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/metrics"
 )
 
 // Package global constants
@@ -33,6 +34,9 @@ var (
 
 	// ConstSQLNameValidator is a regex expression used to check names used within SQL queries
 	ConstSQLNameValidator = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+	// metricOperationDuration is a duration of collection data operations, the same metric is used by all db engines
+	metricOperationDuration = metrics.NewHistogram("ottemo_db_operation_duration_seconds", "Database operations duration.", nil, "collection", "operation")
 )
 
 // RowMap - represents row of data from database
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	sqlite3 "github.com/mxk/go-sqlite/sqlite3"
 	"github.com/ottemo/foundation/db"
@@ -14,6 +15,8 @@ import (
 
 // LoadByID loads record from DB by it's id
 func (it *DBCollection) LoadByID(id string) (map[string]interface{}, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "load_by_id")
+
 	var result map[string]interface{}
 
 	if !ConstUseUUIDids {
@@ -45,6 +48,8 @@ func (it *DBCollection) LoadByID(id string) (map[string]interface{}, error) {
 
 // Load loads records from DB for current collection and filter if it set
 func (it *DBCollection) Load() ([]map[string]interface{}, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "load")
+
 	var result []map[string]interface{}
 
 	err := it.Iterate(func(row map[string]interface{}) bool {
@@ -57,6 +62,7 @@ func (it *DBCollection) Load() ([]map[string]interface{}, error) {
 
 // Iterate applies [iterator] function to each record, stops on return false
 func (it *DBCollection) Iterate(iteratorFunc func(record map[string]interface{}) bool) error {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "iterate")
 
 	SQL := it.getSelectSQL()
 
@@ -87,6 +93,7 @@ func (it *DBCollection) Iterate(iteratorFunc func(record map[string]interface{})
 
 // Distinct returns distinct values of specified attribute
 func (it *DBCollection) Distinct(columnName string) ([]interface{}, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "distinct")
 
 	prevResultColumns := it.ResultColumns
 	if err := it.SetResultColumns(columnName); err != nil {
@@ -155,6 +162,8 @@ func (it *DBCollection) Distinct(columnName string) ([]interface{}, error) {
 
 // Count returns count of rows matching current select statement
 func (it *DBCollection) Count() (int, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "count")
+
 	sqlLoadFilter := it.getSQLFilters()
 
 	SQL := "SELECT COUNT(*) AS cnt FROM " + it.Name + sqlLoadFilter
@@ -181,6 +190,7 @@ func (it *DBCollection) Count() (int, error) {
 
 // Save stores record in DB for current collection
 func (it *DBCollection) Save(item map[string]interface{}) (string, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "save")
 
 	// prevents saving of blank records
 	if len(item) == 0 {
@@ -284,6 +294,8 @@ func (it *DBCollection) Save(item map[string]interface{}) (string, error) {
 // Delete removes records that matches current select statement from DB
 //   - returns amount of affected rows
 func (it *DBCollection) Delete() (int, error) {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "delete")
+
 	sqlDeleteFilter := it.getSQLFilters()
 
 	SQL := "DELETE FROM " + it.Name + sqlDeleteFilter
@@ -295,6 +307,8 @@ func (it *DBCollection) Delete() (int, error) {
 
 // DeleteByID removes record from DB by is's id
 func (it *DBCollection) DeleteByID(id string) error {
+	defer metricOperationDuration.ObserveDuration(time.Now(), it.Name, "delete_by_id")
+
 	SQL := "DELETE FROM " + it.Name + " WHERE _id = " + convertValueForSQL(id)
 
 	return connectionExec(SQL)
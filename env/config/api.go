@@ -24,9 +24,40 @@ func setupAPI() error {
 	service.PUT("config/value/:path", api.IsAdminHandler(restConfigSet))
 	service.DELETE("config/value/:path", api.IsAdminHandler(restConfigUnRegister))
 
+	service.GET("config/history/:path", api.IsAdminHandler(restConfigHistory))
+	service.POST("config/rollback/:path", api.IsAdminHandler(restConfigRollback))
+	service.GET("config/snapshot", api.IsAdminHandler(restConfigSnapshotExport))
+	service.POST("config/snapshot", api.IsAdminHandler(restConfigSnapshotImport))
+	service.POST("config/secrets/rotate", api.IsAdminHandler(restConfigRotateSecretsKey))
+
+	service.GET("config/store/:storeID", api.IsAdminHandler(restConfigStoreValues))
+	service.PUT("config/store/:storeID/:path", api.IsAdminHandler(restConfigStoreSet))
+	service.DELETE("config/store/:storeID/:path", api.IsAdminHandler(restConfigStoreRemove))
+
 	return nil
 }
 
+// getDefaultConfig returns currently registered config service if it is DefaultConfig
+func getDefaultConfig() (*DefaultConfig, error) {
+	if config, ok := env.GetConfig().(*DefaultConfig); ok {
+		return config, nil
+	}
+	return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8d2e5f1a-3c7b-4e9d-a6f0-b4c2d8e1f357", "config service does not support change history")
+}
+
+// getActor returns name of config change initiator for given application context
+func getActor(context api.InterfaceApplicationContext) string {
+	if visitorID := utils.InterfaceToString(context.GetSession().Get(ConstSessionKeyVisitorID)); visitorID != "" {
+		return "visitor:" + visitorID
+	}
+
+	if api.IsAdminSession(context) {
+		return ConstActorAdmin
+	}
+
+	return ConstActorAPI
+}
+
 // WEB REST API to get value information about config items with type [ConstConfigTypeGroup]
 func restConfigGroups(context api.InterfaceApplicationContext) (interface{}, error) {
 	config := env.GetConfig()
@@ -65,9 +96,14 @@ func restConfigGet(context api.InterfaceApplicationContext) (interface{}, error)
 
 			// check rights
 			if !api.IsAdminSession(context) {
-				return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c7724469-8acb-41f4-a031-08b016053b58", "Operation not allowed.")
+				err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "c7724469-8acb-41f4-a031-08b016053b58", "Operation not allowed.")
+				return nil, env.ErrorWithDetails(err, map[string]interface{}{"path": configItemPath})
 			}
 		}
+
+		if itemInfo.Type == env.ConstConfigTypeSecret {
+			return itemInfo.Value, nil
+		}
 	}
 
 	return config.GetValue(context.GetRequestArgument("path")), nil
@@ -90,11 +126,24 @@ func restConfigSet(context api.InterfaceApplicationContext) (interface{}, error)
 		}
 	}
 
-	err = config.SetValue(configPath, setValue)
+	// masked value returned to client means secret was not changed
+	if setValue == ConstSecretMask && env.ConfigIsSecret(configPath) {
+		return setValue, nil
+	}
+
+	if defaultConfig, ok := config.(*DefaultConfig); ok {
+		err = defaultConfig.SetValueBy(configPath, setValue, getActor(context))
+	} else {
+		err = config.SetValue(configPath, setValue)
+	}
 	if err != nil {
 		return nil, env.ErrorDispatch(err)
 	}
 
+	if env.ConfigIsSecret(configPath) {
+		return MaskSecret(config.GetValue(configPath)), nil
+	}
+
 	return config.GetValue(configPath), env.ErrorDispatch(err)
 }
 
@@ -154,3 +203,153 @@ func restConfigReload(context api.InterfaceApplicationContext) (interface{}, err
 
 	return "ok", nil
 }
+
+// WEB REST API used to get change history of particular config item
+//   - path should be without any wildcard
+//   - "limit" argument restricts amount of returned records
+func restConfigHistory(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	configPath := context.GetRequestArgument("path")
+	limit := utils.InterfaceToInt(context.GetRequestArgument("limit"))
+
+	records, err := config.GetValueHistory(configPath, limit)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if env.ConfigIsSecret(configPath) {
+		for _, record := range records {
+			record["old_value"] = MaskSecret(record["old_value"])
+			record["new_value"] = MaskSecret(record["new_value"])
+		}
+	}
+
+	return records, nil
+}
+
+// WEB REST API used to rollback particular config item to a value it had before the change
+//   - "id" of config history record could be specified, otherwise the latest change is rolled back
+func restConfigRollback(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	configPath := context.GetRequestArgument("path")
+	changeID := utils.InterfaceToString(api.GetArgumentOrContentValue(context, "id"))
+
+	if err := config.RollbackValue(configPath, changeID, getActor(context)); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if env.ConfigIsSecret(configPath) {
+		return MaskSecret(config.GetValue(configPath)), nil
+	}
+
+	return config.GetValue(configPath), nil
+}
+
+// WEB REST API used to export stored values of all config items
+func restConfigSnapshotExport(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return config.ExportSnapshot(), nil
+}
+
+// WEB REST API used to import config values exported by "config/snapshot" GET request
+//   - request content should be exported array or map with such array in "items" key
+//   - "test" flag makes dry run, so only paths which would be changed are returned
+func restConfigSnapshotImport(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	content := context.GetRequestContent()
+	if contentMap, ok := content.(map[string]interface{}); ok {
+		content = contentMap["items"]
+	}
+
+	contentItems, ok := content.([]interface{})
+	if !ok {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4f7a1d3e-9c2b-4a8e-b5d6-0e3f2c9a7b18", "config snapshot should be an array of items")
+	}
+
+	var items []map[string]interface{}
+	for _, contentItem := range contentItems {
+		if item, ok := contentItem.(map[string]interface{}); ok {
+			items = append(items, item)
+		}
+	}
+
+	testMode := utils.InterfaceToBool(api.GetArgumentOrContentValue(context, "test"))
+
+	return config.ImportSnapshot(items, getActor(context), testMode)
+}
+
+// WEB REST API used to re-encrypt secret config values with a new key
+//   - "key" should be specified within request content, it should be from 1 to 31 characters long
+func restConfigRotateSecretsKey(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	newKey := utils.InterfaceToString(api.GetContentValue(context, "key"))
+
+	return config.RotateSecretsKey(newKey)
+}
+
+// WEB REST API used to get config value overrides of particular store
+func restConfigStoreValues(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return config.GetStoreValues(context.GetRequestArgument("storeID")), nil
+}
+
+// WEB REST API used to override config value for particular store
+func restConfigStoreSet(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	setValue := context.GetRequestContent()
+	if content, err := api.GetRequestContentAsMap(context); err == nil {
+		if contentValue, present := content["value"]; present {
+			setValue = contentValue
+		}
+	}
+
+	storeID := context.GetRequestArgument("storeID")
+	configPath := context.GetRequestArgument("path")
+	if err := config.SetStoreValue(storeID, configPath, setValue); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return config.GetStoreValues(storeID)[configPath], nil
+}
+
+// WEB REST API used to remove config value override of particular store
+func restConfigStoreRemove(context api.InterfaceApplicationContext) (interface{}, error) {
+	config, err := getDefaultConfig()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := config.RemoveStoreValue(context.GetRequestArgument("storeID"), context.GetRequestArgument("path")); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"net/http"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// setupErrorCatalog registers package errors API clients could branch on within error catalog
+func setupErrorCatalog() error {
+	items := []env.StructErrorCatalogItem{
+		{
+			Code:          "c7724469-8acb-41f4-a031-08b016053b58",
+			Name:          "config_access_denied",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusForbidden,
+			Message:       "Config value '{{.path}}' is available to administrators only.",
+			Documentation: "Config values related to passwords, logins and secrets could be read within admin session only.",
+		},
+		{
+			Code:          "5b9c3c8e-2f6a-4d7e-9a51-7d0e4b6f8c13",
+			Name:          "config_no_changes_to_rollback",
+			Module:        ConstErrorModule,
+			HTTPStatus:    http.StatusNotFound,
+			Message:       "Config value '{{.path}}' has no recorded changes to rollback.",
+			Documentation: "Rollback without change ID undoes the latest recorded change, but config history have no records for the path.",
+		},
+	}
+
+	for _, item := range items {
+		if err := env.ErrorRegisterCatalogItem(item); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
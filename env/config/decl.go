@@ -1,20 +1,66 @@
 package config
 
 import (
+	"sync"
+
 	"github.com/ottemo/foundation/env"
 )
 
 // Package global constants
 const (
-	ConstCollectionNameConfig = "config"
+	ConstCollectionNameConfig        = "config"
+	ConstCollectionNameConfigHistory = "config_history"
+	ConstCollectionNameConfigStore   = "config_store"
+
+	ConstActorSystem = "system" // config changes made by application itself
+	ConstActorImpex  = "impex"  // config changes made by impex import
+	ConstActorAdmin  = "admin"  // config changes made within admin session not bound to visitor
+	ConstActorAPI    = "api"    // config changes made through API with other kind of authorization
+
+	ConstSessionKeyVisitorID = "visitor_id" // session key visitor id stored in, same as visitor model uses
+
+	ConstSourceDefault     = "default"     // value given on config item registration
+	ConstSourceDB          = "db"          // value stored in config collection
+	ConstSourceIni         = "ini"         // value taken from ini file
+	ConstSourceEnvironment = "environment" // value taken from OTTEMO_ prefixed environment variable
+	ConstSourceSecrets     = "secrets"     // value taken from file within mounted secrets directory
+
+	ConstEnvironmentPrefix     = "OTTEMO_"
+	ConstEnvironmentSources    = "OTTEMO_CONFIG_SOURCES"
+	ConstEnvironmentSecretsDir = "OTTEMO_SECRETS_DIR"
+	ConstEnvironmentCryptKey   = "OTTEMO_CRYPT_KEY"
+	ConstIniSecretsDir         = "config.secrets.dir"
+	ConstIniCryptKey           = "crypt.key"
+
+	ConstSecretMask        = "******" // replacement for values of secret config items within API responses
+	ConstCryptKeyMaxLength = 31       // the longest key AES cipher accepts once it is padded by utils.MakeKey
+
+	ConstLogStorage = "config.log"
 
 	ConstErrorModule = "env/config"
 	ConstErrorLevel  = env.ConstErrorLevelService
 )
 
+// Package global variables
+var (
+	// sourcesPrecedence is a list of config value sources, the first source having a value wins
+	//   - could be overridden with comma separated list in OTTEMO_CONFIG_SOURCES environment variable
+	sourcesPrecedence = []string{ConstSourceSecrets, ConstSourceEnvironment, ConstSourceIni, ConstSourceDB, ConstSourceDefault}
+)
+
 // DefaultConfig is a default implementer of InterfaceConfig
 type DefaultConfig struct {
 	configValues     map[string]interface{}
 	configTypes      map[string]string
 	configValidators map[string]env.FuncConfigValueValidator
+
+	configDefaults  map[string]interface{}
+	configOverrides map[string]interface{}
+	configSources   map[string]string
+
+	storeValues map[string]map[string]interface{} // per-store value overrides: storeID -> path -> value
+	storeMutex  sync.RWMutex                      // synchronization on store values access
+
+	secretPaths      map[string]bool // paths of secret config items, rebuilt on config change
+	secretPathsMutex sync.RWMutex    // synchronization on secret paths access
 }
@@ -26,6 +26,37 @@ of functions config.GetGroupItems() and config.GetItemsInfo() you can filter gro
 
 Each config value can have validator function associated, which can also modify value puring verification.
 
+Config value could be supplied by a several sources (layers). Sources are looked through in order of their precedence
+and the first one having a value wins:
+
+    "secrets"     - file named as config path (or as environment variable) within directory set by
+                    OTTEMO_SECRETS_DIR environment variable or "config.secrets.dir" ini value
+    "environment" - environment variable, "payment.stripe.apiKey" path reads OTTEMO_PAYMENT_STRIPE_APIKEY
+    "ini"         - ottemo.ini value with same name as config path
+    "db"          - value stored in config collection
+    "default"     - value config item was registered with
+
+Precedence could be changed with comma separated list of sources in OTTEMO_CONFIG_SOURCES environment variable. Values
+from sources other than "db" are not stored to database, so SetValue() have no visible effect for such paths. Source
+supplied a value is reported within "Source" field of config item info.
+
+Each value change is recorded to [ConstCollectionNameConfigHistory] collection with old and new values, actor made the
+change and time. Use SetValueBy() to specify actor, SetValue() records changes as made by [ConstActorSystem]. Recorded
+changes could be rolled back with RollbackValue(), and ExportSnapshot()/ImportSnapshot() allows to move stored values
+between application instances.
+
+Values of [env.ConstConfigTypeSecret] items are stored encrypted with utils crypt key, which is taken from
+OTTEMO_CRYPT_KEY environment variable or "crypt.key" ini value. Items registered as a secret after their plain value
+was stored are encrypted on registration. RotateSecretsKey() re-encrypts stored secrets with a new key, it is available as
+"config/secrets/rotate" API and "config rotate-key" command. Secret values
+are masked within API responses and skipped by impex export and config snapshot.
+
+Values of "db" and "default" sources could be overridden per store (see "github.com/ottemo/foundation/app/models/store"
+package). Overrides are kept within [ConstCollectionNameConfigStore] collection and applied by GetValue() when call
+stack works for a store, api.GetCurrentStoreID() returns it. Use SetStoreValue()/RemoveStoreValue() or
+"config/store/:storeID" API to manage them. Secret values and values of other sources are deployment level settings
+and could not be overridden per store.
+
 To be more consistent and clear it is highly recommended to declare config value paths as a package constants.
 
     Example 1:
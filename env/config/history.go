@@ -0,0 +1,112 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// recordChange stores config value change to config history collection
+func recordChange(path string, oldValue interface{}, newValue interface{}, actor string) error {
+	collection, err := db.GetCollection(ConstCollectionNameConfigHistory)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	record := map[string]interface{}{
+		"path":       path,
+		"old_value":  oldValue,
+		"new_value":  newValue,
+		"actor":      actor,
+		"created_at": time.Now(),
+	}
+
+	if _, err := collection.Save(record); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// GetValueHistory returns recorded changes of config path, newest first
+//   - limit is a maximum amount of records to return, 0 means no limit
+func (it *DefaultConfig) GetValueHistory(Path string, limit int) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+
+	collection, err := db.GetCollection(ConstCollectionNameConfigHistory)
+	if err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("path", "=", Path); err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddSort("created_at", true); err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	if limit > 0 {
+		if err := collection.SetLimit(0, limit); err != nil {
+			return result, env.ErrorDispatch(err)
+		}
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	valueType := it.configTypes[Path]
+	for _, record := range records {
+		if valueType != env.ConstConfigTypeSecret {
+			record["old_value"] = db.ConvertTypeFromDbToGo(record["old_value"], valueType)
+			record["new_value"] = db.ConvertTypeFromDbToGo(record["new_value"], valueType)
+		}
+		result = append(result, record)
+	}
+
+	return result, nil
+}
+
+// RollbackValue undoes config path change, so value becomes the one it was before the change
+//   - changeID is an id of config history record, blank value means the latest change
+//   - rollback is recorded to config history as a regular change made by given actor
+func (it *DefaultConfig) RollbackValue(Path string, changeID string, Actor string) error {
+	var record map[string]interface{}
+
+	if changeID == "" {
+		records, err := it.GetValueHistory(Path, 1)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+		if len(records) == 0 {
+			err := env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5b9c3c8e-2f6a-4d7e-9a51-7d0e4b6f8c13", "config item '"+Path+"' have no changes to rollback")
+			return env.ErrorWithDetails(err, map[string]interface{}{"path": Path})
+		}
+		record = records[0]
+	} else {
+		collection, err := db.GetCollection(ConstCollectionNameConfigHistory)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+
+		record, err = collection.LoadByID(changeID)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	if utils.InterfaceToString(record["path"]) != Path {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e3c1f7a2-6b0d-4c8e-8f25-1a9d3e7b5c64", "config history record '"+changeID+"' is not related to '"+Path+"'")
+	}
+
+	value := record["old_value"]
+	if valueType := it.configTypes[Path]; valueType != env.ConstConfigTypeSecret {
+		value = db.ConvertTypeFromDbToGo(value, valueType)
+	}
+
+	return it.SetValueBy(Path, value, Actor)
+}
@@ -23,6 +23,9 @@ func (it *DefaultConfig) ListPathes() []string {
 // RegisterItem registers new config value in system
 func (it *DefaultConfig) RegisterItem(Item env.StructConfigItem, Validator env.FuncConfigValueValidator) error {
 
+	// registered value is used as a default one if no other source have it
+	it.configDefaults[Item.Path] = Item.Value
+
 	// registering new config item
 	if _, present := it.configValues[Item.Path]; !present {
 
@@ -56,6 +59,12 @@ func (it *DefaultConfig) RegisterItem(Item env.StructConfigItem, Validator env.F
 
 		it.configValues[Item.Path] = Item.Value
 		it.configTypes[Item.Path] = Item.Type
+
+	} else if Item.Type == env.ConstConfigTypeSecret && it.configTypes[Item.Path] != env.ConstConfigTypeSecret {
+		// item became a secret, so its plain value stored before should be encrypted
+		if err := it.migrateToSecret(Item.Path); err != nil {
+			return env.ErrorDispatch(err)
+		}
 	}
 
 	// registering validator
@@ -73,6 +82,9 @@ func (it *DefaultConfig) RegisterItem(Item env.StructConfigItem, Validator env.F
 		}
 	}
 
+	it.resolveValue(Item.Path, getSecretsDir())
+	configChanged(Item.Path)
+
 	return nil
 }
 
@@ -96,6 +108,12 @@ func (it *DefaultConfig) UnregisterItem(Path string) error {
 			return env.ErrorDispatch(err)
 		}
 
+		for defaultPath := range it.configDefaults {
+			if strings.HasPrefix(defaultPath, Path) {
+				delete(it.configDefaults, defaultPath)
+			}
+		}
+
 		return it.Reload()
 	}
 
@@ -103,7 +121,17 @@ func (it *DefaultConfig) UnregisterItem(Path string) error {
 }
 
 // GetValue returns value for config item of nil if not present
+//   - value taken from the first source having it, refer to GetSourcesPrecedence()
+//   - store override is taken if request was made to store having it
 func (it *DefaultConfig) GetValue(Path string) interface{} {
+	if value, present := it.getStoreValue(Path); present {
+		return value
+	}
+
+	if value, present := it.configOverrides[Path]; present {
+		return value
+	}
+
 	if value, present := it.configValues[Path]; present {
 
 		if it.configTypes[Path] == env.ConstConfigTypeSecret {
@@ -118,7 +146,12 @@ func (it *DefaultConfig) GetValue(Path string) interface{} {
 
 // SetValue updates config item with new value, returns error if not possible
 func (it *DefaultConfig) SetValue(Path string, Value interface{}) error {
-	if _, present := it.configValues[Path]; present {
+	return it.SetValueBy(Path, Value, ConstActorSystem)
+}
+
+// SetValueBy updates config item with new value and records the change made by given actor to config history
+func (it *DefaultConfig) SetValueBy(Path string, Value interface{}, Actor string) error {
+	if oldValue, present := it.configValues[Path]; present {
 
 		// updating value on GO side
 		//--------------------------
@@ -173,6 +206,13 @@ func (it *DefaultConfig) SetValue(Path string, Value interface{}) error {
 			return env.ErrorDispatch(err)
 		}
 
+		if err := recordChange(Path, oldValue, it.configValues[Path], Actor); err != nil {
+			return env.ErrorDispatch(err)
+		}
+
+		it.resolveValue(Path, getSecretsDir())
+		configChanged(Path)
+
 	} else {
 		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6984f1ce-1fb1-40d5-b674-9d88956164c0", "can not find config item '"+Path+"' ")
 	}
@@ -201,29 +241,7 @@ func (it *DefaultConfig) GetGroupItems() []env.StructConfigItem {
 	}
 
 	for _, record := range records {
-
-		valueType := utils.InterfaceToString(record["type"])
-		valuePath := utils.InterfaceToString(record["path"])
-		configItem := env.StructConfigItem{
-			Path: valuePath,
-			Type: valueType,
-
-			Editor:  utils.InterfaceToString(record["editor"]),
-			Options: record["options"],
-
-			Label:       utils.InterfaceToString(record["label"]),
-			Description: utils.InterfaceToString(record["description"]),
-
-			Image: utils.InterfaceToString(record["image"]),
-		}
-
-		if valueType == env.ConstConfigTypeSecret {
-			configItem.Value = it.GetValue(valuePath)
-		} else {
-			configItem.Value = db.ConvertTypeFromDbToGo(record["value"], valueType)
-		}
-
-		result = append(result, configItem)
+		result = append(result, it.makeConfigItem(record))
 	}
 
 	return result
@@ -250,32 +268,40 @@ func (it *DefaultConfig) GetItemsInfo(Path string) []env.StructConfigItem {
 	}
 
 	for _, record := range records {
+		result = append(result, it.makeConfigItem(record))
+	}
 
-		valueType := utils.InterfaceToString(record["type"])
-		valuePath := utils.InterfaceToString(record["path"])
-		configItem := env.StructConfigItem{
-			Path: valuePath,
-			Type: valueType,
+	return result
+}
 
-			Editor:  utils.InterfaceToString(record["editor"]),
-			Options: record["options"],
+// makeConfigItem converts config collection record to config item structure
+func (it *DefaultConfig) makeConfigItem(record map[string]interface{}) env.StructConfigItem {
+	valueType := utils.InterfaceToString(record["type"])
+	valuePath := utils.InterfaceToString(record["path"])
+	configItem := env.StructConfigItem{
+		Path: valuePath,
+		Type: valueType,
 
-			Label:       utils.InterfaceToString(record["label"]),
-			Description: utils.InterfaceToString(record["description"]),
+		Editor:  utils.InterfaceToString(record["editor"]),
+		Options: record["options"],
 
-			Image: utils.InterfaceToString(record["image"]),
-		}
+		Label:       utils.InterfaceToString(record["label"]),
+		Description: utils.InterfaceToString(record["description"]),
 
-		if valueType == env.ConstConfigTypeSecret {
-			configItem.Value = it.GetValue(valuePath)
-		} else {
-			configItem.Value = db.ConvertTypeFromDbToGo(record["value"], valueType)
-		}
+		Image: utils.InterfaceToString(record["image"]),
 
-		result = append(result, configItem)
+		Source: it.GetValueSource(valuePath),
 	}
 
-	return result
+	if valueType == env.ConstConfigTypeSecret {
+		configItem.Value = MaskSecret(it.GetValue(valuePath))
+	} else if _, present := it.configOverrides[valuePath]; present {
+		configItem.Value = it.GetValue(valuePath)
+	} else {
+		configItem.Value = db.ConvertTypeFromDbToGo(record["value"], valueType)
+	}
+
+	return configItem
 }
 
 // Load loads config data from DB on app startup
@@ -299,6 +325,8 @@ func (it *DefaultConfig) Load() error {
 func (it *DefaultConfig) Reload() error {
 	it.configValues = make(map[string]interface{})
 	it.configTypes = make(map[string]string)
+	it.configOverrides = make(map[string]interface{})
+	it.configSources = make(map[string]string)
 
 	collection, err := db.GetCollection(ConstCollectionNameConfig)
 	if err != nil {
@@ -327,5 +355,44 @@ func (it *DefaultConfig) Reload() error {
 		it.configTypes[valuePath] = valueType
 	}
 
+	it.resolveValues()
+
+	if err := it.loadStoreValues(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	configChanged("")
+
 	return nil
 }
+
+// configChanged notifies listeners config value of given path was changed, blank path means all values
+func configChanged(path string) {
+	if config, ok := env.GetConfig().(*DefaultConfig); ok {
+		config.updateSecretPaths()
+	}
+
+	env.Event(env.ConstEventConfigChange, map[string]interface{}{"path": path})
+}
+
+// updateSecretPaths rebuilds set of secret config item paths, so it is checked without database access
+func (it *DefaultConfig) updateSecretPaths() {
+	secretPaths := make(map[string]bool)
+	for path, valueType := range it.configTypes {
+		if valueType == env.ConstConfigTypeSecret {
+			secretPaths[path] = true
+		}
+	}
+
+	it.secretPathsMutex.Lock()
+	it.secretPaths = secretPaths
+	it.secretPathsMutex.Unlock()
+}
+
+// IsSecret returns true if config item with given path have [ConstConfigTypeSecret] type
+func (it *DefaultConfig) IsSecret(Path string) bool {
+	it.secretPathsMutex.RLock()
+	defer it.secretPathsMutex.RUnlock()
+
+	return it.secretPaths[Path]
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -18,7 +19,7 @@ func (it *DefaultConfig) Import(item map[string]interface{}, testMode bool) (map
 	value, _ = item["value"]
 
 	if testMode == false {
-		err := it.SetValue(path, value)
+		err := it.SetValueBy(path, value, ConstActorImpex)
 		return item, err
 	}
 
@@ -26,8 +27,13 @@ func (it *DefaultConfig) Import(item map[string]interface{}, testMode bool) (map
 }
 
 // Export exports config values through Impex
+//   - values of secret config items are not exported
 func (it *DefaultConfig) Export(iterator func(map[string]interface{}) bool) error {
 	for itemPath, itemValue := range it.configValues {
+		if it.configTypes[itemPath] == env.ConstConfigTypeSecret {
+			continue
+		}
+
 		continueFlag := iterator(map[string]interface{}{"path": itemPath, "value": itemValue})
 		if continueFlag == false {
 			break
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/cli"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/impex"
@@ -12,7 +13,14 @@ func init() {
 	instance := &DefaultConfig{
 		configValues:     make(map[string]interface{}),
 		configTypes:      make(map[string]string),
-		configValidators: make(map[string]env.FuncConfigValueValidator)}
+		configValidators: make(map[string]env.FuncConfigValueValidator),
+		configDefaults:   make(map[string]interface{}),
+		configOverrides:  make(map[string]interface{}),
+		configSources:    make(map[string]string),
+		storeValues:      make(map[string]map[string]interface{})}
+
+	env.RegisterOnConfigIniStart(setupCryptKey)
+	env.RegisterOnConfigIniStart(setupErrorCatalog)
 
 	db.RegisterOnDatabaseStart(setupDB)
 	db.RegisterOnDatabaseStart(instance.Load)
@@ -26,6 +34,15 @@ func init() {
 	if err := impex.RegisterImpexModel("Config", instance); err != nil {
 		_ = env.ErrorDispatch(err)
 	}
+
+	if err := cli.RegisterCommand(cli.StructCommand{
+		Name:        "config rotate-key",
+		Usage:       "key",
+		Description: "re-encrypts secret config values with a new key",
+		Handler:     commandRotateSecretsKey,
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
 }
 
 // setupDB prepares system database for package usage
@@ -64,5 +81,41 @@ func setupDB() error {
 		return env.ErrorDispatch(err)
 	}
 
+	collection, err = db.GetCollection(ConstCollectionNameConfigStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddColumn("store_id", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("path", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("value", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	collection, err = db.GetCollection(ConstCollectionNameConfigHistory)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddColumn("path", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("old_value", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("new_value", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("actor", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("created_at", db.ConstTypeDatetime, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
 	return nil
 }
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"crypto/aes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// MaskSecret returns value to show instead of secret config value, blank values are kept as is
+func MaskSecret(value interface{}) interface{} {
+	if utils.InterfaceToString(value) == "" {
+		return value
+	}
+	return ConstSecretMask
+}
+
+// validateCryptKey checks that key could be used by utils crypt functions
+//   - key is padded to the next multiple of AES block size, so keys of 32 characters and longer are not accepted
+func validateCryptKey(key string) error {
+	if key == "" || len(key) > ConstCryptKeyMaxLength {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "0c5e8b7d-4a1f-4d3b-9e62-f8a7c1d05b39", "crypt key should be from 1 to 31 characters long")
+	}
+	if _, err := aes.NewCipher(utils.MakeKey([]byte(key))); err != nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5b2e9d41-c7a3-4f86-8d15-e0a6f3b7c924", "crypt key is not accepted by cipher: "+err.Error())
+	}
+	return nil
+}
+
+// setupCryptKey makes key from environment variable or ini file current one for encryption of secret config values
+func setupCryptKey() error {
+	key := os.Getenv(ConstEnvironmentCryptKey)
+	if key == "" {
+		key = env.IniValue(ConstIniCryptKey)
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	if err := validateCryptKey(key); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := utils.SetKey([]byte(key)); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// migrateToSecret encrypts stored value of config item which was registered as a secret after the value was stored
+func (it *DefaultConfig) migrateToSecret(path string) error {
+	collection, err := db.GetCollection(ConstCollectionNameConfig)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("path", "=", path); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	for _, record := range records {
+		stringValue := utils.DecryptString(utils.InterfaceToString(record["value"]))
+
+		record["type"] = env.ConstConfigTypeSecret
+		record["value"] = utils.EncryptString(stringValue)
+
+		if _, err := collection.Save(record); err != nil {
+			return env.ErrorDispatch(err)
+		}
+
+		it.configValues[path] = record["value"]
+		it.configTypes[path] = env.ConstConfigTypeSecret
+	}
+
+	// values within change history are also plain
+	historyCollection, err := db.GetCollection(ConstCollectionNameConfigHistory)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := historyCollection.AddFilter("path", "=", path); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	historyRecords, err := historyCollection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	for _, record := range historyRecords {
+		record["old_value"] = utils.EncryptString(utils.DecryptString(utils.InterfaceToString(record["old_value"])))
+		record["new_value"] = utils.EncryptString(utils.DecryptString(utils.InterfaceToString(record["new_value"])))
+
+		if _, err := historyCollection.Save(record); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
+
+// RotateSecretsKey re-encrypts stored secret config values and their change history with a new key
+//   - all records are saved encrypted with new key before it becomes current one, records saved before a failure are
+//     restored to previous values, so storage is never left encrypted with mixed keys
+//   - new key is stored to ini file, unless key was set by environment variable
+//   - returns paths of re-encrypted config items
+func (it *DefaultConfig) RotateSecretsKey(newKey string) ([]string, error) {
+	var result []string
+
+	if err := validateCryptKey(newKey); err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	oldCryptKey := utils.GetKey()
+	newCryptKey := utils.MakeKey([]byte(newKey))
+
+	configCollection, err := db.GetCollection(ConstCollectionNameConfig)
+	if err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	if err := configCollection.AddFilter("type", "=", env.ConstConfigTypeSecret); err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	configRecords, err := configCollection.Load()
+	if err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	historyCollection, err := db.GetCollection(ConstCollectionNameConfigHistory)
+	if err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	// re-encrypting values in memory, previous values are kept for rollback
+	//   - each value is checked to decrypt back with new key, so nothing is saved if encryption fails
+	reEncrypt := func(record map[string]interface{}, columns ...string) (map[string]interface{}, error) {
+		previous := make(map[string]interface{})
+		for key, value := range record {
+			previous[key] = value
+		}
+		for _, column := range columns {
+			plainValue := utils.DecryptStringWithKey(utils.InterfaceToString(record[column]), oldCryptKey)
+			encryptedValue := utils.EncryptStringWithKey(plainValue, newCryptKey)
+			if encryptedValue == "" || utils.DecryptStringWithKey(encryptedValue, newCryptKey) != plainValue {
+				return previous, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e93a7c05-2d6b-4f18-b4c0-7a5e1d9f3b62", "unable to re-encrypt "+column+" of "+utils.InterfaceToString(record["path"])+" with new key")
+			}
+			record[column] = encryptedValue
+		}
+		return previous, nil
+	}
+
+	var configPrevious, historyRecords, historyPrevious []map[string]interface{}
+	for _, record := range configRecords {
+		path := utils.InterfaceToString(record["path"])
+		result = append(result, path)
+
+		previous, err := reEncrypt(record, "value")
+		if err != nil {
+			return result, env.ErrorDispatch(err)
+		}
+		configPrevious = append(configPrevious, previous)
+
+		if err := historyCollection.AddFilter("path", "=", path); err != nil {
+			return result, env.ErrorDispatch(err)
+		}
+
+		records, err := historyCollection.Load()
+		if err != nil {
+			return result, env.ErrorDispatch(err)
+		}
+
+		for _, historyRecord := range records {
+			previous, err := reEncrypt(historyRecord, "old_value", "new_value")
+			if err != nil {
+				return result, env.ErrorDispatch(err)
+			}
+			historyPrevious = append(historyPrevious, previous)
+			historyRecords = append(historyRecords, historyRecord)
+		}
+
+		if err := historyCollection.ClearFilters(); err != nil {
+			return result, env.ErrorDispatch(err)
+		}
+	}
+
+	// saving re-encrypted records, on failure saved ones are restored
+	rollback := func(collection db.InterfaceDBCollection, previous []map[string]interface{}) {
+		for _, record := range previous {
+			if _, err := collection.Save(record); err != nil {
+				env.LogError(err)
+			}
+		}
+	}
+
+	for idx, record := range configRecords {
+		if _, err := configCollection.Save(record); err != nil {
+			rollback(configCollection, configPrevious[:idx])
+			return result, env.ErrorDispatch(err)
+		}
+	}
+
+	for idx, record := range historyRecords {
+		if _, err := historyCollection.Save(record); err != nil {
+			rollback(historyCollection, historyPrevious[:idx])
+			rollback(configCollection, configPrevious)
+			return result, env.ErrorDispatch(err)
+		}
+	}
+
+	// all records are encrypted with new key, so it could become current one
+	if err := utils.SetKey([]byte(newKey)); err != nil {
+		return result, env.ErrorDispatch(err)
+	}
+
+	for _, record := range configRecords {
+		it.configValues[utils.InterfaceToString(record["path"])] = record["value"]
+	}
+
+	if os.Getenv(ConstEnvironmentCryptKey) != "" {
+		env.Log(ConstLogStorage, env.ConstLogPrefixWarning, "crypt key was rotated, "+ConstEnvironmentCryptKey+" environment variable should be updated")
+	} else if iniConfig := env.GetIniConfig(); iniConfig != nil {
+		if err := iniConfig.SetValue(ConstIniCryptKey, newKey); err != nil {
+			return result, env.ErrorDispatch(err)
+		}
+	}
+
+	return result, nil
+}
+
+// commandRotateSecretsKey re-encrypts secret config values with a new key given as argument
+func commandRotateSecretsKey(args []string, output io.Writer) error {
+	if len(args) != 1 {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3e8a5d17-9c24-4b6f-a0d3-c71f2e9b8a45", "new key should be specified")
+	}
+
+	config, err := getDefaultConfig()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	paths, err := config.RotateSecretsKey(args[0])
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	for _, path := range paths {
+		fmt.Fprintln(output, path)
+	}
+	fmt.Fprintln(output, len(paths), "secret values re-encrypted")
+
+	return nil
+}
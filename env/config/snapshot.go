@@ -0,0 +1,63 @@
+package config
+
+import (
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// ExportSnapshot returns stored values of all config items, so they could be imported on other instance
+//   - group items are skipped as they have no values
+//   - secret items are skipped as their values are encrypted with instance specific key
+//   - values are taken as they are stored in db, other sources are not considered
+func (it *DefaultConfig) ExportSnapshot() []map[string]interface{} {
+	var result []map[string]interface{}
+
+	for _, path := range it.ListPathes() {
+		valueType := it.configTypes[path]
+		if valueType == env.ConstConfigTypeGroup || valueType == env.ConstConfigTypeSecret {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"path":  path,
+			"type":  valueType,
+			"value": it.configValues[path],
+		})
+	}
+
+	return result
+}
+
+// ImportSnapshot applies config values previously exported by ExportSnapshot()
+//   - items for not registered paths, secret items and items with unchanged values are skipped
+//   - in test mode values are not applied, result contains paths which would be changed
+//   - each applied value is recorded to config history as a change made by given actor
+func (it *DefaultConfig) ImportSnapshot(items []map[string]interface{}, Actor string, testMode bool) (map[string]interface{}, error) {
+	changed := make([]string, 0)
+	skipped := make([]string, 0)
+
+	for _, item := range items {
+		path := utils.InterfaceToString(item["path"])
+		value := item["value"]
+
+		currentValue, present := it.configValues[path]
+		if !present || it.configTypes[path] == env.ConstConfigTypeGroup || it.configTypes[path] == env.ConstConfigTypeSecret {
+			skipped = append(skipped, path)
+			continue
+		}
+
+		if utils.InterfaceToString(currentValue) == utils.InterfaceToString(value) {
+			continue
+		}
+
+		if !testMode {
+			if err := it.SetValueBy(path, value, Actor); err != nil {
+				return nil, env.ErrorDispatch(err)
+			}
+		}
+
+		changed = append(changed, path)
+	}
+
+	return map[string]interface{}{"changed": changed, "skipped": skipped}, nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+)
+
+// EnvironmentVariableName returns environment variable name which overrides given config path
+//   - "payment.stripe.apiKey" becomes "OTTEMO_PAYMENT_STRIPE_APIKEY"
+func EnvironmentVariableName(path string) string {
+	replacer := strings.NewReplacer(".", "_", "-", "_")
+	return ConstEnvironmentPrefix + strings.ToUpper(replacer.Replace(path))
+}
+
+// GetSourcesPrecedence returns config value sources ordered by priority, the first one having a value wins
+func GetSourcesPrecedence() []string {
+	if value := os.Getenv(ConstEnvironmentSources); value != "" {
+		var result []string
+		for _, source := range strings.Split(value, ",") {
+			if source = strings.TrimSpace(source); source != "" {
+				result = append(result, source)
+			}
+		}
+		return result
+	}
+
+	return sourcesPrecedence
+}
+
+// getSecretsDir returns directory config values could be mounted in as files, or "" if not set
+func getSecretsDir() string {
+	if value := os.Getenv(ConstEnvironmentSecretsDir); value != "" {
+		return value
+	}
+	return env.IniValue(ConstIniSecretsDir)
+}
+
+// lookupSource returns raw value given source have for config path
+func (it *DefaultConfig) lookupSource(source string, path string, secretsDir string) (interface{}, bool) {
+	switch source {
+	case ConstSourceDefault:
+		value, present := it.configDefaults[path]
+		return value, present && value != nil
+
+	case ConstSourceDB:
+		value, present := it.configValues[path]
+		return value, present
+
+	case ConstSourceIni:
+		if iniConfig := env.GetIniConfig(); iniConfig != nil {
+			for _, itemName := range iniConfig.ListItems() {
+				if itemName == path {
+					value := iniConfig.GetValue(path, "")
+					return value, value != ""
+				}
+			}
+		}
+
+	case ConstSourceEnvironment:
+		if value, present := os.LookupEnv(EnvironmentVariableName(path)); present {
+			return value, true
+		}
+
+	case ConstSourceSecrets:
+		if secretsDir == "" {
+			return nil, false
+		}
+
+		// secret file could be named as config path or as environment variable
+		for _, fileName := range []string{path, EnvironmentVariableName(path)} {
+			if content, err := ioutil.ReadFile(filepath.Join(secretsDir, fileName)); err == nil {
+				return strings.TrimRight(string(content), "\r\n"), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// resolveValue looks through config value sources and takes effective value for given path
+//   - values from sources other than db are kept as overrides and never stored to db
+func (it *DefaultConfig) resolveValue(path string, secretsDir string) {
+	delete(it.configOverrides, path)
+	delete(it.configSources, path)
+
+	for _, source := range GetSourcesPrecedence() {
+		value, present := it.lookupSource(source, path, secretsDir)
+		if !present {
+			continue
+		}
+
+		if source == ConstSourceDB {
+			it.configSources[path] = source
+			return
+		}
+
+		// string values from outside should be converted to config item type
+		if source != ConstSourceDefault && it.configTypes[path] != env.ConstConfigTypeSecret {
+			value = db.ConvertTypeFromDbToGo(value, it.configTypes[path])
+		}
+
+		if validator, present := it.configValidators[path]; present && validator != nil {
+			newValue, err := validator(value)
+			if err != nil {
+				env.LogError(env.ErrorNew(ConstErrorModule, ConstErrorLevel, "a0f0a7e2-8d1c-4b8e-9f1c-2f7b5e3c6d41", "config value '"+path+"' from "+source+" source is not valid: "+err.Error()))
+				continue
+			}
+			value = newValue
+		}
+
+		it.configOverrides[path] = value
+		it.configSources[path] = source
+		return
+	}
+
+	if _, present := it.configValues[path]; present {
+		it.configSources[path] = ConstSourceDB
+	}
+}
+
+// resolveValues updates effective values for all known config paths
+func (it *DefaultConfig) resolveValues() {
+	secretsDir := getSecretsDir()
+	for path := range it.configTypes {
+		it.resolveValue(path, secretsDir)
+	}
+}
+
+// GetValueSource returns name of the source supplied current config value, or "" if path not present
+func (it *DefaultConfig) GetValueSource(Path string) string {
+	return it.configSources[Path]
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// loadStoreValues reads per-store config overrides from database
+func (it *DefaultConfig) loadStoreValues() error {
+	collection, err := db.GetCollection(ConstCollectionNameConfigStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	storeValues := make(map[string]map[string]interface{})
+	for _, record := range records {
+		storeID := utils.InterfaceToString(record["store_id"])
+		valuePath := utils.InterfaceToString(record["path"])
+
+		valueType, present := it.configTypes[valuePath]
+		if !present {
+			continue
+		}
+
+		if _, present := storeValues[storeID]; !present {
+			storeValues[storeID] = make(map[string]interface{})
+		}
+		storeValues[storeID][valuePath] = db.ConvertTypeFromDbToGo(record["value"], valueType)
+	}
+
+	it.storeMutex.Lock()
+	it.storeValues = storeValues
+	it.storeMutex.Unlock()
+
+	return nil
+}
+
+// getStoreValue returns config value override of store current call stack works for
+//   - values from ini, environment and secrets sources are not overridden, they are deployment level settings
+func (it *DefaultConfig) getStoreValue(path string) (interface{}, bool) {
+	it.storeMutex.RLock()
+	hasValues := len(it.storeValues) > 0
+	it.storeMutex.RUnlock()
+
+	if !hasValues {
+		return nil, false
+	}
+
+	if source := it.configSources[path]; source != ConstSourceDB && source != ConstSourceDefault {
+		return nil, false
+	}
+
+	storeID := api.GetCurrentStoreID()
+	if storeID == "" {
+		return nil, false
+	}
+
+	it.storeMutex.RLock()
+	defer it.storeMutex.RUnlock()
+
+	value, present := it.storeValues[storeID][path]
+	return value, present
+}
+
+// GetStoreValues returns config value overrides of given store
+func (it *DefaultConfig) GetStoreValues(storeID string) map[string]interface{} {
+	it.storeMutex.RLock()
+	defer it.storeMutex.RUnlock()
+
+	result := make(map[string]interface{})
+	for path, value := range it.storeValues[storeID] {
+		result[path] = value
+	}
+	return result
+}
+
+// SetStoreValue overrides config value for given store, value is validated the same way as global one
+//   - secret values can't be overridden per store
+func (it *DefaultConfig) SetStoreValue(storeID string, Path string, Value interface{}) error {
+	if storeID == "" {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "3e7b9d2f-a514-4c86-b0f3-6d1a8c5e2b97", "store id should be specified")
+	}
+
+	valueType, present := it.configTypes[Path]
+	if !present || valueType == env.ConstConfigTypeGroup {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c2a6e8f1-5d39-4b70-9e4c-1f8b3d7a6c25", "can not find config item '"+Path+"'")
+	}
+	if valueType == env.ConstConfigTypeSecret {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9f1d4b7e-2c68-4a35-8e0b-a7c3f5d9e162", "config item '"+Path+"' is secret, it can't be overridden per store")
+	}
+
+	if validator, present := it.configValidators[Path]; present && validator != nil {
+		newValue, err := validator(Value)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+		Value = newValue
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameConfigStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("store_id", "=", storeID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddFilter("path", "=", Path); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	record := map[string]interface{}{"store_id": storeID, "path": Path}
+	if len(records) > 0 {
+		record = records[0]
+	}
+	record["value"] = Value
+
+	if _, err := collection.Save(record); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	it.storeMutex.Lock()
+	if _, present := it.storeValues[storeID]; !present {
+		it.storeValues[storeID] = make(map[string]interface{})
+	}
+	it.storeValues[storeID][Path] = Value
+	it.storeMutex.Unlock()
+
+	configChanged(Path)
+
+	return nil
+}
+
+// RemoveStoreValue removes config value override of given store, global value is used then
+func (it *DefaultConfig) RemoveStoreValue(storeID string, Path string) error {
+	collection, err := db.GetCollection(ConstCollectionNameConfigStore)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("store_id", "=", storeID); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if Path != "" {
+		if err := collection.AddFilter("path", "=", Path); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	if _, err := collection.Delete(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	it.storeMutex.Lock()
+	if Path != "" {
+		delete(it.storeValues[storeID], Path)
+	}
+	if Path == "" || len(it.storeValues[storeID]) == 0 {
+		delete(it.storeValues, storeID)
+	}
+	it.storeMutex.Unlock()
+
+	configChanged(Path)
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorhill/cronexpr"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/metrics"
 )
 
 // Package global constants
@@ -13,6 +14,12 @@ const (
 	ConstErrorLevel  = env.ConstErrorLevelService
 )
 
+// Package global variables
+var (
+	metricTaskRuns     = metrics.NewCounter("ottemo_cron_task_runs_total", "Amount of executed scheduler tasks.", "task", "result")
+	metricTaskDuration = metrics.NewHistogram("ottemo_cron_task_duration_seconds", "Scheduler tasks execution duration.", []float64{.1, .5, 1, 5, 15, 30, 60, 300, 900, 3600}, "task")
+)
+
 // DefaultCronScheduler is a default implementer of InterfaceIniConfig
 type DefaultCronScheduler struct {
 	tasks     map[string]env.FuncCronTask
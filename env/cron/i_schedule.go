@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/gorhill/cronexpr"
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
 	"github.com/ottemo/foundation/env"
 	"github.com/ottemo/foundation/utils"
 )
@@ -26,7 +28,7 @@ func (it *DefaultCronSchedule) Execute() {
 			}
 		}
 
-		err := it.task(it.Params)
+		err := it.runTask(it.Params)
 		if err != nil {
 			err = env.ErrorDispatch(err)
 			env.Log("cron.log", env.ConstLogPrefixError, err.Error())
@@ -38,7 +40,8 @@ func (it *DefaultCronSchedule) Execute() {
 			it.active = false
 		}
 
-	} else {
+	} else if !app.IsCommandMode() {
+		time.Sleep(time.Second)
 		go it.Execute()
 	}
 }
@@ -150,8 +153,30 @@ func (it *DefaultCronSchedule) GetInfo() map[string]interface{} {
 // otherwise schedule params will be used
 func (it *DefaultCronSchedule) RunTask(params map[string]interface{}) error {
 	if params != nil {
-		return it.task(params)
+		return it.runTask(params)
 	}
 
-	return it.task(it.Params)
+	return it.runTask(it.Params)
+}
+
+// runTask executes schedule task with given params and updates task metrics
+//   - task is not started if application is stopping, running task delays application stop
+func (it *DefaultCronSchedule) runTask(params map[string]interface{}) error {
+	finish, err := api.StartOperation()
+	if err != nil {
+		return err
+	}
+	defer finish()
+
+	defer metricTaskDuration.ObserveDuration(time.Now(), it.TaskName)
+
+	err = it.task(params)
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	metricTaskRuns.Inc(it.TaskName, result)
+
+	return err
 }
@@ -28,6 +28,27 @@ func (it *DefaultCronScheduler) RegisterTask(name string, task env.FuncCronTask)
 	return nil
 }
 
+// RunTask executes registered task immediately within current go routine, task is not scheduled
+func (it *DefaultCronScheduler) RunTask(name string, params map[string]interface{}) error {
+	task, present := it.tasks[name]
+	if !present {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5d8b3f1a-c726-4e94-a0b7-2f9e6c1d8a53", "unexistent task")
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+
+	schedule := &DefaultCronSchedule{
+		TaskName:  name,
+		Params:    params,
+		Time:      time.Now(),
+		task:      task,
+		scheduler: it}
+
+	return schedule.runTask(params)
+}
+
 // ScheduleAtTime schedules task execution once with a given params
 func (it *DefaultCronScheduler) ScheduleAtTime(scheduleTime time.Time, taskName string, params map[string]interface{}) (env.InterfaceSchedule, error) {
 
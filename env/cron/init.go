@@ -34,6 +34,11 @@ func (it *DefaultCronScheduler) appInitEvent() error {
 
 	// TODO: load manually specified tasks from DB
 
+	// command line mode runs tasks on demand only
+	if app.IsCommandMode() {
+		return nil
+	}
+
 	it.appStarted = true
 
 	return nil
@@ -0,0 +1,86 @@
+package errorbus
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// SetDetails attaches details to given error, regular errors are converted to OttemoError
+func (it *DefaultErrorBus) SetDetails(err error, details map[string]interface{}) error {
+	if err == nil {
+		return err
+	}
+
+	ottemoErr, ok := err.(*OttemoError)
+	if !ok {
+		ottemoErr = it.parseErrorMessage(err.Error())
+	}
+
+	if ottemoErr.Details == nil {
+		ottemoErr.Details = make(map[string]interface{})
+	}
+	for key, value := range details {
+		ottemoErr.Details[key] = value
+	}
+
+	return it.process(ottemoErr)
+}
+
+// RegisterCatalogItem adds well known error code to error catalog
+//   - code and name are required, name should not be used by other code
+//   - HTTP status defaults to 400 as catalog errors are mostly caused by client request
+func (it *DefaultErrorBus) RegisterCatalogItem(item env.StructErrorCatalogItem) error {
+	item.Code = strings.ToLower(strings.TrimSpace(item.Code))
+	item.Name = strings.TrimSpace(item.Name)
+
+	if item.Code == "" || item.Name == "" {
+		return it.New(ConstErrorModule, ConstErrorLevel, "3e1d8b52-07c4-4c3f-b7f1-9a0c6d2e5f48", "error catalog item should have code and name")
+	}
+
+	it.catalogMutex.Lock()
+	defer it.catalogMutex.Unlock()
+
+	if code, present := it.catalogNames[item.Name]; present && code != item.Code {
+		return it.New(ConstErrorModule, ConstErrorLevel, "b8f0c4d1-6e2a-4a57-8d93-5c1e7f2b0a64", "error catalog name '"+item.Name+"' is already used by '"+code+"'")
+	}
+
+	if item.HTTPStatus == 0 {
+		item.HTTPStatus = http.StatusBadRequest
+	}
+
+	if previous, present := it.catalog[item.Code]; present {
+		delete(it.catalogNames, previous.Name)
+	}
+
+	it.catalog[item.Code] = item
+	it.catalogNames[item.Name] = item.Code
+
+	return nil
+}
+
+// GetCatalogItem returns error catalog information for given error code
+func (it *DefaultErrorBus) GetCatalogItem(code string) (env.StructErrorCatalogItem, bool) {
+	it.catalogMutex.RLock()
+	defer it.catalogMutex.RUnlock()
+
+	item, present := it.catalog[strings.ToLower(code)]
+	return item, present
+}
+
+// GetCatalog returns all registered error catalog items ordered by name
+func (it *DefaultErrorBus) GetCatalog() []env.StructErrorCatalogItem {
+	it.catalogMutex.RLock()
+	defer it.catalogMutex.RUnlock()
+
+	result := make([]env.StructErrorCatalogItem, 0, len(it.catalog))
+	for _, item := range it.catalog {
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
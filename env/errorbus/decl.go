@@ -1,8 +1,10 @@
 package errorbus
 
 import (
-	"github.com/ottemo/foundation/env"
 	"regexp"
+	"sync"
+
+	"github.com/ottemo/foundation/env"
 )
 
 // Package global constants
@@ -30,6 +32,10 @@ var (
 // DefaultErrorBus InterfaceErrorBus implementer class
 type DefaultErrorBus struct {
 	listeners []env.FuncErrorListener
+
+	catalog      map[string]env.StructErrorCatalogItem
+	catalogNames map[string]string
+	catalogMutex sync.RWMutex
 }
 
 // OttemoError @reconcile@ InterfaceOttemoError implementer class
@@ -40,6 +46,7 @@ type OttemoError struct {
 	Level   int
 
 	CallStack string
+	Details   map[string]interface{}
 
 	handled bool
 	logged  bool
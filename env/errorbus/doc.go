@@ -30,6 +30,22 @@ to get unique UUID identifier.
 Once handled message will nto be handled second time it come to error bus as it flags as handled. In this way error
 message can safely travel between application routines, knowing that it will be logged only once (first occurrence).
 
+Error codes API clients could branch on should be registered within error catalog. Catalog item binds error code to a
+stable machine name, HTTP status, user facing message template and documentation. REST service renders catalog message
+using error details as template context, so details should contain all the values message template refers to.
+GET app/errors endpoint lists registered catalog items.
+
+	env.ErrorRegisterCatalogItem(env.StructErrorCatalogItem{
+		Code:       "29fba7a4-bd85-400e-81c2-69189c50d0d0",
+		Name:       "visitor_not_verified",
+		Module:     ConstErrorModule,
+		HTTPStatus: http.StatusForbidden,
+		Message:    "Please check your email account {{.email}} for a verification link.",
+	})
+
+	err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "29fba7a4-bd85-400e-81c2-69189c50d0d0", "not verified")
+	return env.ErrorWithDetails(err, map[string]interface{}{"email": email})
+
 As "module name" and "error level" in most cases not changing for a particular package, it is recommended to use package
 constants for them, like:
 	ConstErrorModule = "rts"
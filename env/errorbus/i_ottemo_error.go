@@ -41,12 +41,22 @@ func (it *OttemoError) ErrorCode() string {
 	return it.Code
 }
 
+// ErrorModule returns name of module error was raised in, if specified
+func (it *OttemoError) ErrorModule() string {
+	return it.Module
+}
+
 // ErrorCallStack returns error functions call stack for error
 //   Note: ConstCollectStack constant should be set to true, otherwise, stack information will be blank
 func (it *OttemoError) ErrorCallStack() string {
 	return it.CallStack
 }
 
+// ErrorDetails returns additional information attached to error, or nil if there is no
+func (it *OttemoError) ErrorDetails() map[string]interface{} {
+	return it.Details
+}
+
 // IsHandled returns handled flag
 func (it *OttemoError) IsHandled() bool {
 	return it.handled
@@ -7,7 +7,11 @@ import (
 
 // init makes package self-initialization routine
 func init() {
-	instance := &DefaultErrorBus{listeners: make([]env.FuncErrorListener, 0)}
+	instance := &DefaultErrorBus{
+		listeners:    make([]env.FuncErrorListener, 0),
+		catalog:      make(map[string]env.StructErrorCatalogItem),
+		catalogNames: make(map[string]string),
+	}
 	var _ env.InterfaceErrorBus = instance
 
 	var _ env.InterfaceOttemoError = new(OttemoError)
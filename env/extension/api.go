@@ -0,0 +1,63 @@
+package extension
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+)
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("extensions", api.IsAdminHandler(restList))
+	service.GET("extension/:name", api.IsAdminHandler(restGet))
+	service.POST("extension/:name/enable", api.IsAdminHandler(restEnable))
+	service.POST("extension/:name/disable", api.IsAdminHandler(restDisable))
+
+	return nil
+}
+
+// WEB REST API used to list registered extensions along with their state
+func restList(context api.InterfaceApplicationContext) (interface{}, error) {
+	return List(), nil
+}
+
+// WEB REST API used to get registered extension manifest and state
+func restGet(context api.InterfaceApplicationContext) (interface{}, error) {
+	name := context.GetRequestArgument("name")
+
+	info, present := GetInfo(name)
+	if !present {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "2a7e5c9f-b386-4d14-a0e9-5f1c8d3b7e62", "unknown extension '"+name+"'")
+	}
+
+	return info, nil
+}
+
+// WEB REST API used to enable extension at runtime
+func restEnable(context api.InterfaceApplicationContext) (interface{}, error) {
+	name := context.GetRequestArgument("name")
+
+	if err := Enable(name); err != nil {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	info, _ := GetInfo(name)
+	return info, nil
+}
+
+// WEB REST API used to disable extension at runtime
+func restDisable(context api.InterfaceApplicationContext) (interface{}, error) {
+	name := context.GetRequestArgument("name")
+
+	if err := Disable(name); err != nil {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	info, _ := GetInfo(name)
+	return info, nil
+}
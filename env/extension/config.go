@@ -0,0 +1,52 @@
+package extension
+
+import (
+	"github.com/ottemo/foundation/env"
+)
+
+// setupConfig setups enabled flags for extensions not having own one
+func setupConfig() error {
+	config := env.GetConfig()
+	if config == nil {
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "c4e8a2f6-1d95-4b73-9a0c-7e3b6d1f5a48", "can't obtain config")
+		return env.ErrorDispatch(err)
+	}
+
+	err := config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathExtensions,
+		Value:       nil,
+		Type:        env.ConstConfigTypeGroup,
+		Editor:      "",
+		Options:     nil,
+		Label:       "Extensions",
+		Description: "extensions enabled flags",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	for _, info := range List() {
+		if info.Core || info.EnabledConfigPath != ConstConfigPathExtensions+"."+info.Name {
+			continue
+		}
+
+		err = config.RegisterItem(env.StructConfigItem{
+			Path:        info.EnabledConfigPath,
+			Value:       true,
+			Type:        env.ConstConfigTypeBoolean,
+			Editor:      "boolean",
+			Options:     nil,
+			Label:       info.Name,
+			Description: info.Description,
+			Image:       "",
+		}, nil)
+
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package extension
+
+import (
+	"sync"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstConfigPathExtensions = "general.extensions" // group of enabled flags for extensions not having own flag
+
+	ConstEventEnable  = "extension.enable"  // event emitted after extension was enabled, "name" argument is set
+	ConstEventDisable = "extension.disable" // event emitted after extension was disabled, "name" argument is set
+
+	ConstErrorModule = "env/extension"
+	ConstErrorLevel  = env.ConstErrorLevelService
+)
+
+// Package global variables
+var (
+	extensions      = make(map[string]*StructManifest)
+	extensionsMutex sync.RWMutex
+
+	routesCache      = make(map[string]bool) // store id and API resource to route availability map
+	routesCacheMutex sync.RWMutex
+)
+
+// StructManifest describes extension (actor) within the system
+//   - "Routes" are API resources extension registers (as they given to rest service), trailing "*" matches any
+//     resource with given prefix, routes of disabled extension respond as not existing ones
+//   - "CronTasks" and "Events" are filled by RegisterTask() and EventRegisterListener() routines
+//   - "EnabledConfigPath" is an existing config flag extension is enabled by, registry makes own flag within
+//     ConstConfigPathExtensions group if it is blank
+type StructManifest struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	Description  string   `json:"description"`
+	Dependencies []string `json:"dependencies"`
+
+	Core bool `json:"core"` // core extension can't be disabled
+
+	ConfigGroup       string `json:"config_group"`
+	EnabledConfigPath string `json:"enabled_config_path"`
+
+	Routes    []string `json:"routes"`
+	CronTasks []string `json:"cron_tasks"`
+	Events    []string `json:"events"`
+
+	OnEnable  func() error `json:"-"` // called after extension was enabled
+	OnDisable func() error `json:"-"` // called after extension was disabled
+}
+
+// StructExtensionInfo is a extension manifest along with its current state
+type StructExtensionInfo struct {
+	StructManifest
+
+	Enabled    bool     `json:"enabled"`    // extension enabled flag
+	Active     bool     `json:"active"`     // extension and all its dependencies are enabled
+	Dependents []string `json:"dependents"` // extensions depending on this one
+}
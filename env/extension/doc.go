@@ -0,0 +1,43 @@
+// Copyright 2014 The Ottemo Authors. All rights reserved.
+
+/*
+Package extension is a registry of application extensions (actors) allowing to enable and disable them at runtime.
+
+Actors are compiled in and self-register within package init(), so extension registry does not load code, it
+controls whether registered code is active. Each actor declares a manifest describing it: name, version,
+dependencies, config group, API routes it registers, scheduler tasks and event listeners.
+
+	func init() {
+		if err := extension.Register(extension.StructManifest{
+			Name:              "mailchimp",
+			Version:           "1.0.0",
+			Description:       "MailChimp integration",
+			ConfigGroup:       ConstConfigPathMailchimp,
+			EnabledConfigPath: ConstConfigPathMailchimpEnabled,
+			Routes:            []string{"mailchimp/*"},
+		}); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+
+		extension.EventRegisterListener("mailchimp", "checkout.success", checkoutSuccessHandler)
+	}
+
+Extension enabled state is a config value: "EnabledConfigPath" of manifest if actor already have such flag, or
+"general.extensions.[name]" made by registry otherwise. Extension is active when it and all its dependencies are
+enabled. Core extensions (catalog, cart, checkout, etc.) can't be disabled.
+
+Disabling extension makes its routes respond as not existing ones (404 status), its event listeners and scheduler
+tasks are skipped, so listeners and tasks should be registered with EventRegisterListener() and RegisterTask()
+routines of this package. Extension could not be disabled while some enabled extension depends on it, and it could
+not be enabled while its dependencies are disabled. Manifest "OnEnable" and "OnDisable" callbacks are called after
+state change, "extension.enable" and "extension.disable" events are emitted as well. Routes state is cached per
+store and evaluated again after "config.change" event.
+
+Admin API:
+
+	GET  extensions               - list of extensions along with their state
+	GET  extension/:name          - extension manifest and state
+	POST extension/:name/enable   - enables extension
+	POST extension/:name/disable  - disables extension
+*/
+package extension
@@ -0,0 +1,38 @@
+package extension
+
+import (
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/env"
+)
+
+// init makes package self-initialization routine
+func init() {
+	if err := api.RegisterRouteGuard(isRouteEnabled); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	env.RegisterOnConfigStart(setupConfig)
+	api.RegisterOnRestServiceStart(setupAPI)
+	app.OnAppStart(checkDependencies)
+	app.OnAppStart(initListeners)
+}
+
+// initListeners registers event listeners
+func initListeners() error {
+	env.EventRegisterListener(env.ConstEventConfigChange, configChangeListener)
+	return nil
+}
+
+// checkDependencies reports extensions depending on not registered ones, such extensions are not active
+func checkDependencies() error {
+	for _, info := range List() {
+		for _, dependency := range info.Dependencies {
+			if _, present := GetManifest(dependency); !present {
+				_ = env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "6f2d9b4e-a173-4c58-8e0d-1b7a5c3f9e26", "extension '"+info.Name+"' depends on unknown extension '"+dependency+"'")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,336 @@
+package extension
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// Register adds extension manifest to registry, it supposed to be called within package init()
+func Register(manifest StructManifest) error {
+	if manifest.Name == "" {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1f8c3e6a-d527-4b94-a0e1-7c5b9d2f4a36", "extension name should be specified")
+	}
+
+	if manifest.Version == "" {
+		manifest.Version = app.GetVersion()
+	}
+
+	if !manifest.Core && manifest.EnabledConfigPath == "" {
+		manifest.EnabledConfigPath = ConstConfigPathExtensions + "." + manifest.Name
+	}
+
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+
+	if _, present := extensions[manifest.Name]; present {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b3d9a6e1-4c72-4f58-8e0b-2a7f5c1d9e63", "extension '"+manifest.Name+"' already registered")
+	}
+	extensions[manifest.Name] = &manifest
+
+	resetRoutesCache()
+
+	return nil
+}
+
+// GetManifest returns manifest of registered extension
+func GetManifest(name string) (StructManifest, bool) {
+	extensionsMutex.RLock()
+	defer extensionsMutex.RUnlock()
+
+	if manifest, present := extensions[name]; present {
+		return *manifest, true
+	}
+	return StructManifest{}, false
+}
+
+// List returns registered extensions along with their state ordered by name
+func List() []StructExtensionInfo {
+	extensionsMutex.RLock()
+	var names []string
+	for name := range extensions {
+		names = append(names, name)
+	}
+	extensionsMutex.RUnlock()
+
+	sort.Strings(names)
+
+	result := make([]StructExtensionInfo, 0, len(names))
+	for _, name := range names {
+		if info, present := GetInfo(name); present {
+			result = append(result, info)
+		}
+	}
+	return result
+}
+
+// GetInfo returns registered extension manifest along with its state
+func GetInfo(name string) (StructExtensionInfo, bool) {
+	manifest, present := GetManifest(name)
+	if !present {
+		return StructExtensionInfo{}, false
+	}
+
+	return StructExtensionInfo{
+		StructManifest: manifest,
+		Enabled:        isFlagSet(manifest),
+		Active:         IsEnabled(name),
+		Dependents:     getDependents(name),
+	}, true
+}
+
+// isFlagSet checks extension enabled flag, extension is enabled until config is not available
+func isFlagSet(manifest StructManifest) bool {
+	if manifest.Core {
+		return true
+	}
+
+	value := env.ConfigGetValue(manifest.EnabledConfigPath)
+	if value == nil {
+		return true
+	}
+	return utils.InterfaceToBool(value)
+}
+
+// IsEnabled checks extension and all its dependencies are enabled, not registered extensions are considered enabled
+func IsEnabled(name string) bool {
+	return isEnabled(name, make(map[string]bool))
+}
+
+// isEnabled is a recursive part of IsEnabled(), visited map prevents dependencies loop
+func isEnabled(name string, visited map[string]bool) bool {
+	if visited[name] {
+		return true
+	}
+	visited[name] = true
+
+	manifest, present := GetManifest(name)
+	if !present {
+		return true
+	}
+
+	if !isFlagSet(manifest) {
+		return false
+	}
+
+	for _, dependency := range manifest.Dependencies {
+		if _, present := GetManifest(dependency); !present || !isEnabled(dependency, visited) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getDependents returns names of extensions directly depending on given one
+func getDependents(name string) []string {
+	extensionsMutex.RLock()
+	defer extensionsMutex.RUnlock()
+
+	result := make([]string, 0)
+	for dependentName, manifest := range extensions {
+		if utils.IsInListStr(name, manifest.Dependencies) {
+			result = append(result, dependentName)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// setFlag stores extension enabled flag
+func setFlag(manifest StructManifest, value bool) error {
+	config := env.GetConfig()
+	if config == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7e4b1d9c-a236-4f85-9c0e-5b8d3f2a6e17", "can't obtain config")
+	}
+
+	if err := config.SetValue(manifest.EnabledConfigPath, value); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	return nil
+}
+
+// Enable enables extension at runtime, its dependencies should be enabled before
+func Enable(name string) error {
+	manifest, present := GetManifest(name)
+	if !present {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "d6a2f8c5-3e91-4b47-8a0d-1c7e5b9f3a28", "unknown extension '"+name+"'")
+	}
+
+	for _, dependency := range manifest.Dependencies {
+		if _, present := GetManifest(dependency); !present {
+			return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4b9e7c2a-f158-4d36-b0e8-9a3d6f1c5e72", "extension '"+name+"' depends on unknown extension '"+dependency+"'")
+		}
+		if !IsEnabled(dependency) {
+			return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "a1c5e9d3-7b24-4f68-9e0c-3d8b2f6a1e54", "extension '"+name+"' depends on disabled extension '"+dependency+"'")
+		}
+	}
+
+	if isFlagSet(manifest) {
+		return nil
+	}
+
+	if err := setFlag(manifest, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if manifest.OnEnable != nil {
+		if err := manifest.OnEnable(); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	env.Event(ConstEventEnable, map[string]interface{}{"name": name})
+
+	return nil
+}
+
+// Disable disables extension at runtime, extensions depending on it should be disabled before
+func Disable(name string) error {
+	manifest, present := GetManifest(name)
+	if !present {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "5e8d2b7f-c463-4a19-8b0e-6f1a9c3d7e25", "unknown extension '"+name+"'")
+	}
+
+	if manifest.Core {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "e9f3a6c1-2d85-4b74-a0e7-8c5b1d4f9a36", "extension '"+name+"' is a core one and can't be disabled")
+	}
+
+	var enabledDependents []string
+	for _, dependent := range getDependents(name) {
+		if IsEnabled(dependent) {
+			enabledDependents = append(enabledDependents, dependent)
+		}
+	}
+	if len(enabledDependents) > 0 {
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3c7a1e9d-b546-4f82-9d0a-2e6f8b4c1d73", "extension '"+name+"' is required by: "+strings.Join(enabledDependents, ", "))
+	}
+
+	if !isFlagSet(manifest) {
+		return nil
+	}
+
+	if err := setFlag(manifest, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if manifest.OnDisable != nil {
+		if err := manifest.OnDisable(); err != nil {
+			return env.ErrorDispatch(err)
+		}
+	}
+
+	env.Event(ConstEventDisable, map[string]interface{}{"name": name})
+
+	return nil
+}
+
+// addToManifest appends value to manifest list field unless it is already there
+func addToManifest(name string, value string, field func(manifest *StructManifest) *[]string) {
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+
+	if manifest, present := extensions[name]; present {
+		if list := field(manifest); !utils.IsInListStr(value, *list) {
+			*list = append(*list, value)
+		}
+	}
+}
+
+// EventRegisterListener registers event listener on behalf of extension, listener is not called while extension is
+// disabled
+func EventRegisterListener(name string, event string, listener env.FuncEventListener) {
+	addToManifest(name, event, func(manifest *StructManifest) *[]string { return &manifest.Events })
+
+	env.EventRegisterListener(event, func(event string, eventData map[string]interface{}) bool {
+		if !IsEnabled(name) {
+			return true
+		}
+		return listener(event, eventData)
+	})
+}
+
+// RegisterTask registers scheduler task on behalf of extension, task is skipped while extension is disabled
+func RegisterTask(name string, taskName string, task env.FuncCronTask) error {
+	scheduler := env.GetScheduler()
+	if scheduler == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8a4f2c6e-d931-4b57-a0e8-7c3d5b1f9e42", "can't obtain scheduler")
+	}
+
+	addToManifest(name, taskName, func(manifest *StructManifest) *[]string { return &manifest.CronTasks })
+
+	err := scheduler.RegisterTask(taskName, func(params map[string]interface{}) error {
+		if !IsEnabled(name) {
+			return nil
+		}
+		return task(params)
+	})
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
+
+// isRouteEnabled is a route guard making routes of disabled extensions not available
+//   - result is cached per store as flags could be overridden per store, cache is dropped on config change
+func isRouteEnabled(resource string) bool {
+	cacheKey := api.GetCurrentStoreID() + " " + resource
+
+	routesCacheMutex.RLock()
+	result, present := routesCache[cacheKey]
+	routesCacheMutex.RUnlock()
+
+	if present {
+		return result
+	}
+
+	result = true
+	for _, name := range getRouteOwners(resource) {
+		if !IsEnabled(name) {
+			result = false
+			break
+		}
+	}
+
+	routesCacheMutex.Lock()
+	routesCache[cacheKey] = result
+	routesCacheMutex.Unlock()
+
+	return result
+}
+
+// getRouteOwners returns names of extensions given API resource belongs to
+func getRouteOwners(resource string) []string {
+	extensionsMutex.RLock()
+	defer extensionsMutex.RUnlock()
+
+	var result []string
+	for name, manifest := range extensions {
+		for _, route := range manifest.Routes {
+			if route == resource || (strings.HasSuffix(route, "*") && strings.HasPrefix(resource, strings.TrimSuffix(route, "*"))) {
+				result = append(result, name)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// resetRoutesCache makes routes state to be evaluated again on next request
+func resetRoutesCache() {
+	routesCacheMutex.Lock()
+	routesCache = make(map[string]bool)
+	routesCacheMutex.Unlock()
+}
+
+// configChangeListener drops routes state cache as extension flags could be changed
+func configChangeListener(event string, eventData map[string]interface{}) bool {
+	resetRoutesCache()
+	return true
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/ottemo/foundation/api"
+)
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("health/live", restLiveness)
+	api.RegisterSessionlessRoute("health/live")
+	service.GET("health/ready", restReadiness)
+	api.RegisterSessionlessRoute("health/ready")
+
+	return nil
+}
+
+// respond runs probes of given kind and sets response status, so orchestrator could rely on it only
+func respond(context api.InterfaceApplicationContext, kind string) (interface{}, error) {
+	status, results := Check(kind)
+
+	if status != ConstStatusOK {
+		context.SetResponseStatus(http.StatusServiceUnavailable)
+	}
+
+	return map[string]interface{}{
+		"status": status,
+		"probes": results,
+	}, nil
+}
+
+// WEB REST API used to check application process is alive
+func restLiveness(context api.InterfaceApplicationContext) (interface{}, error) {
+	return respond(context, ConstKindLiveness)
+}
+
+// WEB REST API used to check application is ready to serve requests
+func restReadiness(context api.InterfaceApplicationContext) (interface{}, error) {
+	return respond(context, ConstKindReadiness)
+}
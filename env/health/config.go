@@ -0,0 +1,69 @@
+package health
+
+import (
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupConfig setups package configuration values for a system
+func setupConfig() error {
+	config := env.GetConfig()
+	if config == nil {
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "f17c4a2e-9b3d-4e60-8a5f-2d6b9e0c3a71", "can't obtain config")
+		return env.ErrorDispatch(err)
+	}
+
+	err := config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathHealth,
+		Value:       nil,
+		Type:        env.ConstConfigTypeGroup,
+		Editor:      "",
+		Options:     nil,
+		Label:       "Health",
+		Description: "health checks settings",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	positiveValidator := func(newValue interface{}) (interface{}, error) {
+		if utils.InterfaceToInt(newValue) < 0 {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8b5e2d07-4c1f-4a96-9d38-e6f0a7b1c254", "value should not be negative")
+		}
+		return utils.InterfaceToInt(newValue), nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathHealthCacheTTL,
+		Value:       ConstDefaultCacheTTL,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Cache TTL",
+		Description: "seconds probe result is reused for, so frequent checks do not load resources",
+		Image:       "",
+	}, positiveValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathHealthTimeout,
+		Value:       ConstDefaultTimeout,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Probe timeout",
+		Description: "seconds probe could take before it is considered failed",
+		Image:       "",
+	}, positiveValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
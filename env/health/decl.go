@@ -0,0 +1,62 @@
+package health
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstKindLiveness  = "liveness"  // probe checks application process is working and should not be restarted
+	ConstKindReadiness = "readiness" // probe checks application is able to serve requests
+
+	ConstStatusOK       = "ok"
+	ConstStatusFail     = "fail"
+	ConstStatusDraining = "draining"
+
+	ConstConfigPathHealth         = "general.health"
+	ConstConfigPathHealthCacheTTL = "general.health.cache_ttl"
+	ConstConfigPathHealthTimeout  = "general.health.timeout"
+
+	ConstDefaultCacheTTL = 5 // seconds probe result is reused for, when config is not available yet
+	ConstDefaultTimeout  = 3 // seconds probe could take, when config is not available yet
+
+	ConstErrorModule = "env/health"
+	ConstErrorLevel  = env.ConstErrorLevelService
+)
+
+// Package global variables
+var (
+	probes      = make(map[string]*probe)
+	probesMutex sync.RWMutex
+
+	draining      bool
+	drainingMutex sync.RWMutex
+)
+
+// FuncProbe is a health probe callback function prototype, nil result means healthy
+type FuncProbe func() error
+
+// probe holds registered probe along with its last result
+type probe struct {
+	name     string
+	kind     string
+	critical bool
+	check    FuncProbe
+
+	result StructProbeResult
+	mutex  sync.Mutex
+}
+
+// StructProbeResult is a result of health probe execution
+type StructProbeResult struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Error     string    `json:"error,omitempty"`
+	LatencyMs float64   `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+	Cached    bool      `json:"cached"`
+}
@@ -0,0 +1,23 @@
+// Copyright 2014 The Ottemo Authors. All rights reserved.
+
+/*
+Package health is a registry of health probes exposed at GET health/live and GET health/ready endpoints.
+
+Liveness probes check the application process works and should not be restarted, so they should not depend on
+external resources. Readiness probes check the application is able to serve requests, like database connection or
+session storage availability. Endpoints respond with 503 status if any critical probe fails, non critical probes are
+reported only, which suits optional integrations like payment gateways.
+
+	health.RegisterProbe("db", health.ConstKindReadiness, true, func() error {
+		return connector.Ping()
+	})
+
+Probes run on every check, so they should return errors made with env.Error() rather than dispatched ones, otherwise
+each failed check gets to error stats. Endpoints are served without session, so orchestrator requests do not make
+sessions and cookies.
+
+Probe results are cached for "general.health.cache_ttl" seconds, each probe could take "general.health.timeout"
+seconds at most. Readiness status is "draining" since application end started, so traffic is routed to other
+instances while in-flight requests are completed.
+*/
+package health
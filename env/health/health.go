@@ -0,0 +1,170 @@
+package health
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// RegisterProbe adds health probe of given kind
+//   - failed critical probe makes whole check failed, non critical one is reported only
+//   - probe registered with already used name replaces previous one
+func RegisterProbe(name string, kind string, critical bool, check FuncProbe) error {
+	if name == "" || check == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6e2a9c41-8f0d-4b57-a3e1-d7c5b0f94a28", "health probe should have name and check function")
+	}
+
+	if kind != ConstKindLiveness && kind != ConstKindReadiness {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "b4d1f8e7-2c6a-4a93-8e05-1f9c3a7d6b52", "unknown health probe kind '"+kind+"'")
+	}
+
+	probesMutex.Lock()
+	probes[name] = &probe{name: name, kind: kind, critical: critical, check: check}
+	probesMutex.Unlock()
+
+	return nil
+}
+
+// UnregisterProbe removes health probe with given name
+func UnregisterProbe(name string) {
+	probesMutex.Lock()
+	delete(probes, name)
+	probesMutex.Unlock()
+}
+
+// SetDraining marks application as going to stop, so readiness check fails and traffic is routed elsewhere
+func SetDraining(value bool) {
+	drainingMutex.Lock()
+	draining = value
+	drainingMutex.Unlock()
+}
+
+// IsDraining returns true if application is going to stop
+func IsDraining() bool {
+	drainingMutex.RLock()
+	defer drainingMutex.RUnlock()
+	return draining
+}
+
+// getDuration returns config value in seconds as duration, or default one if config is not available yet
+func getDuration(path string, defaultValue int) time.Duration {
+	value := defaultValue
+	if configValue := env.ConfigGetValue(path); configValue != nil {
+		value = utils.InterfaceToInt(configValue)
+	}
+	return time.Duration(value) * time.Second
+}
+
+// run executes probe, or returns cached result if it is fresh enough
+func (it *probe) run(cacheTTL time.Duration, timeout time.Duration) StructProbeResult {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if !it.result.CheckedAt.IsZero() && time.Since(it.result.CheckedAt) < cacheTTL {
+		result := it.result
+		result.Cached = true
+		return result
+	}
+
+	startTime := time.Now()
+
+	// probe could hang on unavailable resource, so it is executed with timeout
+	done := make(chan error, 1)
+	go func() {
+		done <- it.check()
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(timeout):
+		err = env.Error(ConstErrorModule, ConstErrorLevel, "0d7e3b94-5a1c-4f68-b2e9-c8a6f1d4e037", "probe timed out after "+timeout.String())
+	}
+
+	it.result = StructProbeResult{
+		Name:      it.name,
+		Status:    ConstStatusOK,
+		Critical:  it.critical,
+		LatencyMs: float64(time.Since(startTime)) / float64(time.Millisecond),
+		CheckedAt: startTime,
+	}
+
+	if err != nil {
+		it.result.Status = ConstStatusFail
+		it.result.Error = err.Error()
+	}
+
+	return it.result
+}
+
+// Check runs all probes of given kind in parallel and returns overall status along with each probe result
+//   - readiness status is "draining" while application is stopping, regardless of probes results
+func Check(kind string) (string, []StructProbeResult) {
+	cacheTTL := getDuration(ConstConfigPathHealthCacheTTL, ConstDefaultCacheTTL)
+	timeout := getDuration(ConstConfigPathHealthTimeout, ConstDefaultTimeout)
+
+	probesMutex.RLock()
+	var kindProbes []*probe
+	for _, item := range probes {
+		if item.kind == kind {
+			kindProbes = append(kindProbes, item)
+		}
+	}
+	probesMutex.RUnlock()
+
+	results := make([]StructProbeResult, len(kindProbes))
+
+	var waitGroup sync.WaitGroup
+	for index, item := range kindProbes {
+		waitGroup.Add(1)
+		go func(index int, item *probe) {
+			defer waitGroup.Done()
+			results[index] = item.run(cacheTTL, timeout)
+		}(index, item)
+	}
+	waitGroup.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	status := ConstStatusOK
+	for _, result := range results {
+		if result.Critical && result.Status != ConstStatusOK {
+			status = ConstStatusFail
+		}
+	}
+
+	if kind == ConstKindReadiness && IsDraining() {
+		status = ConstStatusDraining
+	}
+
+	return status, results
+}
+
+// CheckURL checks host of given URL accepts connections, useful for probes of external services
+func CheckURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "5c9f0e3a-7d2b-4e81-a6c4-b3e8d1f07a95", "'"+rawURL+"' is not valid URL")
+	}
+
+	address := parsedURL.Host
+	if parsedURL.Port() == "" {
+		port := "80"
+		if parsedURL.Scheme == "https" {
+			port = "443"
+		}
+		address = net.JoinHostPort(parsedURL.Hostname(), port)
+	}
+
+	connection, err := net.DialTimeout("tcp", address, getDuration(ConstConfigPathHealthTimeout, ConstDefaultTimeout))
+	if err != nil {
+		return err
+	}
+
+	return connection.Close()
+}
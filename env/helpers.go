@@ -15,6 +15,25 @@ func ConfigGetValue(Path string) interface{} {
 	return nil
 }
 
+// ConfigIsSecret returns true if config item with given path have [ConstConfigTypeSecret] type
+//   - config service could keep set of secret paths, so it is checked without loading items info
+func ConfigIsSecret(Path string) bool {
+	if config := GetConfig(); config != nil {
+		if secretsConfig, ok := config.(interface {
+			IsSecret(Path string) bool
+		}); ok {
+			return secretsConfig.IsSecret(Path)
+		}
+
+		for _, item := range config.GetItemsInfo(Path) {
+			if item.Path == Path && item.Type == ConstConfigTypeSecret {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // IniValue returns value from ini file or "" if not present
 func IniValue(Path string) string {
 	if iniConfig := GetIniConfig(); iniConfig != nil {
@@ -116,6 +135,38 @@ func ErrorRaw(message string) error {
 	return errors.New(message)
 }
 
+// ErrorWithDetails attaches details to error, they are used to render catalog message and are shown to API client
+func ErrorWithDetails(err error, details map[string]interface{}) error {
+	if errorBus := GetErrorBus(); errorBus != nil {
+		return errorBus.SetDetails(err, details)
+	}
+	return err
+}
+
+// ErrorRegisterCatalogItem registers well known error code within error catalog
+func ErrorRegisterCatalogItem(item StructErrorCatalogItem) error {
+	if errorBus := GetErrorBus(); errorBus != nil {
+		return errorBus.RegisterCatalogItem(item)
+	}
+	return nil
+}
+
+// ErrorGetCatalogItem returns error catalog information for given error code
+func ErrorGetCatalogItem(code string) (StructErrorCatalogItem, bool) {
+	if errorBus := GetErrorBus(); errorBus != nil {
+		return errorBus.GetCatalogItem(code)
+	}
+	return StructErrorCatalogItem{}, false
+}
+
+// ErrorGetCatalog returns all registered error catalog items
+func ErrorGetCatalog() []StructErrorCatalogItem {
+	if errorBus := GetErrorBus(); errorBus != nil {
+		return errorBus.GetCatalog()
+	}
+	return nil
+}
+
 // EventRegisterListener registers listener for event bus
 func EventRegisterListener(event string, listener FuncEventListener) {
 	if eventBus := GetEventBus(); eventBus != nil {
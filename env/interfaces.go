@@ -37,6 +37,8 @@ const (
 	ConstErrorLevelStartStop  = 1
 	ConstErrorLevelExternal   = 0
 
+	ConstEventConfigChange = "config.change" // event emitted after config values were changed, "path" argument is set
+
 	ConstErrorModule = "env"
 	ConstErrorLevel  = ConstErrorLevelService
 )
@@ -59,6 +61,7 @@ type InterfaceSchedule interface {
 type InterfaceScheduler interface {
 	ListTasks() []string
 	RegisterTask(name string, task FuncCronTask) error
+	RunTask(name string, params map[string]interface{}) error
 
 	ScheduleAtTime(scheduleTime time.Time, taskName string, taskParams map[string]interface{}) (InterfaceSchedule, error)
 	ScheduleRepeat(cronExpr string, taskName string, taskParams map[string]interface{}) (InterfaceSchedule, error)
@@ -87,6 +90,12 @@ type InterfaceErrorBus interface {
 	Prepare(module string, level int, code string, message string) error
 	New(module string, level int, code string, message string) error
 	Raw(message string) error
+
+	SetDetails(err error, details map[string]interface{}) error
+
+	RegisterCatalogItem(item StructErrorCatalogItem) error
+	GetCatalogItem(code string) (StructErrorCatalogItem, bool)
+	GetCatalog() []StructErrorCatalogItem
 }
 
 // InterfaceLogger is an interface to system logging service
@@ -133,8 +142,10 @@ type InterfaceOttemoError interface {
 	ErrorFull() string
 	ErrorLevel() int
 	ErrorCode() string
+	ErrorModule() string
 	ErrorMessage() string
 	ErrorCallStack() string
+	ErrorDetails() map[string]interface{}
 
 	IsHandled() bool
 	MarkHandled() bool
@@ -172,4 +183,20 @@ type StructConfigItem struct {
 	Description string
 
 	Image string
+
+	Source string
+}
+
+// StructErrorCatalogItem is a structure to hold information about well known error code
+//   - Name is a stable machine name clients could branch on, unlike Code it is meant to be readable
+//   - Message is a user facing text/template message, error details are used as template context
+type StructErrorCatalogItem struct {
+	Code   string
+	Name   string
+	Module string
+
+	HTTPStatus int
+
+	Message       string
+	Documentation string
 }
@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"mime"
+	"path/filepath"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models"
+	"github.com/ottemo/foundation/env"
+)
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("jobs", api.IsAdminHandler(restList))
+	service.GET("jobs/:id", api.IsAdminHandler(restGet))
+	service.DELETE("jobs/:id", api.IsAdminHandler(restDelete))
+	service.GET("jobs/:id/artifact/:name", api.IsAdminHandler(restGetArtifact))
+
+	return nil
+}
+
+// WEB REST API used to list jobs from newest
+//   - "type" and "status" arguments filter jobs, "limit" argument is an "offset,limit" pair
+func restList(context api.InterfaceApplicationContext) (interface{}, error) {
+	offset, limit := models.GetListLimit(context)
+	if limit == 0 {
+		limit = 100
+	}
+
+	jobs, err := List(context.GetRequestArgument("type"), context.GetRequestArgument("status"), offset, limit)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(jobs))
+	for _, job := range jobs {
+		result = append(result, job.ToHashMap())
+	}
+
+	return result, nil
+}
+
+// WEB REST API used to get job status, progress and result
+func restGet(context api.InterfaceApplicationContext) (interface{}, error) {
+	job, err := Get(context.GetRequestArgument("id"))
+	if err != nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return job.ToHashMap(), nil
+}
+
+// WEB REST API used to cancel queued or running job, finished job is removed along with its artifacts
+func restDelete(context api.InterfaceApplicationContext) (interface{}, error) {
+	jobID := context.GetRequestArgument("id")
+
+	job, err := Get(jobID)
+	if err != nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	switch job.GetStatus() {
+	case ConstStatusNew, ConstStatusQueued, ConstStatusRunning:
+		if err := Cancel(jobID); err != nil {
+			context.SetResponseStatusBadRequest()
+			return nil, env.ErrorDispatch(err)
+		}
+
+		job, err = Get(jobID)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		return job.ToHashMap(), nil
+	}
+
+	if err := Delete(jobID); err != nil {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return "ok", nil
+}
+
+// WEB REST API used to download job file
+//   - result is not a JSON but a file content
+func restGetArtifact(context api.InterfaceApplicationContext) (interface{}, error) {
+	job, err := Get(context.GetRequestArgument("id"))
+	if err != nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	name := context.GetRequestArgument("name")
+	data, err := job.LoadArtifact(name)
+	if err != nil {
+		context.SetResponseStatusNotFound()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	if err := context.SetResponseContentType(contentType); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+	if err := context.SetResponseSetting("Content-disposition", "attachment;filename="+name); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupConfig setups package configuration values for a system
+func setupConfig() error {
+	config := env.GetConfig()
+	if config == nil {
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "a4d8f2c6-7e13-4b95-9c0a-3e6b1d9f5a72", "can't obtain config")
+		return env.ErrorDispatch(err)
+	}
+
+	err := config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathJobs,
+		Value:       nil,
+		Type:        env.ConstConfigTypeGroup,
+		Editor:      "",
+		Options:     nil,
+		Label:       "Background Jobs",
+		Description: "background jobs processing settings",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	// workers limit is applied at once
+	workersValidator := func(newValue interface{}) (interface{}, error) {
+		workers := utils.InterfaceToInt(newValue)
+		if workers < 1 {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "6e2b9d4a-f158-4c73-a0e9-1d7c5b3f8a26", "workers amount should be positive")
+		}
+		setWorkersLimit(workers)
+
+		return workers, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathJobsWorkers,
+		Value:       ConstDefaultWorkers,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Workers",
+		Description: "amount of background jobs running simultaneously",
+		Image:       "",
+	}, workersValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if value := env.ConfigGetValue(ConstConfigPathJobsWorkers); value != nil {
+		setWorkersLimit(utils.InterfaceToInt(value))
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathJobsRetention,
+		Value:       ConstDefaultRetention,
+		Type:        env.ConstConfigTypeInteger,
+		Editor:      "integer",
+		Options:     nil,
+		Label:       "Retention (days)",
+		Description: "days finished jobs and their files are kept",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstCollectionNameJob = "job"
+
+	ConstStatusNew       = "new"       // job created but not submitted yet (inputs are uploading)
+	ConstStatusQueued    = "queued"    // job waits for a worker
+	ConstStatusRunning   = "running"   // job handler is running
+	ConstStatusCompleted = "completed" // job handler finished successfully
+	ConstStatusFailed    = "failed"    // job handler returned error
+	ConstStatusCanceled  = "canceled"  // job was canceled before or during run
+
+	ConstConfigPathJobs          = "general.jobs"
+	ConstConfigPathJobsWorkers   = "general.jobs.workers"
+	ConstConfigPathJobsRetention = "general.jobs.retention"
+
+	ConstDefaultWorkers   = 2 // amount of jobs running simultaneously
+	ConstDefaultRetention = 7 // days finished jobs and their artifacts are kept
+
+	ConstProgressSaveInterval = 2 // seconds between job progress writes to database
+
+	ConstLeaseTime          = 120 // seconds node keeps queued or running job, other nodes restore job after that
+	ConstLeaseRenewInterval = 30  // seconds between lease renewals of jobs processed by node
+
+	ConstCleanupTaskName = "jobsCleanup"
+
+	ConstArtifactsModel = "job" // media storage model job artifacts are kept within
+
+	ConstErrorModule = "env/jobs"
+	ConstErrorLevel  = env.ConstErrorLevelService
+)
+
+// Package global variables
+var (
+	handlers = make(map[string]*structHandler) // registered job handlers by job type
+
+	queue        []*DefaultJob                  // jobs waiting for a worker in submit order
+	running      = make(map[string]*DefaultJob) // jobs currently running by job id
+	runningTypes = make(map[string]int)         // amount of running jobs by job type
+	workersLimit = ConstDefaultWorkers          // amount of jobs allowed to run simultaneously
+	workersCount int                            // amount of started worker go routines
+	queueMutex   sync.Mutex
+	queueCond    = sync.NewCond(&queueMutex)
+
+	nodeName           string // unique name of application process jobs are processed by
+	leaseKeeperStarted bool   // lease keeper go routine was started
+)
+
+// FuncJobHandler is a job handler, returned value is stored as job result
+//   - handler should check job.IsCanceled() periodically and return as soon as job canceled
+type FuncJobHandler func(job InterfaceJob) (interface{}, error)
+
+// structHandler is a registered job handler along with its concurrency limit
+type structHandler struct {
+	handler     FuncJobHandler
+	concurrency int
+}
+
+// DefaultJob is a default implementer of InterfaceJob
+type DefaultJob struct {
+	id      string
+	jobType string
+	params  map[string]interface{}
+
+	status    string
+	progress  int
+	total     int
+	message   string
+	result    interface{}
+	err       string
+	artifacts []string
+
+	createdAt  time.Time
+	startedAt  time.Time
+	finishedAt time.Time
+
+	node       string    // application process job belongs to
+	leaseUntil time.Time // time other nodes could take not finished job after
+
+	canceled bool
+	savedAt  time.Time
+
+	mutex sync.Mutex
+}
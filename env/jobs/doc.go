@@ -0,0 +1,40 @@
+// Copyright 2014 The Ottemo Authors. All rights reserved.
+
+/*
+Package jobs is a background job queue for long-running work (imports, exports, media processing, etc.) which should
+not be done within API request.
+
+Package registers handler for a job type, so job is a type and parameters persisted within database. Jobs are
+processed by a pool of workers, amount of simultaneously running jobs is limited by "general.jobs.workers" config
+value and, optionally, by job type concurrency. Handler reports progress and checks cancel flag, returned value is
+stored as job result, files handler makes are stored within media storage as job artifacts.
+
+	func init() {
+		jobs.RegisterHandler("impex.export", 1, func(job jobs.InterfaceJob) (interface{}, error) {
+			...
+			job.SetProgress(done, total)
+			if job.IsCanceled() {
+				return nil, nil
+			}
+			...
+			return nil, job.SaveArtifact("export.csv", data)
+		})
+	}
+
+	job, err := jobs.Run("impex.export", map[string]interface{}{"model": "Product"})
+
+Job input files could be saved as artifacts between NewJob() and Submit() calls. Job belongs to application process
+(node) which submitted or restored it, node renews lease of its not finished jobs, jobs of stopped or crashed node
+are restored by other (or restarted) node after lease expiration. Job state is checked within database before run
+and before finish, so job canceled by other node is not run or completed. Command line mode does not process jobs.
+Finished jobs are removed after "general.jobs.retention" days. "job.completed", "job.failed" and "job.canceled"
+events are emitted on job finish.
+
+Admin API:
+
+	GET    jobs                      - list of jobs, "type" and "status" filters, "limit" argument
+	GET    jobs/:id                  - job status, progress and result
+	DELETE jobs/:id                  - cancels queued or running job, removes finished one
+	GET    jobs/:id/artifact/:name   - downloads job file
+*/
+package jobs
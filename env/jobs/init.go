@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+)
+
+// init makes package self-initialization routine
+func init() {
+	// restarted process is a new node, its not finished jobs are restored after lease expiration
+	hostName, _ := os.Hostname()
+	nodeName = hostName + "." + strconv.Itoa(os.Getpid()) + "." + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	env.RegisterOnConfigStart(setupConfig)
+	api.RegisterOnRestServiceStart(setupAPI)
+	db.RegisterOnDatabaseStart(setupDB)
+	app.OnAppStart(onAppStart)
+}
+
+// setupDB prepares system database for package usage and restores not finished jobs
+func setupDB() error {
+	collection, err := db.GetCollection(ConstCollectionNameJob)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddColumn("type", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("node", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("lease_until", db.ConstTypeDatetime, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("status", db.ConstTypeVarchar, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("params", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("progress", db.ConstTypeInteger, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("total", db.ConstTypeInteger, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("message", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("result", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("error", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("artifacts", db.ConstTypeText, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("cancel", db.ConstTypeBoolean, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("created_at", db.ConstTypeDatetime, true); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("started_at", db.ConstTypeDatetime, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddColumn("finished_at", db.ConstTypeDatetime, false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if app.IsCommandMode() {
+		return nil
+	}
+
+	return restoreJobs()
+}
+
+// onAppStart starts workers and lease keeper, schedules finished jobs cleanup, command line mode does not process
+// jobs
+func onAppStart() error {
+	if app.IsCommandMode() {
+		return nil
+	}
+
+	queueMutex.Lock()
+	limit := workersLimit
+	startKeeper := !leaseKeeperStarted
+	leaseKeeperStarted = true
+	queueMutex.Unlock()
+
+	setWorkersLimit(limit)
+
+	if startKeeper {
+		go leaseKeeper()
+	}
+
+	if scheduler := env.GetScheduler(); scheduler != nil {
+		if err := scheduler.RegisterTask(ConstCleanupTaskName, cleanupTask); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+		if _, err := scheduler.ScheduleRepeat("0 3 * * *", ConstCleanupTaskName, nil); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package jobs
+
+// InterfaceJob represents background job for a job handler
+type InterfaceJob interface {
+	GetID() string
+	GetType() string
+	GetParams() map[string]interface{}
+	GetStatus() string
+
+	SetProgress(current int, total int)
+	SetMessage(message string)
+
+	IsCanceled() bool
+
+	SaveArtifact(name string, data []byte) error
+	LoadArtifact(name string) ([]byte, error)
+	GetArtifacts() []string
+
+	ToHashMap() map[string]interface{}
+}
@@ -0,0 +1,284 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/media"
+	"github.com/ottemo/foundation/utils"
+)
+
+// newJobFromRecord makes job instance from database record
+func newJobFromRecord(record map[string]interface{}) *DefaultJob {
+	job := &DefaultJob{
+		id:         utils.InterfaceToString(record["_id"]),
+		jobType:    utils.InterfaceToString(record["type"]),
+		node:       utils.InterfaceToString(record["node"]),
+		leaseUntil: utils.InterfaceToTime(record["lease_until"]),
+		status:     utils.InterfaceToString(record["status"]),
+		progress:   utils.InterfaceToInt(record["progress"]),
+		total:      utils.InterfaceToInt(record["total"]),
+		message:    utils.InterfaceToString(record["message"]),
+		err:        utils.InterfaceToString(record["error"]),
+		createdAt:  utils.InterfaceToTime(record["created_at"]),
+		startedAt:  utils.InterfaceToTime(record["started_at"]),
+		finishedAt: utils.InterfaceToTime(record["finished_at"]),
+		canceled:   utils.InterfaceToBool(record["cancel"]),
+	}
+
+	job.params, _ = utils.DecodeJSONToStringKeyMap(record["params"])
+	if job.params == nil {
+		job.params = make(map[string]interface{})
+	}
+
+	if value := utils.InterfaceToString(record["result"]); value != "" {
+		job.result, _ = utils.DecodeJSONToInterface(value)
+	}
+
+	if value := utils.InterfaceToString(record["artifacts"]); value != "" {
+		artifacts, _ := utils.DecodeJSONToInterface(value)
+		job.artifacts = utils.InterfaceToStringArray(artifacts)
+	}
+
+	return job
+}
+
+// toRecord makes database record from job instance, job mutex should be locked
+func (it *DefaultJob) toRecord() map[string]interface{} {
+	record := map[string]interface{}{
+		"type":        it.jobType,
+		"node":        it.node,
+		"lease_until": it.leaseUntil,
+		"status":      it.status,
+		"params":      utils.EncodeToJSONString(it.params),
+		"progress":    it.progress,
+		"total":       it.total,
+		"message":     it.message,
+		"result":      "",
+		"error":       it.err,
+		"artifacts":   utils.EncodeToJSONString(it.artifacts),
+		"cancel":      it.canceled,
+		"created_at":  it.createdAt,
+		"started_at":  it.startedAt,
+		"finished_at": it.finishedAt,
+	}
+
+	if it.id != "" {
+		record["_id"] = it.id
+	}
+	if it.result != nil {
+		record["result"] = utils.EncodeToJSONString(it.result)
+	}
+
+	return record
+}
+
+// refresh picks up cancel request made by other application node, false result means job was taken by other node
+// after lease expiration, so it should not be processed or written by job instance anymore
+func (it *DefaultJob) refresh() (bool, error) {
+	if it.id == "" {
+		return true, nil
+	}
+
+	record, err := loadRecord(it.id)
+	if err != nil {
+		return false, env.ErrorDispatch(err)
+	}
+
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if utils.InterfaceToString(record["node"]) != it.node {
+		return false, nil
+	}
+	if utils.InterfaceToBool(record["cancel"]) {
+		it.canceled = true
+		if it.status == ConstStatusQueued {
+			it.status = ConstStatusCanceled
+			it.finishedAt = time.Now()
+		}
+	}
+
+	return true, nil
+}
+
+// save writes job to database keeping cancel request made by other application node
+func (it *DefaultJob) save() error {
+	owned, err := it.refresh()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if !owned {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "6e3b9d1a-f472-4c58-8a0d-2b7e5c9f1a63", "job "+it.id+" is processed by other node")
+	}
+
+	return it.write()
+}
+
+// write writes job to database as is, lease is renewed for not finished job of current node
+func (it *DefaultJob) write() error {
+	collection, err := db.GetCollection(ConstCollectionNameJob)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if it.node == nodeName && (it.status == ConstStatusQueued || it.status == ConstStatusRunning) {
+		it.leaseUntil = time.Now().Add(ConstLeaseTime * time.Second)
+	}
+
+	id, err := collection.Save(it.toRecord())
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+	it.id = id
+	it.savedAt = time.Now()
+
+	return nil
+}
+
+// saveProgress writes running job progress to database not more often than progress save interval, cancel
+// request made by other application node is picked up meanwhile
+func (it *DefaultJob) saveProgress() {
+	it.mutex.Lock()
+	skip := time.Now().Sub(it.savedAt) < ConstProgressSaveInterval*time.Second
+	it.mutex.Unlock()
+
+	if skip {
+		return
+	}
+
+	if err := it.save(); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
+
+// InterfaceJob implementation
+// ---------------------------
+
+// GetID returns job id
+func (it *DefaultJob) GetID() string {
+	return it.id
+}
+
+// GetType returns job type handler is registered for
+func (it *DefaultJob) GetType() string {
+	return it.jobType
+}
+
+// GetParams returns job parameters given on job creation
+func (it *DefaultJob) GetParams() map[string]interface{} {
+	return it.params
+}
+
+// GetStatus returns current job status
+func (it *DefaultJob) GetStatus() string {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	return it.status
+}
+
+// SetProgress updates job progress, total is an amount of work units, current is amount of done ones
+func (it *DefaultJob) SetProgress(current int, total int) {
+	it.mutex.Lock()
+	it.progress = current
+	it.total = total
+	it.mutex.Unlock()
+
+	it.saveProgress()
+}
+
+// SetMessage updates job human readable state message
+func (it *DefaultJob) SetMessage(message string) {
+	it.mutex.Lock()
+	it.message = message
+	it.mutex.Unlock()
+
+	it.saveProgress()
+}
+
+// IsCanceled returns true if job cancel was requested
+func (it *DefaultJob) IsCanceled() bool {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	return it.canceled
+}
+
+// SaveArtifact stores job input or result file within media storage
+func (it *DefaultJob) SaveArtifact(name string, data []byte) error {
+	mediaStorage, err := media.GetMediaStorage()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := mediaStorage.Save(ConstArtifactsModel, it.id, media.ConstMediaTypeDocument, name, data); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	it.mutex.Lock()
+	if !utils.IsInListStr(name, it.artifacts) {
+		it.artifacts = append(it.artifacts, name)
+	}
+	it.mutex.Unlock()
+
+	return it.save()
+}
+
+// LoadArtifact reads job file from media storage
+func (it *DefaultJob) LoadArtifact(name string) ([]byte, error) {
+	if !utils.IsInListStr(name, it.GetArtifacts()) {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "4c8e2a7f-d193-4b56-9e0a-6f3b1d8c5a27", "job has no artifact '"+name+"'")
+	}
+
+	mediaStorage, err := media.GetMediaStorage()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return mediaStorage.Load(ConstArtifactsModel, it.id, media.ConstMediaTypeDocument, name)
+}
+
+// GetArtifacts returns names of job files
+func (it *DefaultJob) GetArtifacts() []string {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	result := make([]string, len(it.artifacts))
+	copy(result, it.artifacts)
+	return result
+}
+
+// ToHashMap returns job representation for API
+func (it *DefaultJob) ToHashMap() map[string]interface{} {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	result := map[string]interface{}{
+		"_id":         it.id,
+		"type":        it.jobType,
+		"status":      it.status,
+		"params":      it.params,
+		"progress":    it.progress,
+		"total":       it.total,
+		"message":     it.message,
+		"result":      it.result,
+		"error":       it.err,
+		"artifacts":   it.artifacts,
+		"cancel":      it.canceled,
+		"created_at":  it.createdAt,
+		"started_at":  nil,
+		"finished_at": nil,
+	}
+
+	if !it.startedAt.IsZero() {
+		result["started_at"] = it.startedAt
+	}
+	if !it.finishedAt.IsZero() {
+		result["finished_at"] = it.finishedAt
+	}
+
+	return result
+}
@@ -0,0 +1,286 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/media"
+)
+
+// RegisterHandler registers handler for given job type, it supposed to be called within package init()
+//   - concurrency limits amount of simultaneously running jobs of given type, 0 means workers limit only
+func RegisterHandler(jobType string, concurrency int, handler FuncJobHandler) error {
+	if jobType == "" || handler == nil {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "8b3d6f1e-a529-4c74-b0e8-2d7c9a5f1e36", "job type and handler should be specified")
+	}
+
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	if _, present := handlers[jobType]; present {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "e5a19c7d-3f62-4b08-9d4e-7a1b6c8f2d53", "job handler '"+jobType+"' already registered")
+	}
+	handlers[jobType] = &structHandler{handler: handler, concurrency: concurrency}
+
+	return nil
+}
+
+// NewJob makes new job of given type, job is not processed until submitted, so input artifacts could be saved before
+func NewJob(jobType string, params map[string]interface{}) (InterfaceJob, error) {
+	queueMutex.Lock()
+	_, present := handlers[jobType]
+	queueMutex.Unlock()
+
+	if !present {
+		return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "2f7c4e9a-b186-4d35-8a0e-c3d9f5b7a164", "unknown job type '"+jobType+"'")
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+
+	job := &DefaultJob{
+		jobType:   jobType,
+		params:    params,
+		status:    ConstStatusNew,
+		node:      nodeName,
+		createdAt: time.Now(),
+	}
+
+	if err := job.save(); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return job, nil
+}
+
+// Submit puts job to queue
+func Submit(job InterfaceJob) error {
+	defaultJob, ok := job.(*DefaultJob)
+	if !ok {
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "91d6b3a8-e427-4f5c-b0d9-5a8e2c7f3b16", "unexpected job instance")
+	}
+
+	defaultJob.mutex.Lock()
+	if defaultJob.status != ConstStatusNew {
+		defaultJob.mutex.Unlock()
+		return env.ErrorNew(ConstErrorModule, ConstErrorLevel, "c7e31f5b-9a84-4d26-8e0b-1f6d4a9c2e75", "job "+defaultJob.id+" already submitted")
+	}
+	defaultJob.status = ConstStatusQueued
+	defaultJob.mutex.Unlock()
+
+	if err := defaultJob.save(); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	enqueue(defaultJob)
+
+	return nil
+}
+
+// Run makes new job of given type and submits it
+func Run(jobType string, params map[string]interface{}) (InterfaceJob, error) {
+	job, err := NewJob(jobType, params)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := Submit(job); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return job, nil
+}
+
+// RunOnce returns queued or running job of given type, new job is submitted if there is no such
+func RunOnce(jobType string, params map[string]interface{}) (InterfaceJob, error) {
+	if job := findActive(jobType); job != nil {
+		return job, nil
+	}
+	return Run(jobType, params)
+}
+
+// findActive returns queued or running job of given type processed by current node
+func findActive(jobType string) *DefaultJob {
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	for _, job := range running {
+		if job.jobType == jobType {
+			return job
+		}
+	}
+	for _, job := range queue {
+		if job.jobType == jobType {
+			return job
+		}
+	}
+	return nil
+}
+
+// loadRecord reads job record from database
+func loadRecord(jobID string) (map[string]interface{}, error) {
+	collection, err := db.GetCollection(ConstCollectionNameJob)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	record, err := collection.LoadByID(jobID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if len(record) == 0 {
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "5a2e8d4f-c713-4b96-a0e5-9d1f7c3b6e28", "job "+jobID+" not found")
+	}
+
+	return record, nil
+}
+
+// Get returns job by id, running and queued jobs are taken from memory to have actual progress
+func Get(jobID string) (InterfaceJob, error) {
+	queueMutex.Lock()
+	job, present := running[jobID]
+	if !present {
+		for _, queuedJob := range queue {
+			if queuedJob.id == jobID {
+				job, present = queuedJob, true
+				break
+			}
+		}
+	}
+	queueMutex.Unlock()
+
+	if present {
+		return job, nil
+	}
+
+	record, err := loadRecord(jobID)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return newJobFromRecord(record), nil
+}
+
+// List returns jobs ordered from newest, optionally filtered by type and status
+func List(jobType string, status string, offset int, limit int) ([]InterfaceJob, error) {
+	collection, err := db.GetCollection(ConstCollectionNameJob)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if jobType != "" {
+		if err := collection.AddFilter("type", "=", jobType); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+	if status != "" {
+		if err := collection.AddFilter("status", "=", status); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+	if err := collection.AddSort("created_at", true); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	if limit > 0 {
+		if err := collection.SetLimit(offset, limit); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	result := make([]InterfaceJob, 0, len(records))
+	for _, record := range records {
+		job, err := Get(newJobFromRecord(record).id)
+		if err != nil {
+			continue
+		}
+		result = append(result, job)
+	}
+
+	return result, nil
+}
+
+// Cancel cancels queued or running job, running job stops as soon as its handler checks cancel flag
+//   - job running by other application node gets cancel flag on next progress update
+func Cancel(jobID string) error {
+	queueMutex.Lock()
+	job, isRunning := running[jobID]
+	if !isRunning {
+		for index, queuedJob := range queue {
+			if queuedJob.id == jobID {
+				job = queuedJob
+				queue = append(queue[:index], queue[index+1:]...)
+				break
+			}
+		}
+	}
+	queueMutex.Unlock()
+
+	if job == nil {
+		record, err := loadRecord(jobID)
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+		job = newJobFromRecord(record)
+	}
+
+	job.mutex.Lock()
+	switch job.status {
+	case ConstStatusCompleted, ConstStatusFailed, ConstStatusCanceled:
+		job.mutex.Unlock()
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "b8f42d6c-1e97-4a53-9c0d-e6a3f7b2c915", "job "+jobID+" already finished")
+	case ConstStatusRunning:
+		job.canceled = true
+	default:
+		job.canceled = true
+		job.status = ConstStatusCanceled
+		job.finishedAt = time.Now()
+	}
+	job.mutex.Unlock()
+
+	return job.save()
+}
+
+// Delete removes finished job along with its artifacts, not finished job should be canceled before
+func Delete(jobID string) error {
+	job, err := Get(jobID)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	switch job.GetStatus() {
+	case ConstStatusQueued, ConstStatusRunning:
+		return env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "3d9a7e1f-b562-4c84-8e0a-f1c5d7b3a926", "job "+jobID+" is not finished, it should be canceled first")
+	}
+
+	return deleteJob(job)
+}
+
+// deleteJob removes job record and artifacts
+func deleteJob(job InterfaceJob) error {
+	if artifacts := job.GetArtifacts(); len(artifacts) > 0 {
+		mediaStorage, err := media.GetMediaStorage()
+		if err != nil {
+			return env.ErrorDispatch(err)
+		}
+
+		for _, name := range artifacts {
+			if err := mediaStorage.Remove(ConstArtifactsModel, job.GetID(), media.ConstMediaTypeDocument, name); err != nil {
+				_ = env.ErrorDispatch(err)
+			}
+		}
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameJob)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return collection.DeleteByID(job.GetID())
+}
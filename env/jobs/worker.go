@@ -0,0 +1,324 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/db"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// enqueue puts job to queue and wakes up workers
+func enqueue(job *DefaultJob) {
+	queueMutex.Lock()
+	queue = append(queue, job)
+	queueMutex.Unlock()
+
+	queueCond.Broadcast()
+}
+
+// setWorkersLimit updates amount of simultaneously running jobs, worker go routines are started if needed
+func setWorkersLimit(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	queueMutex.Lock()
+	workersLimit = limit
+	for workersCount < workersLimit {
+		workersCount++
+		go worker()
+	}
+	queueMutex.Unlock()
+
+	queueCond.Broadcast()
+}
+
+// nextJob takes from queue first job allowed to run by workers and job type limits, returns nil if there is no such,
+// queue mutex should be locked
+func nextJob() *DefaultJob {
+	if len(running) >= workersLimit || api.IsStopping() {
+		return nil
+	}
+
+	for index, job := range queue {
+		handler, present := handlers[job.jobType]
+		if present && handler.concurrency > 0 && runningTypes[job.jobType] >= handler.concurrency {
+			continue
+		}
+
+		queue = append(queue[:index], queue[index+1:]...)
+		running[job.id] = job
+		runningTypes[job.jobType]++
+
+		return job
+	}
+
+	return nil
+}
+
+// worker is a worker go routine processing queued jobs
+func worker() {
+	for {
+		queueMutex.Lock()
+		job := nextJob()
+		for job == nil {
+			queueCond.Wait()
+			job = nextJob()
+		}
+		handler := handlers[job.jobType]
+		queueMutex.Unlock()
+
+		execute(job, handler)
+
+		queueMutex.Lock()
+		delete(running, job.id)
+		runningTypes[job.jobType]--
+		queueMutex.Unlock()
+
+		queueCond.Broadcast()
+	}
+}
+
+// execute runs job handler and stores job result
+//   - job stays queued if application is stopping, it is restored on next start
+func execute(job *DefaultJob, handler *structHandler) {
+	finish, err := api.StartOperation()
+	if err != nil {
+		return
+	}
+	defer finish()
+
+	// job could be canceled or taken by other node while it was queued
+	if owned, err := job.refresh(); err != nil || !owned {
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+		return
+	}
+
+	job.mutex.Lock()
+	if job.canceled {
+		job.mutex.Unlock()
+		return
+	}
+	job.status = ConstStatusRunning
+	job.startedAt = time.Now()
+	job.mutex.Unlock()
+
+	if err := job.save(); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	var result interface{}
+	if handler == nil {
+		err = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "f26b8d3e-4a91-4c57-b0e6-8d3c1f7a5e49", "unknown job type '"+job.jobType+"'")
+	} else {
+		result, err = runHandler(job, handler.handler)
+	}
+
+	// job could be canceled by other node meanwhile, job taken by other node is not written anymore
+	if owned, refreshErr := job.refresh(); refreshErr != nil {
+		_ = env.ErrorDispatch(refreshErr)
+	} else if !owned {
+		return
+	}
+
+	job.mutex.Lock()
+	job.finishedAt = time.Now()
+	job.result = result
+	switch {
+	case job.canceled:
+		job.status = ConstStatusCanceled
+	case err != nil:
+		job.status = ConstStatusFailed
+		job.err = env.ErrorDispatch(err).Error()
+	default:
+		job.status = ConstStatusCompleted
+		if job.total > 0 {
+			job.progress = job.total
+		}
+	}
+	job.mutex.Unlock()
+
+	if err := job.write(); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	env.Event("job."+job.GetStatus(), map[string]interface{}{"job": job, "type": job.jobType})
+}
+
+// runHandler calls job handler converting its panic to error, so worker is not lost
+func runHandler(job InterfaceJob, handler FuncJobHandler) (result interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = env.ErrorNew(ConstErrorModule, ConstErrorLevel, "7c1e9a5d-b348-4f26-9e0b-a5d2f8c6e371", "job handler panic: "+utils.InterfaceToString(recovered))
+		}
+	}()
+
+	return handler(job)
+}
+
+// restoreJobs puts to queue not finished jobs of nodes which did not renew lease (stopped or crashed application)
+//   - several nodes could restore job simultaneously, job is taken by node which wrote it last
+func restoreJobs() error {
+	collection, err := db.GetCollection(ConstCollectionNameJob)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("status", "in", []string{ConstStatusQueued, ConstStatusRunning}); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddSort("created_at", false); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	for _, record := range records {
+		job := newJobFromRecord(record)
+		if job.leaseUntil.After(time.Now()) || isProcessed(job.id) {
+			continue
+		}
+
+		job.node = nodeName
+		if job.canceled {
+			job.status = ConstStatusCanceled
+			job.finishedAt = time.Now()
+		} else {
+			job.status = ConstStatusQueued
+			job.message = "restored after application restart"
+		}
+
+		if err := job.write(); err != nil {
+			_ = env.ErrorDispatch(err)
+			continue
+		}
+
+		if owned, err := job.refresh(); err != nil || !owned || job.canceled {
+			continue
+		}
+
+		enqueue(job)
+	}
+
+	return nil
+}
+
+// isProcessed checks if job is queued or running by current node
+func isProcessed(jobID string) bool {
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	if _, present := running[jobID]; present {
+		return true
+	}
+	for _, job := range queue {
+		if job.id == jobID {
+			return true
+		}
+	}
+	return false
+}
+
+// dequeue removes job from queue, it is not processed by current node anymore
+func dequeue(jobID string) {
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	for index, job := range queue {
+		if job.id == jobID {
+			queue = append(queue[:index], queue[index+1:]...)
+			break
+		}
+	}
+}
+
+// leaseKeeper is a go routine renewing leases of jobs processed by current node and restoring jobs of nodes which
+// were stopped
+func leaseKeeper() {
+	for range time.Tick(ConstLeaseRenewInterval * time.Second) {
+		if api.IsStopping() {
+			continue
+		}
+
+		renewLeases()
+
+		if err := restoreJobs(); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+}
+
+// renewLeases extends leases of queued and running jobs of current node, jobs canceled or taken by other node are
+// removed from queue, running ones get cancel flag so their handlers return
+func renewLeases() {
+	queueMutex.Lock()
+	jobs := make([]*DefaultJob, 0, len(queue)+len(running))
+	jobs = append(jobs, queue...)
+	for _, job := range running {
+		jobs = append(jobs, job)
+	}
+	queueMutex.Unlock()
+
+	for _, job := range jobs {
+		owned, err := job.refresh()
+		if err != nil {
+			_ = env.ErrorDispatch(err)
+			continue
+		}
+
+		if !owned || job.GetStatus() == ConstStatusCanceled {
+			job.mutex.Lock()
+			job.canceled = true
+			job.mutex.Unlock()
+
+			dequeue(job.id)
+			if !owned {
+				continue
+			}
+		}
+
+		if err := job.write(); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+}
+
+// cleanupTask is a scheduler task removing finished jobs and their artifacts after retention period
+func cleanupTask(params map[string]interface{}) error {
+	retention := ConstDefaultRetention
+	if value := env.ConfigGetValue(ConstConfigPathJobsRetention); value != nil {
+		retention = utils.InterfaceToInt(value)
+	}
+
+	collection, err := db.GetCollection(ConstCollectionNameJob)
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	if err := collection.AddFilter("status", "in", []string{ConstStatusCompleted, ConstStatusFailed, ConstStatusCanceled, ConstStatusNew}); err != nil {
+		return env.ErrorDispatch(err)
+	}
+	if err := collection.AddFilter("created_at", "<", time.Now().Add(-time.Duration(retention)*24*time.Hour)); err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	records, err := collection.Load()
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	for _, record := range records {
+		if err := deleteJob(newJobFromRecord(record)); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNextJob tests queue takes jobs in submit order respecting workers and job type concurrency limits
+func TestNextJob(t *testing.T) {
+	queueMutex.Lock()
+	defer queueMutex.Unlock()
+
+	handlers["test.single"] = &structHandler{concurrency: 1}
+	handlers["test.any"] = &structHandler{}
+	defer func() {
+		delete(handlers, "test.single")
+		delete(handlers, "test.any")
+		queue = nil
+		running = make(map[string]*DefaultJob)
+		runningTypes = make(map[string]int)
+		workersLimit = ConstDefaultWorkers
+	}()
+
+	workersLimit = 2
+	queue = []*DefaultJob{
+		{id: "1", jobType: "test.single"},
+		{id: "2", jobType: "test.single"},
+		{id: "3", jobType: "test.any"},
+		{id: "4", jobType: "test.any"},
+	}
+
+	if job := nextJob(); job == nil || job.id != "1" {
+		t.Fatal("job 1 expected:", job)
+	}
+	if job := nextJob(); job == nil || job.id != "3" {
+		t.Fatal("job 3 expected as job 2 type is at concurrency limit:", job)
+	}
+	if job := nextJob(); job != nil {
+		t.Fatal("no job expected as workers limit reached:", job.id)
+	}
+
+	delete(running, "1")
+	runningTypes["test.single"]--
+
+	if job := nextJob(); job == nil || job.id != "2" {
+		t.Fatal("job 2 expected:", job)
+	}
+	if len(queue) != 1 || queue[0].id != "4" {
+		t.Error("job 4 should stay queued:", queue)
+	}
+}
+
+// TestJobRecordNode tests job record keeps node job belongs to, so job saved by other node is not reassigned
+func TestJobRecordNode(t *testing.T) {
+	leaseUntil := time.Now().Add(ConstLeaseTime * time.Second).Truncate(time.Second)
+	job := &DefaultJob{id: "1", jobType: "test", node: "other", status: ConstStatusRunning, leaseUntil: leaseUntil}
+
+	record := job.toRecord()
+	if record["node"] != "other" {
+		t.Error("job node should be kept:", record["node"])
+	}
+
+	restored := newJobFromRecord(record)
+	if restored.node != "other" || !restored.leaseUntil.Equal(leaseUntil) {
+		t.Error("unexpected restored job node and lease:", restored.node, restored.leaseUntil)
+	}
+}
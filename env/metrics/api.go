@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupAPI setups package related API endpoint routines
+func setupAPI() error {
+
+	service := api.GetRestService()
+
+	service.GET("metrics", restMetrics)
+	api.RegisterSessionlessRoute("metrics")
+
+	return nil
+}
+
+// checkAccess validates request is allowed to read metrics according to access mode set in config
+func checkAccess(context api.InterfaceApplicationContext) error {
+	switch utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathMetricsAccess)) {
+	case ConstAccessPublic:
+		return nil
+
+	case ConstAccessToken:
+		token := utils.InterfaceToString(env.ConfigGetValue(ConstConfigPathMetricsToken))
+		header := utils.InterfaceToString(context.GetRequestSetting("Authorization"))
+
+		if token != "" && strings.HasPrefix(header, "Bearer ") {
+			if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, "Bearer ")), []byte(token)) == 1 {
+				return nil
+			}
+		}
+	}
+
+	return api.ValidateAdminRights(context)
+}
+
+// WEB REST API used to get all registered metrics in Prometheus text exposition format
+func restMetrics(context api.InterfaceApplicationContext) (interface{}, error) {
+
+	if err := checkAccess(context); err != nil {
+		context.SetResponseStatusForbidden()
+		return nil, env.ErrorDispatch(err)
+	}
+
+	if err := context.SetResponseContentType(ConstContentType); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var result bytes.Buffer
+	if err := WriteText(&result); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return result.Bytes(), nil
+}
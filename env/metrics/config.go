@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/utils"
+)
+
+// setupConfig setups package configuration values for a system
+func setupConfig() error {
+	config := env.GetConfig()
+	if config == nil {
+		err := env.ErrorNew(ConstErrorModule, env.ConstErrorLevelStartStop, "4c8e1a6f-0d3b-4b95-9e27-a5f3c0d8b146", "can't obtain config")
+		return env.ErrorDispatch(err)
+	}
+
+	err := config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathMetrics,
+		Value:       nil,
+		Type:        env.ConstConfigTypeGroup,
+		Editor:      "",
+		Options:     nil,
+		Label:       "Metrics",
+		Description: "metrics endpoint settings",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	accessValidator := func(newValue interface{}) (interface{}, error) {
+		value := utils.InterfaceToString(newValue)
+		if !utils.IsInListStr(value, []string{ConstAccessAdmin, ConstAccessToken, ConstAccessPublic}) {
+			return nil, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "d2a7f0c4-6e81-4b3d-9c5a-8f1e3b7d0a29", "unknown metrics access mode '"+value+"'")
+		}
+		return value, nil
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathMetricsAccess,
+		Value:       ConstAccessAdmin,
+		Type:        env.ConstConfigTypeVarchar,
+		Editor:      "select",
+		Options:     map[string]string{ConstAccessAdmin: "Admin session", ConstAccessToken: "Bearer token", ConstAccessPublic: "Public"},
+		Label:       "Access",
+		Description: "who could read metrics: admins only, scrapers having token or anyone",
+		Image:       "",
+	}, accessValidator)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	err = config.RegisterItem(env.StructConfigItem{
+		Path:        ConstConfigPathMetricsToken,
+		Value:       "",
+		Type:        env.ConstConfigTypeSecret,
+		Editor:      "password",
+		Options:     nil,
+		Label:       "Token",
+		Description: "token scraper should send within 'Authorization: Bearer' header for token access mode",
+		Image:       "",
+	}, nil)
+
+	if err != nil {
+		return env.ErrorDispatch(err)
+	}
+
+	return nil
+}
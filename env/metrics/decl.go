@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// Package global constants
+const (
+	ConstMetricTypeCounter   = "counter"
+	ConstMetricTypeGauge     = "gauge"
+	ConstMetricTypeHistogram = "histogram"
+
+	ConstConfigPathMetrics       = "general.metrics"
+	ConstConfigPathMetricsAccess = "general.metrics.access"
+	ConstConfigPathMetricsToken  = "general.metrics.token"
+
+	ConstAccessAdmin  = "admin"  // metrics are available within admin session only
+	ConstAccessToken  = "token"  // metrics are available by bearer token, or within admin session
+	ConstAccessPublic = "public" // metrics are available to anyone
+
+	ConstContentType = "text/plain; version=0.0.4; charset=utf-8" // Prometheus text exposition format
+
+	ConstErrorModule = "env/metrics"
+	ConstErrorLevel  = env.ConstErrorLevelService
+)
+
+// Package global variables
+var (
+	// DefaultBuckets are histogram buckets suitable to measure durations in seconds
+	DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+	registry      = make(map[string]*metric)
+	registryMutex sync.RWMutex
+)
+
+// metric holds values of a named metric for each combination of label values
+type metric struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+
+	buckets []float64
+	getter  func() float64
+
+	series map[string]*series
+	mutex  sync.Mutex
+}
+
+// series holds value of a metric for particular label values
+type series struct {
+	labelValues []string
+
+	value float64
+
+	bucketCounts []uint64
+	count        uint64
+}
+
+// Counter is a metric which value only goes up, like amount of served requests
+type Counter struct{ *metric }
+
+// Gauge is a metric which value could go up and down, like amount of active sessions
+type Gauge struct{ *metric }
+
+// Histogram is a metric which counts observed values within buckets, like request durations
+type Histogram struct{ *metric }
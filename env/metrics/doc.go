@@ -0,0 +1,23 @@
+// Copyright 2014 The Ottemo Authors. All rights reserved.
+
+/*
+Package metrics is a registry of application metrics exposed in Prometheus text format at GET metrics endpoint.
+
+Any package could register counters, gauges and histograms, usually as package variables. Metric could have labels,
+label values are given on each update in the same order as label names were given on registration. Registering metric
+with already used name returns the existing one, so packages could share metrics.
+
+	var metricRequests = metrics.NewCounter("ottemo_requests_total", "Amount of served requests.", "route", "status")
+	var metricDuration = metrics.NewHistogram("ottemo_request_duration_seconds", "Requests duration.", nil, "route")
+
+	metricRequests.Inc("product/:productID", "200")
+	defer metricDuration.ObserveDuration(time.Now(), "product/:productID")
+
+Label values should come from a small set, like route patterns rather than request URLs, as each combination of values
+is kept in memory.
+
+Access to metrics endpoint is controlled by "general.metrics.access" config value: "admin" allows admin sessions only,
+"token" additionally allows requests with "Authorization: Bearer <token>" header matching "general.metrics.token" config
+value, "public" allows anyone.
+*/
+package metrics
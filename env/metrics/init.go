@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+)
+
+// init makes package self-initialization routine
+func init() {
+	env.RegisterOnConfigStart(setupConfig)
+	api.RegisterOnRestServiceStart(setupAPI)
+
+	setupProcessMetrics()
+}
+
+// setupProcessMetrics registers metrics describing application process itself
+func setupProcessMetrics() {
+	startTime := time.Now()
+
+	if err := NewGaugeFunc("ottemo_uptime_seconds", "Seconds passed since application start.", func() float64 {
+		return time.Since(startTime).Seconds()
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := NewGaugeFunc("ottemo_goroutines", "Amount of currently existing goroutines.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
+	if err := NewGaugeFunc("ottemo_memory_alloc_bytes", "Bytes of allocated heap objects.", func() float64 {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		return float64(memStats.Alloc)
+	}); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// getSeries returns series for given label values, creating it if needed
+//   - metric mutex should be locked by caller
+//   - returns nil if amount of values does not match metric labels
+func (it *metric) getSeries(labelValues []string) *series {
+	if len(labelValues) != len(it.labels) {
+		return nil
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	if result, present := it.series[key]; present {
+		return result
+	}
+
+	result := &series{labelValues: append([]string{}, labelValues...)}
+	if it.kind == ConstMetricTypeHistogram {
+		result.bucketCounts = make([]uint64, len(it.buckets))
+	}
+	it.series[key] = result
+
+	return result
+}
+
+// add adds value to series of given label values
+func (it *metric) add(value float64, labelValues []string) {
+	it.mutex.Lock()
+	if series := it.getSeries(labelValues); series != nil {
+		series.value += value
+	}
+	it.mutex.Unlock()
+}
+
+// sortedSeries returns copy of metric series ordered by label values
+func (it *metric) sortedSeries() []series {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	result := make([]series, 0, len(it.series))
+	for _, value := range it.series {
+		copied := *value
+		copied.bucketCounts = append([]uint64{}, value.bucketCounts...)
+		result = append(result, copied)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.Join(result[i].labelValues, "\xff") < strings.Join(result[j].labelValues, "\xff")
+	})
+
+	return result
+}
+
+// Inc increments counter by 1
+func (it *Counter) Inc(labelValues ...string) {
+	it.add(1, labelValues)
+}
+
+// Add increments counter by given value, negative values are ignored
+func (it *Counter) Add(value float64, labelValues ...string) {
+	if value > 0 {
+		it.add(value, labelValues)
+	}
+}
+
+// Set sets gauge to given value
+func (it *Gauge) Set(value float64, labelValues ...string) {
+	it.mutex.Lock()
+	if series := it.getSeries(labelValues); series != nil {
+		series.value = value
+	}
+	it.mutex.Unlock()
+}
+
+// Inc increments gauge by 1
+func (it *Gauge) Inc(labelValues ...string) {
+	it.add(1, labelValues)
+}
+
+// Dec decrements gauge by 1
+func (it *Gauge) Dec(labelValues ...string) {
+	it.add(-1, labelValues)
+}
+
+// Add adds given value to gauge, value could be negative
+func (it *Gauge) Add(value float64, labelValues ...string) {
+	it.add(value, labelValues)
+}
+
+// Observe adds value to histogram
+func (it *Histogram) Observe(value float64, labelValues ...string) {
+	it.mutex.Lock()
+	if series := it.getSeries(labelValues); series != nil {
+		series.value += value
+		series.count++
+		for index, bound := range it.buckets {
+			if value <= bound {
+				series.bucketCounts[index]++
+			}
+		}
+	}
+	it.mutex.Unlock()
+}
+
+// ObserveDuration adds amount of seconds passed since given time to histogram
+//   - convenient to use with defer: defer histogram.ObserveDuration(time.Now(), "label")
+func (it *Histogram) ObserveDuration(startTime time.Time, labelValues ...string) {
+	it.Observe(time.Since(startTime).Seconds(), labelValues...)
+}
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ottemo/foundation/env"
+)
+
+// metricNameRegexp is a regular expression metric and label names should match
+var metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// register adds metric to registry, or returns already registered one with the same name
+//   - metric which can't be registered is returned detached along with error, so callers still could use it
+//   - metric with getter can't be shared, so it is registered only once
+func register(name string, help string, kind string, labels []string, buckets []float64, getter func() float64) (*metric, error) {
+	newMetric := &metric{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		getter:  getter,
+		series:  make(map[string]*series),
+	}
+
+	for _, label := range append([]string{name}, labels...) {
+		if !metricNameRegexp.MatchString(label) {
+			return newMetric, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "1f6b2d8e-7c4a-4e93-a0b5-3d9e8c1f7a26", "'"+label+"' is not valid metric or label name")
+		}
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if registered, present := registry[name]; present {
+		if registered.kind != kind || strings.Join(registered.labels, ",") != strings.Join(labels, ",") {
+			return newMetric, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "9a3e7f15-2b6c-4d80-8e4f-6c1a0b9d2e73", "metric '"+name+"' is already registered with other type or labels")
+		}
+		if registered.getter != nil || getter != nil {
+			return newMetric, env.ErrorNew(ConstErrorModule, ConstErrorLevel, "5d2c8e41-a7b3-4f96-8c0e-1b9f6d3a7e52", "metric '"+name+"' is already registered")
+		}
+		return registered, nil
+	}
+
+	registry[name] = newMetric
+
+	return newMetric, nil
+}
+
+// NewCounter registers counter metric with given label names
+func NewCounter(name string, help string, labels ...string) *Counter {
+	result, _ := register(name, help, ConstMetricTypeCounter, labels, nil, nil)
+	return &Counter{result}
+}
+
+// NewGauge registers gauge metric with given label names
+func NewGauge(name string, help string, labels ...string) *Gauge {
+	result, _ := register(name, help, ConstMetricTypeGauge, labels, nil, nil)
+	return &Gauge{result}
+}
+
+// NewGaugeFunc registers gauge metric which value is taken from given function on each exposition
+//   - returns error if metric with given name is already registered
+func NewGaugeFunc(name string, help string, getter func() float64) error {
+	_, err := register(name, help, ConstMetricTypeGauge, nil, nil, getter)
+	return err
+}
+
+// NewHistogram registers histogram metric with given buckets upper bounds and label names
+//   - nil buckets means DefaultBuckets
+func NewHistogram(name string, help string, buckets []float64, labels ...string) *Histogram {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	result, _ := register(name, help, ConstMetricTypeHistogram, labels, buckets, nil)
+	return &Histogram{result}
+}
+
+// formatValue returns float value as it should be within exposition
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// formatLabels returns label pairs block, extra pair is added at the end if given
+func formatLabels(names []string, values []string, extra ...string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	var pairs []string
+	for index, name := range names {
+		pairs = append(pairs, name+`="`+replacer.Replace(values[index])+`"`)
+	}
+	if len(extra) == 2 {
+		pairs = append(pairs, extra[0]+`="`+extra[1]+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// WriteText writes all registered metrics to given writer in Prometheus text exposition format
+func WriteText(writer io.Writer) error {
+	registryMutex.RLock()
+	var metrics []*metric
+	for _, value := range registry {
+		metrics = append(metrics, value)
+	}
+	registryMutex.RUnlock()
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].name < metrics[j].name })
+
+	helpReplacer := strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+	buffer := bufio.NewWriter(writer)
+	for _, metric := range metrics {
+		buffer.WriteString("# HELP " + metric.name + " " + helpReplacer.Replace(metric.help) + "\n")
+		buffer.WriteString("# TYPE " + metric.name + " " + metric.kind + "\n")
+
+		if metric.getter != nil {
+			buffer.WriteString(metric.name + " " + formatValue(metric.getter()) + "\n")
+			continue
+		}
+
+		for _, series := range metric.sortedSeries() {
+			if metric.kind != ConstMetricTypeHistogram {
+				buffer.WriteString(metric.name + formatLabels(metric.labels, series.labelValues) + " " + formatValue(series.value) + "\n")
+				continue
+			}
+
+			for index, bound := range metric.buckets {
+				labels := formatLabels(metric.labels, series.labelValues, "le", formatValue(bound))
+				buffer.WriteString(metric.name + "_bucket" + labels + " " + strconv.FormatUint(series.bucketCounts[index], 10) + "\n")
+			}
+
+			labels := formatLabels(metric.labels, series.labelValues, "le", "+Inf")
+			buffer.WriteString(metric.name + "_bucket" + labels + " " + strconv.FormatUint(series.count, 10) + "\n")
+
+			labels = formatLabels(metric.labels, series.labelValues)
+			buffer.WriteString(metric.name + "_sum" + labels + " " + formatValue(series.value) + "\n")
+			buffer.WriteString(metric.name + "_count" + labels + " " + strconv.FormatUint(series.count, 10) + "\n")
+		}
+	}
+
+	return buffer.Flush()
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewGaugeFuncDuplicate(t *testing.T) {
+	if err := NewGaugeFunc("test_gauge_func", "Test gauge.", func() float64 { return 1 }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewGaugeFunc("test_gauge_func", "Test gauge.", func() float64 { return 2 }); err == nil {
+		t.Error("duplicate gauge function registration was accepted")
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteText(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buffer.String(), "\ntest_gauge_func 1\n") {
+		t.Error("getter of first registration was replaced")
+	}
+}
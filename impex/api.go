@@ -10,6 +10,7 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/jobs"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -21,8 +22,9 @@ func setupAPI() error {
 	service.GET("impex/models", restImpexListModels)
 	service.GET("impex/import/status", restImpexImportStatus)
 	service.GET("impex/export/:model", restImpexExportModel)
-	service.POST("impex/import/:model", ImportStartHandler(api.AsyncHandler(restImpexImportModel, ImportResultHandler)))
-	service.POST("impex/import", ImportStartHandler(api.AsyncHandler(restImpexImport, ImportResultHandler)))
+	service.POST("impex/export/:model", api.IsAdminHandler(restImpexExportModelJob))
+	service.POST("impex/import/:model", api.IsAdminHandler(restImpexImportModel))
+	service.POST("impex/import", api.IsAdminHandler(restImpexImport))
 
 	service.POST("impex/test/import", restImpexTestImport)
 	service.POST("impex/test/mapping", restImpexTestCsvToMap)
@@ -60,50 +62,9 @@ func restImpexExportModel(context api.InterfaceApplicationContext) (interface{},
 
 	modelName := context.GetRequestArgument("model")
 
-	var records []map[string]interface{}
-
-	if model, present := impexModels[modelName]; present {
-		exportIterator := func(item map[string]interface{}) bool {
-			records = append(records, item)
-			return true
-		}
-		err := model.Export(exportIterator)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-
-		model, err := models.GetModel(modelName)
-		if err != nil {
-			return nil, env.ErrorDispatch(err)
-		}
-
-		listable, isListable := model.(models.InterfaceListable)
-		object, isObject := model.(models.InterfaceObject)
-
-		if isListable && isObject {
-			collection := listable.GetCollection()
-			if collection == nil {
-				return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "12f978f5-3a90-438b-a3f4-475b34a97884", "can't obtain model collection")
-			}
-
-			var attributes []string
-			for _, attribute := range object.GetAttributesInfo() {
-				attributes = append(attributes, attribute.Attribute)
-				if err := collection.ListAddExtraAttribute(attribute.Attribute); err != nil {
-					return nil, env.ErrorDispatch(err)
-				}
-			}
-
-			list, err := collection.List()
-			if err != nil {
-				return nil, err
-			}
-
-			for _, item := range list {
-				records = append(records, item.Extra)
-			}
-		}
+	records, err := ExportModel(modelName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
 	}
 
 	// preparing csv writer
@@ -119,7 +80,7 @@ func restImpexExportModel(context api.InterfaceApplicationContext) (interface{},
 		_ = env.ErrorDispatch(err)
 	}
 
-	err := MapToCSV(records, csvWriter)
+	err = MapToCSV(records, csvWriter)
 	if err != nil {
 		return nil, env.ErrorDispatch(err)
 	}
@@ -157,48 +118,9 @@ func restImpexImportStatus(context api.InterfaceApplicationContext) (interface{}
 }
 
 // WEB REST API used import data to system
+//   - import is made by background job, job info returned
 func restImpexImportModel(context api.InterfaceApplicationContext) (interface{}, error) {
-
-	var commandLine string
-	exchangeDict := make(map[string]interface{})
-
-	modelName := context.GetRequestArgument("model")
-
-	if _, present := impexModels[modelName]; present {
-		commandLine = "IMPORT " + modelName
-	} else {
-		commandLine = "UPDATE " + modelName
-	}
-
-	filesProcessed := 0
-	additionalMessage := ""
-
-	for fileName, attachedFile := range context.GetRequestFiles() {
-
-		importStatus.file = &StructImportingFile{reader: attachedFile, name: fileName}
-
-		if seeker, ok := attachedFile.(io.Seeker); ok {
-			if fileSize, err := seeker.Seek(0, 2); err == nil {
-				importStatus.file.size = fileSize
-				_, _ = seeker.Seek(0, 0)
-			}
-		}
-
-		csvReader := csv.NewReader(attachedFile)
-		csvReader.Comma = ','
-
-		err := ImportCSVData(commandLine, exchangeDict, csvReader, nil, false)
-		if err != nil && additionalMessage == "" {
-			_ = env.ErrorDispatch(err)
-			additionalMessage += "with errors"
-		}
-
-		filesProcessed++
-	}
-
-	importStatus.file = nil
-
-	return fmt.Sprintf("%d file(s) processed %s", filesProcessed, additionalMessage), nil
+	return submitImportJob(context, context.GetRequestArgument("model"))
 }
 
 // WEB REST API used to test csv file before import
@@ -238,36 +160,19 @@ func restImpexTestImport(context api.InterfaceApplicationContext) (interface{},
 }
 
 // WEB REST API used to process csv file script in impex format
+//   - import is made by background job, job info returned
 func restImpexImport(context api.InterfaceApplicationContext) (interface{}, error) {
+	return submitImportJob(context, "")
+}
 
-	filesProcessed := 0
-	additionalMessage := ""
-	for fileName, attachedFile := range context.GetRequestFiles() {
-
-		importStatus.file = &StructImportingFile{reader: attachedFile, name: fileName}
-
-		if seeker, ok := attachedFile.(io.Seeker); ok {
-			if fileSize, err := seeker.Seek(0, 2); err == nil {
-				importStatus.file.size = fileSize
-				_, _ = seeker.Seek(0, 0)
-			}
-		}
-
-		csvReader := csv.NewReader(attachedFile)
-		csvReader.Comma = ','
-
-		err := ImportCSVScript(csvReader, nil, false)
-		if err != nil && additionalMessage == "" {
-			_ = env.ErrorDispatch(err)
-			additionalMessage += "with errors"
-		}
-
-		filesProcessed++
+// WEB REST API used to export specific model data from system by background job, csv file is a job artifact
+func restImpexExportModelJob(context api.InterfaceApplicationContext) (interface{}, error) {
+	job, err := jobs.Run(ConstJobTypeExport, map[string]interface{}{"model": context.GetRequestArgument("model")})
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
 	}
 
-	importStatus.file = nil
-
-	return fmt.Sprintf("%d file(s) processed %s", filesProcessed, additionalMessage), nil
+	return job.ToHashMap(), nil
 }
 
 // WEB REST API to test conversion from csv to json / map[string]interface{}
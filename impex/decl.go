@@ -15,6 +15,9 @@ const (
 
 	ConstLogFileName = "impex.log"
 
+	ConstJobTypeImport = "impex.import"
+	ConstJobTypeExport = "impex.export"
+
 	constImportStateIdle       = "idle"
 	constImportStateProcessing = "processing"
 )
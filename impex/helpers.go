@@ -1,7 +1,11 @@
 package impex
 
 import (
+	"encoding/csv"
+	"io"
+
 	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/app/models"
 	"github.com/ottemo/foundation/env"
 )
 
@@ -33,3 +37,65 @@ var ImportResultHandler = func(context api.InterfaceApplicationContext, result i
 	}
 }
 
+
+// ExportModel returns records of given model to be exported, impex model export routine is used if model registered
+// within impex, model collection attributes are used otherwise
+func ExportModel(modelName string) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+
+	if model, present := impexModels[modelName]; present {
+		exportIterator := func(item map[string]interface{}) bool {
+			records = append(records, item)
+			return true
+		}
+		err := model.Export(exportIterator)
+		if err != nil {
+			return nil, err
+		}
+
+		return records, nil
+	}
+
+	model, err := models.GetModel(modelName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	listable, isListable := model.(models.InterfaceListable)
+	object, isObject := model.(models.InterfaceObject)
+
+	if isListable && isObject {
+		collection := listable.GetCollection()
+		if collection == nil {
+			return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "12f978f5-3a90-438b-a3f4-475b34a97884", "can't obtain model collection")
+		}
+
+		for _, attribute := range object.GetAttributesInfo() {
+			if err := collection.ListAddExtraAttribute(attribute.Attribute); err != nil {
+				return nil, env.ErrorDispatch(err)
+			}
+		}
+
+		list, err := collection.List()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list {
+			records = append(records, item.Extra)
+		}
+	}
+
+	return records, nil
+}
+
+// ImportModel imports csv data to given model, "IMPORT" command is used for models registered within impex, "UPDATE"
+// command otherwise
+func ImportModel(modelName string, csvReader *csv.Reader, output io.Writer, testMode bool) error {
+	commandLine := "UPDATE " + modelName
+	if _, present := impexModels[modelName]; present {
+		commandLine = "IMPORT " + modelName
+	}
+
+	return ImportCSVData(commandLine, make(map[string]interface{}), csvReader, output, testMode)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/jobs"
 	"github.com/ottemo/foundation/utils"
 
 	"bytes"
@@ -18,6 +19,14 @@ func init() {
 
 	api.RegisterOnRestServiceStart(setupAPI)
 
+	// imports are made one by one as import commands share state
+	if err := jobs.RegisterHandler(ConstJobTypeImport, 1, importJob); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+	if err := jobs.RegisterHandler(ConstJobTypeExport, 0, exportJob); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
 	if err := RegisterImportCommand("IMPORT", new(ImportCmdImport)); err != nil {
 		_ = env.ErrorDispatch(err)
 	}
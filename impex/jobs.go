@@ -0,0 +1,121 @@
+package impex
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/ottemo/foundation/api"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/jobs"
+	"github.com/ottemo/foundation/utils"
+)
+
+// submitImportJob saves request attached files as job artifacts and puts import job to queue
+//   - empty model name means files are csv scripts in impex format
+func submitImportJob(context api.InterfaceApplicationContext, modelName string) (interface{}, error) {
+	job, err := jobs.NewJob(ConstJobTypeImport, map[string]interface{}{"model": modelName})
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	var files []string
+	for fileName, attachedFile := range context.GetRequestFiles() {
+		data, err := ioutil.ReadAll(attachedFile)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+
+		if err := job.SaveArtifact(fileName, data); err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+		files = append(files, fileName)
+	}
+
+	if len(files) == 0 {
+		context.SetResponseStatusBadRequest()
+		return nil, env.ErrorNew(ConstErrorModule, env.ConstErrorLevelAPI, "d53a8e1f-6b27-4c94-a0d8-2f7e9c4b1a65", "no files to import")
+	}
+
+	job.GetParams()["files"] = files
+	if err := jobs.Submit(job); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return job.ToHashMap(), nil
+}
+
+// importJob is a "impex.import" job handler, imports files saved as job artifacts
+func importJob(job jobs.InterfaceJob) (interface{}, error) {
+	params := job.GetParams()
+	modelName := utils.InterfaceToString(params["model"])
+	files := utils.InterfaceToStringArray(params["files"])
+
+	importStatus.state = constImportStateProcessing
+	defer func() {
+		importStatus.file = nil
+		importStatus.state = constImportStateIdle
+	}()
+
+	filesProcessed := 0
+	additionalMessage := ""
+	for _, fileName := range files {
+		if job.IsCanceled() {
+			break
+		}
+
+		data, err := job.LoadArtifact(fileName)
+		if err != nil {
+			return nil, env.ErrorDispatch(err)
+		}
+
+		reader := bytes.NewReader(data)
+		importStatus.file = &StructImportingFile{reader: reader, name: fileName, size: int64(len(data))}
+		job.SetMessage("importing " + fileName)
+
+		csvReader := csv.NewReader(reader)
+		csvReader.Comma = ','
+
+		if modelName != "" {
+			err = ImportModel(modelName, csvReader, nil, false)
+		} else {
+			err = ImportCSVScript(csvReader, nil, false)
+		}
+		if err != nil && additionalMessage == "" {
+			_ = env.ErrorDispatch(err)
+			additionalMessage += "with errors"
+		}
+
+		filesProcessed++
+		job.SetProgress(filesProcessed, len(files))
+	}
+
+	return fmt.Sprintf("%d file(s) processed %s", filesProcessed, additionalMessage), nil
+}
+
+// exportJob is a "impex.export" job handler, model data saved as csv job artifact
+func exportJob(job jobs.InterfaceJob) (interface{}, error) {
+	modelName := utils.InterfaceToString(job.GetParams()["model"])
+
+	records, err := ExportModel(modelName)
+	if err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	buffer := new(bytes.Buffer)
+	csvWriter := csv.NewWriter(buffer)
+	if err := MapToCSV(records, csvWriter); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+	csvWriter.Flush()
+
+	fileName := strings.ToLower(modelName) + "_export_" + time.Now().Format("2006_01_02") + ".csv"
+	if err := job.SaveArtifact(fileName, buffer.Bytes()); err != nil {
+		return nil, env.ErrorDispatch(err)
+	}
+
+	return fmt.Sprintf("%d record(s) exported to %s", len(records), fileName), nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ottemo/foundation/app"
+	"github.com/ottemo/foundation/cli"
 
 	// using standard set of packages
 	_ "github.com/ottemo/foundation/basebuild"
@@ -21,26 +22,14 @@ func init() {
 
 // executable file start point
 func main() {
-	defer func() {
-		if err := app.End(); err != nil { // application close event
-			fmt.Println(err.Error())
-		}
-	}()
+	// command line mode, command runs within application lifecycle without HTTP listener
+	if args := cli.GetCommandArgs(); len(args) > 0 {
+		os.Exit(cli.Run(args))
+	}
 
 	// we should intercept os signals to application as we should call app.End() before
 	signalChain := make(chan os.Signal, 1)
 	signal.Notify(signalChain, os.Interrupt, syscall.SIGTERM)
-	go func() {
-		for _ = range signalChain {
-			err := app.End()
-			if err != nil {
-				_ = env.ErrorDispatch(err)
-				fmt.Println(err.Error())
-			}
-
-			os.Exit(0)
-		}
-	}()
 
 	// application start event
 	if err := app.Start(); err != nil {
@@ -52,7 +41,30 @@ func main() {
 	fmt.Println("Ottemo " + app.GetVerboseVersion())
 
 	// starting HTTP server
-	if err := app.Serve(); err != nil {
+	serveResult := make(chan error, 1)
+	go func() {
+		serveResult <- app.Serve()
+	}()
+
+	select {
+	case <-signalChain:
+		fmt.Println("Stopping, waiting for in-flight requests and background jobs")
+
+		// repeated signal means we should not wait anymore
+		go func() {
+			<-signalChain
+			os.Exit(1)
+		}()
+
+	case err := <-serveResult:
+		if err != nil {
+			fmt.Println(err.Error())
+		}
+	}
+
+	// application end event, it drains in-flight requests and background jobs up to configured timeout
+	if err := app.End(); err != nil {
+		_ = env.ErrorDispatch(err)
 		fmt.Println(err.Error())
 	}
 }
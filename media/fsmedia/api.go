@@ -2,7 +2,8 @@ package fsmedia
 
 import (
 	"github.com/ottemo/foundation/api"
-	"github.com/ottemo/foundation/media"
+	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/jobs"
 	"github.com/ottemo/foundation/utils"
 )
 
@@ -15,6 +16,7 @@ func setupAPI() error {
 }
 
 // APIGetMediaInfo will resize all images if the params of the request contain 'resizeAll' with a value of true
+//   - resize is made by background job, job info returned
 func APIGetMediaInfo(context api.InterfaceApplicationContext) (interface{}, error) {
 	// TODO: add example api call or add this to Apiary - jwv
 
@@ -22,15 +24,21 @@ func APIGetMediaInfo(context api.InterfaceApplicationContext) (interface{}, erro
 	resizeAll := utils.GetFirstMapValue(requestParams, "resizeAll", "resizeImages", "resizeAllImages")
 
 	if resizeAll != nil && utils.InterfaceToBool(resizeAll) {
-		mediaStorage, err := media.GetMediaStorage()
+		job, err := jobs.RunOnce(ConstJobTypeResize, nil)
 		if err != nil {
-			return nil, err
+			return nil, env.ErrorDispatch(err)
 		}
 
-		err = mediaStorage.ResizeAllMediaImages()
-		if err != nil {
-			return nil, err
-		}
+		return job.ToHashMap(), nil
+	}
+
+	return "ok", nil
+}
+
+// resizeJob is a "media.resize" job handler
+func (it *FilesystemMediaStorage) resizeJob(job jobs.InterfaceJob) (interface{}, error) {
+	if err := it.ResizeAllMediaImages(); err != nil {
+		return nil, env.ErrorDispatch(err)
 	}
 
 	return "ok", nil
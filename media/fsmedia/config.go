@@ -2,6 +2,7 @@ package fsmedia
 
 import (
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/jobs"
 )
 
 // setupConfig setups package configuration values for a system
@@ -22,11 +23,10 @@ func (it *FilesystemMediaStorage) setupConfig() error {
 				return ConstDefaultImageSizes, env.ErrorDispatch(err)
 			}
 
-			// resize all images instantly on change size if we not in "on fly" mode
+			// resize all images by background job on change size if we not in "on fly" mode
 			// and prevent from resizing while initial calls for validator
 			if !resizeImagesOnFly && it.setupWaitCnt == 0 {
-				err = it.ResizeAllMediaImages()
-				if err != nil {
+				if _, err := jobs.RunOnce(ConstJobTypeResize, nil); err != nil {
 					_ = env.ErrorDispatch(err)
 				}
 			}
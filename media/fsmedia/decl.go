@@ -10,6 +10,7 @@ const (
 	ConstMediaDefaultFolder = "./media/" // filesystem folder path to store media files in there
 
 	ConstResizeOnBackground = true
+	ConstJobTypeResize      = "media.resize" // background job resizing all images
 
 	ConstDefaultImageSize  = "1000x1000"      // "800x400"
 	ConstDefaultImageSizes = "thumb: 280x350" // "small: 75x75, thumb: 260x300, big: 560x650"
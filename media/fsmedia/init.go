@@ -6,6 +6,8 @@ import (
 	"github.com/ottemo/foundation/api"
 	"github.com/ottemo/foundation/db"
 	"github.com/ottemo/foundation/env"
+	"github.com/ottemo/foundation/env/health"
+	"github.com/ottemo/foundation/env/jobs"
 	"github.com/ottemo/foundation/media"
 	"github.com/ottemo/foundation/utils"
 )
@@ -24,6 +26,10 @@ func init() {
 
 		api.RegisterOnRestServiceStart(setupAPI)
 
+		if err := jobs.RegisterHandler(ConstJobTypeResize, 1, instance.resizeJob); err != nil {
+			_ = env.ErrorDispatch(err)
+		}
+
 		// process of resizing images on media start
 		//		media.RegisterOnMediaStorageStart(instance.ResizeAllMediaImages)
 	}
@@ -67,11 +73,27 @@ func (it *FilesystemMediaStorage) setupOnIniConfigStart() error {
 		it.storageFolder += "/"
 	}
 
+	if err := health.RegisterProbe("media", health.ConstKindReadiness, true, it.probe); err != nil {
+		_ = env.ErrorDispatch(err)
+	}
+
 	it.setupCheckDone()
 
 	return nil
 }
 
+// probe is a readiness health probe checking media storage folder is accessible
+func (it *FilesystemMediaStorage) probe() error {
+	fileInfo, err := os.Stat(it.storageFolder)
+	if err != nil {
+		return err
+	}
+	if !fileInfo.IsDir() {
+		return env.Error(ConstErrorModule, ConstErrorLevel, "e7b3c1a9-5d28-4f06-8c4e-2a9f6b0d3e15", "media storage '"+it.storageFolder+"' is not a folder")
+	}
+	return nil
+}
+
 // setupOnDatabaseStart is a initialization based on config service
 func (it *FilesystemMediaStorage) setupOnDatabaseStart() error {
 
@@ -17,14 +17,20 @@ var (
 
 // SetKey changes a key that package using for crypto/cipher algorithm
 func SetKey(key []byte) error {
-	if diff := aes.BlockSize - len(key)%aes.BlockSize; diff > 0 {
+	cryptKey = MakeKey(key)
+	return nil
+}
+
+// MakeKey pads given key to the form SetKey() uses, so it could be given to "WithKey" functions
+func MakeKey(key []byte) []byte {
+	result := append([]byte{}, key...)
+	if diff := aes.BlockSize - len(result)%aes.BlockSize; diff > 0 {
 		for diff > 0 {
-			key = append(key, 0)
+			result = append(result, 0)
 			diff--
 		}
 	}
-	cryptKey = key
-	return nil
+	return result
 }
 
 // GetKey returns a key used in crypto/cipher algorithm
@@ -38,15 +44,20 @@ func GetKey() []byte {
 
 // EncryptString encrypts string with crypto/cipher, salting it and makes base64.StdEncoding, returns blank string if encoding fails
 func EncryptString(data string) string {
+	return EncryptStringWithKey(data, GetKey())
+}
+
+// EncryptStringWithKey does same as EncryptString() using given key instead of package one
+func EncryptStringWithKey(data string, cryptKey []byte) string {
 	// cypher encryption
-	result, err := EncryptData([]byte(data))
+	result, err := EncryptDataWithKey([]byte(data), cryptKey)
 	if err != nil {
 		return ""
 	}
 
 	// salting
 	salt := []byte{':'}
-	if cryptKey := GetKey(); len(cryptKey) > 0 {
+	if len(cryptKey) > 0 {
 		salt = append(salt, cryptKey[0])
 	}
 	result = append(result, salt...)
@@ -57,6 +68,11 @@ func EncryptString(data string) string {
 
 // DecryptString decodes base64.StdEncoding string un-salting it and then decrypts it with crypto/cipher, returns original value or error
 func DecryptString(data string) string {
+	return DecryptStringWithKey(data, GetKey())
+}
+
+// DecryptStringWithKey does same as DecryptString() using given key instead of package one
+func DecryptStringWithKey(data string, cryptKey []byte) string {
 
 	// base64 decoding
 	decodedData, err := base64.StdEncoding.DecodeString(data)
@@ -66,7 +82,7 @@ func DecryptString(data string) string {
 
 	// checking and removing salt
 	salt := ":"
-	if cryptKey := GetKey(); len(cryptKey) > 0 {
+	if len(cryptKey) > 0 {
 		salt += string(cryptKey[0])
 	}
 	saltIdx := len(decodedData) - len(salt)
@@ -76,7 +92,7 @@ func DecryptString(data string) string {
 	decodedData = decodedData[0:saltIdx]
 
 	// making cypher decryption
-	result, err := DecryptData(decodedData)
+	result, err := DecryptDataWithKey(decodedData, cryptKey)
 	if err != nil {
 		return data
 	}
@@ -86,9 +102,15 @@ func DecryptString(data string) string {
 
 // EncryptData encrypts given data with crypto/cipher algorithm
 func EncryptData(data []byte) ([]byte, error) {
+	return EncryptDataWithKey(data, GetKey())
+}
+
+// EncryptDataWithKey encrypts given data with crypto/cipher algorithm using given key instead of package one
+//   - key length should be 16, 24 or 32 bytes
+func EncryptDataWithKey(data []byte, key []byte) ([]byte, error) {
 	var buffer bytes.Buffer
 
-	writer, err := EncryptWriter(&buffer)
+	writer, err := EncryptWriterWithKey(&buffer, key)
 	if err != nil {
 		return nil, err
 	}
@@ -103,9 +125,14 @@ func EncryptData(data []byte) ([]byte, error) {
 
 // DecryptData decrypts given data with crypto/cipher algorithm
 func DecryptData(encodedData []byte) ([]byte, error) {
+	return DecryptDataWithKey(encodedData, GetKey())
+}
+
+// DecryptDataWithKey decrypts given data with crypto/cipher algorithm using given key instead of package one
+func DecryptDataWithKey(encodedData []byte, key []byte) ([]byte, error) {
 	result := make([]byte, len(encodedData))
 
-	reader, err := EncryptReader(bytes.NewReader(encodedData))
+	reader, err := EncryptReaderWithKey(bytes.NewReader(encodedData), key)
 	if err != nil {
 		return nil, err
 	}
@@ -120,8 +147,12 @@ func DecryptData(encodedData []byte) ([]byte, error) {
 
 // EncryptReader decrypts given stream with crypto/cipher algorithm
 func EncryptReader(rawReader io.Reader) (io.Reader, error) {
+	return EncryptReaderWithKey(rawReader, GetKey())
+}
+
+// EncryptReaderWithKey decrypts given stream with crypto/cipher algorithm using given key instead of package one
+func EncryptReaderWithKey(rawReader io.Reader, cryptKey []byte) (io.Reader, error) {
 
-	cryptKey := GetKey()
 	cipherBlock, err := aes.NewCipher(cryptKey)
 	if err != nil {
 		return nil, err
@@ -135,8 +166,12 @@ func EncryptReader(rawReader io.Reader) (io.Reader, error) {
 
 // EncryptWriter encrypts given stream with crypto/cipher algorithm
 func EncryptWriter(rawWriter io.Writer) (io.Writer, error) {
+	return EncryptWriterWithKey(rawWriter, GetKey())
+}
+
+// EncryptWriterWithKey encrypts given stream with crypto/cipher algorithm using given key instead of package one
+func EncryptWriterWithKey(rawWriter io.Writer, cryptKey []byte) (io.Writer, error) {
 
-	cryptKey := GetKey()
 	cipherBlock, err := aes.NewCipher(cryptKey)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestEncryptStringWithKey(t *testing.T) {
+	oldKey := MakeKey([]byte("old key"))
+	newKey := MakeKey([]byte("new key"))
+
+	encrypted := EncryptStringWithKey("secret value", oldKey)
+	if encrypted == "secret value" {
+		t.Fatal("value was not encrypted")
+	}
+
+	reEncrypted := EncryptStringWithKey(DecryptStringWithKey(encrypted, oldKey), newKey)
+	if value := DecryptStringWithKey(reEncrypted, newKey); value != "secret value" {
+		t.Errorf("unexpected value decrypted with new key: %q", value)
+	}
+
+	if value := DecryptStringWithKey(reEncrypted, oldKey); value == "secret value" {
+		t.Error("value re-encrypted with new key was decrypted with old key")
+	}
+}
+
+func TestMakeKeyLength(t *testing.T) {
+	for length := 1; length < 32; length++ {
+		key := MakeKey([]byte("0123456789abcdef0123456789abcdef")[:length])
+		if value := DecryptStringWithKey(EncryptStringWithKey("secret value", key), key); value != "secret value" {
+			t.Errorf("key of %d characters could not be used, got %q", length, value)
+		}
+	}
+
+	if EncryptStringWithKey("secret value", MakeKey([]byte("0123456789abcdef0123456789abcdef"))) != "" {
+		t.Error("key of 32 characters is padded to a length cipher should not accept")
+	}
+}
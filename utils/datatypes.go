@@ -461,6 +461,8 @@ func InterfaceToFloat64(value interface{}) float64 {
 	switch typedValue := value.(type) {
 	case float64:
 		return typedValue
+	case Money:
+		return typedValue.Float64()
 	case int64:
 		return float64(typedValue)
 	case int:
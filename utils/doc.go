@@ -62,6 +62,16 @@ considered that application code will use utils package as often as can.
       fmt.Println(matched, err)
 
 
+"money.go" - contains fixed-point Money type amounts arithmetic should be made with instead of float64.
+
+  Example:
+  --------
+      price := utils.InterfaceToMoney("9.99")
+      discount := price.Mul(3).Percent(15, utils.ConstRoundingHalfUp).Round(utils.ConstRoundingHalfUp)
+      parts := discount.Allocate([]utils.Money{price, price.Mul(2)}, utils.ConstRoundingHalfUp)
+      fmt.Println(discount, parts, discount.Float64())
+
+
 "crypt.go" - provides an centralized way for bi-directional crypt of secure data.
 
   Notes:
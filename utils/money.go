@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Money related constants
+const (
+	ConstMoneyScale     = 10000 // Money units within one currency unit, 4 decimal places are kept for intermediate amounts
+	ConstMoneyPrecision = 2     // decimal places amounts are rounded to
+
+	ConstRoundingHalfUp   = "half_up"   // half is rounded away from zero
+	ConstRoundingHalfEven = "half_even" // half is rounded to nearest even value (banker's rounding)
+
+	moneyRoundingUnit = ConstMoneyScale / 100 // Money units within minimal rounded amount, 10^(4-ConstMoneyPrecision)
+)
+
+// Money is a fixed-point currency amount counted in 1/ConstMoneyScale parts of currency unit, it is used for amount
+// arithmetic instead of float64 which can not represent decimal fractions exactly
+type Money int64
+
+// MoneyFromFloat converts float64 amount to Money, value is rounded to nearest Money unit
+func MoneyFromFloat(value float64) Money {
+	return Money(math.Round(value * ConstMoneyScale))
+}
+
+// InterfaceToMoney converts interface{} to Money, decimal strings are converted exactly
+func InterfaceToMoney(value interface{}) Money {
+	switch typedValue := value.(type) {
+	case Money:
+		return typedValue
+	case int:
+		return Money(typedValue) * ConstMoneyScale
+	case int64:
+		return Money(typedValue) * ConstMoneyScale
+	case float64:
+		return MoneyFromFloat(typedValue)
+	case string:
+		rat, ok := new(big.Rat).SetString(strings.TrimSpace(typedValue))
+		if !ok {
+			return 0
+		}
+		rat.Mul(rat, big.NewRat(ConstMoneyScale, 1))
+		return Money(roundQuotient(rat.Num(), rat.Denom(), ConstRoundingHalfUp))
+	}
+
+	return MoneyFromFloat(InterfaceToFloat64(value))
+}
+
+// IsRoundingMode checks given value to be a known rounding mode
+func IsRoundingMode(mode string) bool {
+	return mode == ConstRoundingHalfUp || mode == ConstRoundingHalfEven
+}
+
+// roundQuotient divides numerator by denominator and rounds result to integer using given rounding mode
+func roundQuotient(numerator *big.Int, denominator *big.Int, mode string) int64 {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient.Int64()
+	}
+
+	// doubled remainder compared to denominator tells whether truncated part is less, equal or more than half
+	doubledRemainder := new(big.Int).Abs(remainder)
+	doubledRemainder.Lsh(doubledRemainder, 1)
+	comparison := doubledRemainder.Cmp(new(big.Int).Abs(denominator))
+
+	if comparison > 0 || (comparison == 0 && (mode != ConstRoundingHalfEven || quotient.Bit(0) == 1)) {
+		if numerator.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient.Int64()
+}
+
+// Float64 returns amount as float64
+func (it Money) Float64() float64 {
+	return float64(it) / ConstMoneyScale
+}
+
+// Round rounds amount to money precision using given rounding mode
+func (it Money) Round(mode string) Money {
+	return Money(roundQuotient(big.NewInt(int64(it)), big.NewInt(moneyRoundingUnit), mode)) * moneyRoundingUnit
+}
+
+// Cents returns amount rounded to money precision as a number of minimal currency units (cents)
+func (it Money) Cents(mode string) int64 {
+	return int64(it.Round(mode)) / moneyRoundingUnit
+}
+
+// Mul multiplies amount by quantity
+func (it Money) Mul(qty int) Money {
+	return it * Money(qty)
+}
+
+// Percent returns given percent of amount, result is rounded to Money unit but not to money precision
+//   - percent is taken with 4 decimal places, so 8.875% is exact
+func (it Money) Percent(percent float64, mode string) Money {
+	numerator := new(big.Int).Mul(big.NewInt(int64(it)), big.NewInt(int64(MoneyFromFloat(percent))))
+	return Money(roundQuotient(numerator, big.NewInt(100*ConstMoneyScale), mode))
+}
+
+// Convert multiplies amount by currency exchange rate, result is rounded to money precision using given rounding mode
+//   - rate is taken as its shortest decimal representation, so 1.1 is exact
+func (it Money) Convert(rate float64, mode string) Money {
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		return 0
+	}
+	rat.Mul(rat, new(big.Rat).SetInt64(int64(it)))
+	rat.Mul(rat, big.NewRat(1, moneyRoundingUnit))
+
+	return Money(roundQuotient(rat.Num(), rat.Denom(), mode)) * moneyRoundingUnit
+}
+
+// Allocate splits amount into parts proportional to given weights, parts are rounded to money precision and their
+// sum is exactly the amount rounded to money precision
+//   - units left after rounding are given one by one to parts having biggest rounding remainders
+//   - negative weights are taken as zero ones, amount is split evenly if weights sum is zero
+func (it Money) Allocate(weights []Money, mode string) []Money {
+	result := make([]Money, len(weights))
+	if len(weights) == 0 {
+		return result
+	}
+
+	units := big.NewInt(int64(it.Round(mode) / moneyRoundingUnit))
+
+	normalized := make([]int64, len(weights))
+	totalWeight := big.NewInt(0)
+	for idx, weight := range weights {
+		if weight > 0 {
+			normalized[idx] = int64(weight)
+			totalWeight.Add(totalWeight, big.NewInt(int64(weight)))
+		}
+	}
+	if totalWeight.Sign() == 0 {
+		for idx := range normalized {
+			normalized[idx] = 1
+		}
+		totalWeight.SetInt64(int64(len(normalized)))
+	}
+
+	remainders := make([]*big.Int, len(weights))
+	allocated := int64(0)
+	for idx, weight := range normalized {
+		share, remainder := new(big.Int).QuoRem(new(big.Int).Mul(units, big.NewInt(weight)), totalWeight, new(big.Int))
+		result[idx] = Money(share.Int64())
+		remainders[idx] = remainder.Abs(remainder)
+		allocated += share.Int64()
+	}
+
+	// units left are the same sign as amount and there are less of them than parts
+	left := units.Int64() - allocated
+	step := Money(1)
+	if left < 0 {
+		step, left = -1, -left
+	}
+
+	order := make([]int, len(weights))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+	for _, idx := range order[:left] {
+		result[idx] += step
+	}
+
+	for idx := range result {
+		result[idx] *= moneyRoundingUnit
+	}
+
+	return result
+}
+
+// String returns amount as decimal string of money precision, sub-precision digits are kept if there are any
+func (it Money) String() string {
+	sign := ""
+	value := int64(it)
+	if value < 0 {
+		sign, value = "-", -value
+	}
+
+	fraction := strconv.FormatInt(value%ConstMoneyScale+ConstMoneyScale, 10)[1:]
+	for len(fraction) > ConstMoneyPrecision && strings.HasSuffix(fraction, "0") {
+		fraction = fraction[:len(fraction)-1]
+	}
+
+	return sign + strconv.FormatInt(value/ConstMoneyScale, 10) + "." + fraction
+}
+
+// MarshalJSON represents amount as JSON number
+func (it Money) MarshalJSON() ([]byte, error) {
+	return []byte(it.String()), nil
+}
+
+// UnmarshalJSON takes amount from JSON number or string
+func (it *Money) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), "\"")
+	if value != "null" {
+		*it = InterfaceToMoney(value)
+	}
+	return nil
+}
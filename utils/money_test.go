@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestMoneyConversion(t *testing.T) {
+	if x := InterfaceToMoney("19.99"); x != 199900 {
+		t.Error("incorect result:", x)
+	}
+	if x := InterfaceToMoney(19.99); x != 199900 {
+		t.Error("incorect result:", x)
+	}
+	if x := InterfaceToMoney(-0.1 - 0.2); x != -3000 {
+		t.Error("incorect result:", x)
+	}
+	if x := InterfaceToMoney(3); x != 30000 {
+		t.Error("incorect result:", x)
+	}
+
+	if x := InterfaceToMoney("12.5").String(); x != "12.50" {
+		t.Error("incorect result:", x)
+	}
+	if x := InterfaceToMoney("-0.0125").String(); x != "-0.0125" {
+		t.Error("incorect result:", x)
+	}
+	if x := InterfaceToFloat64(InterfaceToMoney("32.87")); x != 32.87 {
+		t.Error("incorect result:", x)
+	}
+}
+
+func TestMoneyRounding(t *testing.T) {
+	cases := []struct {
+		value    string
+		mode     string
+		expected string
+	}{
+		{"32.865", ConstRoundingHalfUp, "32.87"},
+		{"-32.865", ConstRoundingHalfUp, "-32.87"},
+		{"32.865", ConstRoundingHalfEven, "32.86"},
+		{"32.875", ConstRoundingHalfEven, "32.88"},
+		{"-32.865", ConstRoundingHalfEven, "-32.86"},
+		{"32.8651", ConstRoundingHalfEven, "32.87"},
+		{"0.0045", ConstRoundingHalfUp, "0.00"},
+	}
+
+	for _, testCase := range cases {
+		if x := InterfaceToMoney(testCase.value).Round(testCase.mode).String(); x != testCase.expected {
+			t.Error("incorect result for", testCase.value, testCase.mode, ":", x)
+		}
+	}
+
+	if x := InterfaceToMoney("10.00").Percent(8.875, ConstRoundingHalfUp); x != InterfaceToMoney("0.8875") {
+		t.Error("incorect result:", x)
+	}
+
+	// 19.99 * 100 is 1998.9999999999998 as float64
+	if x := MoneyFromFloat(19.99).Cents(ConstRoundingHalfUp); x != 1999 {
+		t.Error("incorect cents:", x)
+	}
+
+	// 10.05 * 1.1 is 11.055, which is 11.055000000000001 as float64
+	if x := InterfaceToMoney("10.05").Convert(1.1, ConstRoundingHalfEven).String(); x != "11.06" {
+		t.Error("incorect converted amount:", x)
+	}
+	if x := InterfaceToMoney("10.15").Convert(1.1, ConstRoundingHalfEven).String(); x != "11.16" {
+		t.Error("incorect converted amount:", x)
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	weights := []Money{InterfaceToMoney(10), InterfaceToMoney(10), InterfaceToMoney(10)}
+
+	parts := InterfaceToMoney("-10.00").Allocate(weights, ConstRoundingHalfUp)
+	var sum Money
+	for _, part := range parts {
+		sum += part
+	}
+	if sum != InterfaceToMoney("-10.00") {
+		t.Error("parts sum differs from amount:", parts)
+	}
+	if parts[0] != InterfaceToMoney("-3.34") || parts[1] != InterfaceToMoney("-3.33") {
+		t.Error("incorect result:", parts)
+	}
+
+	parts = InterfaceToMoney("1.00").Allocate([]Money{0, InterfaceToMoney(3), InterfaceToMoney(1)}, ConstRoundingHalfUp)
+	if parts[0] != 0 || parts[1] != InterfaceToMoney("0.75") || parts[2] != InterfaceToMoney("0.25") {
+		t.Error("incorect result:", parts)
+	}
+
+	parts = InterfaceToMoney("0.05").Allocate([]Money{0, 0}, ConstRoundingHalfUp)
+	if parts[0]+parts[1] != InterfaceToMoney("0.05") {
+		t.Error("incorect result:", parts)
+	}
+}